@@ -9,6 +9,7 @@ import (
 	"transport-app/app/adapter/out/natspublisher"
 	"transport-app/app/domain"
 	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/jwt"
 	"transport-app/app/shared/infrastructure/observability"
 	"transport-app/app/shared/sharedcontext"
 
@@ -23,12 +24,14 @@ func init() {
 		confirmDeliveries,
 		httpserver.New,
 		natspublisher.NewApplicationEvents,
-		observability.NewObservability)
+		observability.NewObservability,
+		jwt.NewJWTServiceFromConfig)
 }
 func confirmDeliveries(
 	s httpserver.Server,
 	publish natspublisher.ApplicationEvents,
-	obs observability.Observability) {
+	obs observability.Observability,
+	jwtService *jwt.JWTService) {
 	fuego.Post(s.Manager, "/orders/deliveries",
 		func(c fuego.ContextWithBody[request.ConfirmDeliveriesRequest]) (response.ConfirmDeliveriesResponse, error) {
 			spanCtx, span := obs.Tracer.Start(c.Context(), "confirmDeliveries")
@@ -47,6 +50,15 @@ func confirmDeliveries(
 				}
 			}
 
+			// Forzar transiciones fuera de la tabla es una operación de administrador
+			if requestBody.ManualChange.Override && !jwt.HasScope(c.Request(), jwtService, jwt.AdminScope) {
+				return response.ConfirmDeliveriesResponse{}, fuego.HTTPError{
+					Title:  "manual override not allowed",
+					Detail: "overriding status transitions requires the " + jwt.AdminScope + " scope",
+					Status: http.StatusForbidden,
+				}
+			}
+
 			eventPayload, _ := json.Marshal(requestBody)
 
 			eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
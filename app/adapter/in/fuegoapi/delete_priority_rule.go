@@ -0,0 +1,83 @@
+package fuegoapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		deletePriorityRule,
+		httpserver.New,
+		tidbrepository.NewDeletePriorityRule,
+		observability.NewObservability)
+}
+
+func deletePriorityRule(
+	s httpserver.Server,
+	deletePriorityRule tidbrepository.DeletePriorityRule,
+	obs observability.Observability) {
+	fuego.Delete(s.Manager, "/optimize/priority-rules",
+		func(c fuego.ContextNoBody) (response.DeletePriorityRuleResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "deletePriorityRule")
+			defer span.End()
+
+			// La regla se identifica igual que al crearla: categoría y días a la fecha prometida
+			rule := domain.PriorityRule{ServiceCategory: c.QueryParam("serviceCategory")}
+			if maxDays := c.QueryParam("maxDaysToPromisedDate"); maxDays != "" {
+				days, err := strconv.Atoi(maxDays)
+				if err != nil {
+					return response.DeletePriorityRuleResponse{}, fuego.HTTPError{
+						Title:  "error deleting priority rule",
+						Detail: "maxDaysToPromisedDate must be an integer",
+						Status: http.StatusBadRequest,
+					}
+				}
+				rule.MaxDaysToPromisedDate = &days
+			}
+
+			err := deletePriorityRule(spanCtx, rule)
+			if errors.Is(err, tidbrepository.ErrPriorityRuleNotFound) {
+				return response.DeletePriorityRuleResponse{}, fuego.HTTPError{
+					Title:  "priority rule not found",
+					Detail: err.Error(),
+					Status: http.StatusNotFound,
+				}
+			}
+			if err != nil {
+				return response.DeletePriorityRuleResponse{}, fuego.HTTPError{
+					Title:  "error deleting priority rule",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			obs.Logger.InfoContext(spanCtx,
+				"PRIORITY_RULE_DELETED",
+				slog.String("serviceCategory", rule.ServiceCategory))
+
+			return response.DeletePriorityRuleResponse{
+				Message: "Priority rule deleted successfully",
+				Status:  "deleted",
+			}, nil
+		},
+		option.Summary("delete optimization priority rule"),
+		option.Description("Deletes the tenant rule identified by its service category and max days to the promised date"),
+		option.Query("serviceCategory", "Service category of the rule; empty for rules that apply to every category"),
+		option.QueryInt("maxDaysToPromisedDate", "Max days to the promised date of the rule; omit for rules that ignore the date"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagPlanning))
+}
@@ -0,0 +1,85 @@
+package fuegoapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/natspublisher"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		driverLocations,
+		httpserver.New,
+		natspublisher.NewApplicationEvents,
+		observability.NewObservability)
+}
+
+func driverLocations(
+	s httpserver.Server,
+	publish natspublisher.ApplicationEvents,
+	obs observability.Observability) {
+	fuego.Post(s.Manager, "/routes/locations",
+		func(c fuego.ContextWithBody[request.DriverLocationsRequest]) (response.DriverLocationsResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "driverLocations")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return response.DriverLocationsResponse{}, err
+			}
+
+			if err := requestBody.Validate(); err != nil {
+				return response.DriverLocationsResponse{}, fuego.HTTPError{
+					Title:  "error validating driver locations",
+					Detail: err.Error(),
+					Status: http.StatusBadRequest,
+				}
+			}
+
+			eventPayload, _ := json.Marshal(requestBody)
+
+			eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
+				sharedcontext.EventContext{
+					EntityType: "route",
+					EventType:  "driverLocationsSubmitted",
+				})
+
+			if err := publish(eventCtx, domain.Outbox{
+				Payload: eventPayload,
+			}); err != nil {
+				return response.DriverLocationsResponse{}, fuego.HTTPError{
+					Title:  "error submitting driver locations",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			// Endpoint de alto volumen: se registra el tamaño del lote en vez del payload completo
+			obs.Logger.DebugContext(spanCtx,
+				"DRIVER_LOCATIONS_SUBMITTED",
+				"routeReferenceID", requestBody.Route.ReferenceID,
+				"locations", len(requestBody.Locations))
+
+			return response.DriverLocationsResponse{
+				Accepted: len(requestBody.Locations),
+				Message:  "Driver locations submitted successfully",
+			}, nil
+		},
+		option.Summary("submit driver locations"),
+		option.Description("Batched GPS pings reported by the vehicle while executing a route. The last known position and a downsampled breadcrumb trail are updated asynchronously"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("channel", "api channel", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagLocations))
+}
@@ -4,12 +4,16 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"time"
 	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
 	"transport-app/app/adapter/out/natspublisher"
 	"transport-app/app/domain"
+	canonicaljson "transport-app/app/shared/caonincaljson"
 	"transport-app/app/shared/infrastructure/httpserver"
 	"transport-app/app/shared/infrastructure/observability"
 	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 	"github.com/go-fuego/fuego"
@@ -21,9 +25,10 @@ func init() {
 		fleetOptimizationAgent,
 		httpserver.New,
 		natspublisher.NewApplicationEvents,
+		usecase.NewSaveOptimizationJob,
 		observability.NewObservability)
 }
-func fleetOptimizationAgent(s httpserver.Server, publish natspublisher.ApplicationEvents, obs observability.Observability) {
+func fleetOptimizationAgent(s httpserver.Server, publish natspublisher.ApplicationEvents, saveOptimizationJob usecase.SaveOptimizationJob, obs observability.Observability) {
 	fuego.Post(s.Manager, "/agents/optimize/fleet",
 		func(c fuego.ContextWithBody[request.AgentOptimizationRequest]) (any, error) {
 			spanCtx, span := obs.Tracer.Start(c.Context(), "agentOptimization")
@@ -42,6 +47,19 @@ func fleetOptimizationAgent(s httpserver.Server, publish natspublisher.Applicati
 
 			eventPayload, _ := json.Marshal(requestBody)
 
+			// El consumidor del agente reenvía este job al de la optimización normalizada
+			jobID, err := canonicaljson.HashKey(spanCtx, "agent_optimize_fleet", requestBody)
+			if err != nil {
+				return nil, err
+			}
+			if err := saveOptimizationJob(spanCtx, domain.NewOptimizationJob(jobID, time.Now())); err != nil {
+				return nil, fuego.HTTPError{
+					Title:  "error requesting agent optimization",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
 			eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
 				sharedcontext.EventContext{
 					EntityType: "agentOptimization",
@@ -60,8 +78,12 @@ func fleetOptimizationAgent(s httpserver.Server, publish natspublisher.Applicati
 
 			obs.Logger.InfoContext(spanCtx,
 				"AGENT_OPTIMIZATION_REQUEST_SUBMITTED",
+				slog.String("jobID", jobID),
 				slog.Any("payload", requestBody))
 
-			return "ok", nil
+			return response.OptimizationResponse{
+				TraceID: span.SpanContext().TraceID().String(),
+				JobID:   jobID,
+			}, nil
 		}, option.Summary("fleetOptimizationAgent"))
 }
@@ -0,0 +1,48 @@
+package fuegoapi
+
+import (
+	"net/http"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/usecase"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		getLiveDriverPositions,
+		httpserver.New,
+		usecase.NewFindLiveDriverPositions,
+		observability.NewObservability)
+}
+
+func getLiveDriverPositions(
+	s httpserver.Server,
+	findLiveDriverPositions usecase.FindLiveDriverPositions,
+	obs observability.Observability) {
+	fuego.Get(s.Manager, "/routes/positions",
+		func(c fuego.ContextNoBody) (response.LiveDriverPositionsResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "getLiveDriverPositions")
+			defer span.End()
+
+			positions, err := findLiveDriverPositions(spanCtx)
+			if err != nil {
+				return response.LiveDriverPositionsResponse{}, fuego.HTTPError{
+					Title:  "error getting driver positions",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return response.MapLiveDriverPositionsResponse(positions), nil
+		},
+		option.Summary("get live driver positions"),
+		option.Description("Last known position of every active route of the tenant, i.e. routes that reported a location within the configured window and still have delivery units in transit"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagLocations))
+}
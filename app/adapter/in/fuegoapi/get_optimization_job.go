@@ -0,0 +1,57 @@
+package fuegoapi
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/usecase"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		getOptimizationJob,
+		httpserver.New,
+		usecase.NewFindOptimizationJob,
+		observability.NewObservability)
+}
+
+func getOptimizationJob(
+	s httpserver.Server,
+	findOptimizationJob usecase.FindOptimizationJob,
+	obs observability.Observability) {
+	fuego.Get(s.Manager, "/optimize/fleet/{jobId}",
+		func(c fuego.ContextNoBody) (response.OptimizationJobResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "getOptimizationJob")
+			defer span.End()
+
+			job, err := findOptimizationJob(spanCtx, c.PathParam("jobId"))
+			if errors.Is(err, usecase.ErrOptimizationJobNotFound) {
+				return response.OptimizationJobResponse{}, fuego.HTTPError{
+					Title:  "optimization job not found",
+					Detail: err.Error(),
+					Status: http.StatusNotFound,
+				}
+			}
+			if err != nil {
+				return response.OptimizationJobResponse{}, fuego.HTTPError{
+					Title:  "error getting optimization job",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return response.MapOptimizationJobResponse(job, time.Now()), nil
+		},
+		option.Summary("get fleet optimization job"),
+		option.Description("Status, timings and resulting routes of an optimization requested through /optimize/fleet or /agents/optimize/fleet"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags("optimization"))
+}
@@ -0,0 +1,262 @@
+package fuegoapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/natspublisher"
+	"transport-app/app/adapter/out/storjbucket"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/infrastructure/storj"
+	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/shared/spreadsheet"
+	"transport-app/app/usecase"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxOrderImportFileSize limita el tamaño del archivo subido a POST /orders/import
+	maxOrderImportFileSize = 10 << 20
+	// maxOrderImportInlineErrors limita los errores devueltos en la respuesta; el reporte los incluye todos
+	maxOrderImportInlineErrors = 100
+	orderImportErrorReportTTL  = 7 * 24 * time.Hour
+)
+
+func init() {
+	ioc.Registry(
+		importOrders,
+		httpserver.New,
+		natspublisher.NewApplicationEvents,
+		observability.NewObservability,
+		usecase.NewResolveOrderImportMapping,
+		tidbrepository.NewUpdateTenantOrderImportMapping,
+		storjbucket.NewTransportAppBucket)
+}
+
+func importOrders(
+	s httpserver.Server,
+	publish natspublisher.ApplicationEvents,
+	obs observability.Observability,
+	resolveMapping usecase.ResolveOrderImportMapping,
+	saveMapping tidbrepository.UpdateTenantOrderImportMapping,
+	storjManager storj.UplinkManager) {
+	fuego.Post(s.Manager, "/orders/import",
+		func(c fuego.ContextNoBody) (response.ImportOrdersResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "importOrders")
+			defer span.End()
+
+			r := c.Request()
+			r.Body = http.MaxBytesReader(c.Response(), r.Body, maxOrderImportFileSize)
+			if err := r.ParseMultipartForm(maxOrderImportFileSize); err != nil {
+				return response.ImportOrdersResponse{}, importOrdersBadRequest(fmt.Errorf("invalid multipart form: %w", err))
+			}
+			file, fileHeader, err := r.FormFile("file")
+			if err != nil {
+				return response.ImportOrdersResponse{}, importOrdersBadRequest(fmt.Errorf("file is required: %w", err))
+			}
+			defer file.Close()
+			data, err := io.ReadAll(file)
+			if err != nil {
+				return response.ImportOrdersResponse{}, importOrdersBadRequest(err)
+			}
+
+			sheet, err := spreadsheet.Read(fileHeader.Filename, data)
+			if err != nil {
+				return response.ImportOrdersResponse{}, importOrdersBadRequest(err)
+			}
+
+			dryRun, _ := strconv.ParseBool(r.FormValue("dryRun"))
+			persistMapping, _ := strconv.ParseBool(r.FormValue("saveMapping"))
+
+			// Un mapeo explícito (por ejemplo, el propuesto en un dryRun y corregido por el usuario) tiene prioridad
+			var (
+				mapping       domain.OrderImportMapping
+				mappingSource string
+			)
+			if raw := r.FormValue("mapping"); raw != "" {
+				var columns map[string]string
+				if err := json.Unmarshal([]byte(raw), &columns); err != nil {
+					return response.ImportOrdersResponse{}, importOrdersBadRequest(fmt.Errorf("invalid mapping: %w", err))
+				}
+				mapping = usecase.SanitizeOrderImportMapping(columns, sheet.Headers)
+				mappingSource = usecase.OrderImportMappingSourceRequest
+			} else {
+				mapping, mappingSource, err = resolveMapping(spanCtx, sheet)
+				if err != nil {
+					return response.ImportOrdersResponse{}, fuego.HTTPError{
+						Title:  "error resolving import mapping",
+						Detail: err.Error(),
+						Status: http.StatusInternalServerError,
+					}
+				}
+			}
+			if mapping.Columns[request.OrderImportKeyReferenceID] == "" {
+				return response.ImportOrdersResponse{}, importOrdersBadRequest(
+					errors.New("mapping must assign a column to referenceID"))
+			}
+
+			if persistMapping && mappingSource != usecase.OrderImportMappingSourceSaved {
+				if err := saveMapping(spanCtx, mapping); err != nil {
+					return response.ImportOrdersResponse{}, fuego.HTTPError{
+						Title:  "error saving import mapping",
+						Detail: err.Error(),
+						Status: http.StatusInternalServerError,
+					}
+				}
+			}
+
+			orders, rowErrors := request.MapOrderImportRows(sheet.Headers, sheet.Rows, sheet.RowNumbers, mapping.Columns)
+
+			accepted := 0
+			for _, order := range orders {
+				if err := order.Request.Map(spanCtx).Validate(); err != nil {
+					rowErrors = appendOrderRowErrors(rowErrors, order, err)
+					continue
+				}
+				if dryRun {
+					accepted++
+					continue
+				}
+
+				eventPayload, _ := json.Marshal(order.Request)
+				eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
+					sharedcontext.EventContext{
+						EntityType: "order",
+						EventType:  "orderSubmitted",
+					})
+				if err := publish(eventCtx, domain.Outbox{
+					Payload: eventPayload,
+				}); err != nil {
+					rowErrors = appendOrderRowErrors(rowErrors, order, fmt.Errorf("error submitting order: %w", err))
+					continue
+				}
+				accepted++
+			}
+			sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+
+			resp := response.ImportOrdersResponse{
+				Message:        "Orders import submitted",
+				DryRun:         dryRun,
+				MappingSource:  mappingSource,
+				Mapping:        mapping.Columns,
+				TotalRows:      len(sheet.Rows),
+				AcceptedOrders: accepted,
+				RejectedRows:   len(rowErrors),
+			}
+			if dryRun {
+				resp.Message = "Orders import validated"
+			}
+			for i, rowError := range rowErrors {
+				if i == maxOrderImportInlineErrors {
+					break
+				}
+				resp.Errors = append(resp.Errors, response.ImportOrdersRowError{
+					Row:         rowError.Row,
+					ReferenceID: rowError.ReferenceID,
+					Error:       rowError.Error,
+				})
+			}
+
+			if len(rowErrors) > 0 {
+				reportURL, err := uploadOrderImportErrorReport(spanCtx, storjManager, sheet, rowErrors)
+				if err != nil {
+					// Las órdenes válidas ya fueron enviadas; el reporte no debe ocultar ese resultado
+					obs.Logger.ErrorContext(spanCtx, "ORDER_IMPORT_ERROR_REPORT_FAILED", slog.Any("error", err))
+				}
+				resp.ErrorReportURL = reportURL
+			}
+
+			obs.Logger.InfoContext(spanCtx,
+				"ORDERS_IMPORT_SUBMITTED",
+				slog.String("file", fileHeader.Filename),
+				slog.String("mappingSource", mappingSource),
+				slog.Bool("dryRun", dryRun),
+				slog.Int("acceptedOrders", resp.AcceptedOrders),
+				slog.Int("rejectedRows", resp.RejectedRows))
+
+			return resp, nil
+		},
+		option.Summary("import orders from a CSV or XLSX file"),
+		option.Description("Multipart form with `file` (.csv or .xlsx), optional `mapping` (JSON object canonical key → column header), "+
+			"`saveMapping` to store the mapping for the tenant and `dryRun` to validate without submitting orders. "+
+			"Rows sharing a referenceID are grouped in one order and rows sharing an LPN in one delivery unit."),
+		option.RequestContentType("multipart/form-data"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("consumer", "api consumer key", param.Required()),
+		option.Header("commerce", "api commerce key", param.Required()),
+		option.Header("channel", "api channel key", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagOrders),
+	)
+}
+
+func importOrdersBadRequest(err error) fuego.HTTPError {
+	return fuego.HTTPError{
+		Title:  "error importing orders",
+		Detail: err.Error(),
+		Status: http.StatusBadRequest,
+	}
+}
+
+// appendOrderRowErrors replica el error de una orden en cada una de sus filas
+func appendOrderRowErrors(rowErrors []request.OrderImportRowError, order request.ImportedOrder, err error) []request.OrderImportRowError {
+	for _, row := range order.Rows {
+		rowErrors = append(rowErrors, request.OrderImportRowError{
+			Row:         row,
+			ReferenceID: order.Request.ReferenceID,
+			Error:       err.Error(),
+		})
+	}
+	return rowErrors
+}
+
+// uploadOrderImportErrorReport sube un CSV con las filas rechazadas (con sus valores originales)
+// y retorna la URL de descarga
+func uploadOrderImportErrorReport(
+	ctx context.Context,
+	storjManager storj.UplinkManager,
+	sheet spreadsheet.Sheet,
+	rowErrors []request.OrderImportRowError) (string, error) {
+	report := spreadsheet.Sheet{
+		Headers: append([]string{"row", "error"}, sheet.Headers...),
+		Rows:    make([][]string, 0, len(rowErrors)),
+	}
+	rowIndexes := make(map[int]int, len(sheet.RowNumbers))
+	for i, rowNumber := range sheet.RowNumbers {
+		rowIndexes[rowNumber] = i
+	}
+	for _, rowError := range rowErrors {
+		reportRow := []string{strconv.Itoa(rowError.Row), rowError.Error}
+		if idx, ok := rowIndexes[rowError.Row]; ok && idx < len(sheet.Rows) {
+			reportRow = append(reportRow, sheet.Rows[idx]...)
+		}
+		report.Rows = append(report.Rows, reportRow)
+	}
+
+	data, err := spreadsheet.WriteCSV(report)
+	if err != nil {
+		return "", err
+	}
+	objectKey := fmt.Sprintf("orders/imports/%s/errors.csv", uuid.New().String())
+	if err := storjManager.Upload(ctx, objectKey, "text/csv", data); err != nil {
+		return "", err
+	}
+	return storjManager.GeneratePublicDownloadURL(ctx, objectKey, orderImportErrorReportTTL)
+}
@@ -4,13 +4,16 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"time"
 	"transport-app/app/adapter/in/fuegoapi/request"
 	"transport-app/app/adapter/in/fuegoapi/response"
 	"transport-app/app/adapter/out/natspublisher"
 	"transport-app/app/domain"
+	canonicaljson "transport-app/app/shared/caonincaljson"
 	"transport-app/app/shared/infrastructure/httpserver"
 	"transport-app/app/shared/infrastructure/observability"
 	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 	"github.com/go-fuego/fuego"
@@ -26,12 +29,14 @@ func init() {
 		optimizeFleet,
 		httpserver.New,
 		natspublisher.NewApplicationEvents,
+		usecase.NewSaveOptimizationJob,
 		observability.NewObservability)
 }
 
 func optimizeFleet(
 	s httpserver.Server,
 	publish natspublisher.ApplicationEvents,
+	saveOptimizationJob usecase.SaveOptimizationJob,
 	obs observability.Observability) {
 	fuego.Post(s.Manager, "/optimize/fleet",
 		func(c fuego.ContextWithBody[request.OptimizeFleetRequest]) (response.OptimizationResponse, error) {
@@ -47,6 +52,19 @@ func optimizeFleet(
 
 			eventPayload, _ := json.Marshal(requestBody)
 
+			// Misma llave de idempotencia que calcula el consumidor de la optimización
+			jobID, err := canonicaljson.HashKey(spanCtx, "optimize_fleet", requestBody)
+			if err != nil {
+				return response.OptimizationResponse{}, err
+			}
+			if err := saveOptimizationJob(spanCtx, domain.NewOptimizationJob(jobID, time.Now())); err != nil {
+				return response.OptimizationResponse{}, fuego.HTTPError{
+					Title:  "error requesting optimization",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
 			eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
 				sharedcontext.EventContext{
 					EntityType: "optimization",
@@ -65,10 +83,12 @@ func optimizeFleet(
 
 			obs.Logger.InfoContext(spanCtx,
 				"OPTIMIZATION_REQUEST_SUBMITTED",
+				slog.String("jobID", jobID),
 				slog.Any("payload", requestBody))
 
 			return response.OptimizationResponse{
 				TraceID: span.SpanContext().TraceID().String(),
+				JobID:   jobID,
 			}, nil
 		}, option.Summary("optimize fleet"), option.Tags("optimization"),
 		option.Header("X-Access-Token", "api access token"),
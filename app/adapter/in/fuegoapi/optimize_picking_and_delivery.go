@@ -1,22 +1,88 @@
 package fuegoapi
-/*
+
 import (
+	"fmt"
+	"log/slog"
+	"net/http"
 	"transport-app/app/adapter/in/fuegoapi/request"
 	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 	"github.com/go-fuego/fuego"
 	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
 )
 
+// maxPickingAndDeliveryVisits limita las visitas que se secuencian de forma síncrona;
+// los recorridos más grandes deben solicitarse en /optimize/fleet
+const maxPickingAndDeliveryVisits = 100
+
 func init() {
-	ioc.Registry(optimizePickingAndDelivery, httpserver.New)
+	ioc.Registry(
+		optimizePickingAndDelivery,
+		httpserver.New,
+		usecase.NewOptimizePickingAndDelivery,
+		observability.NewObservability)
 }
-func optimizePickingAndDelivery(s httpserver.Server) {
+
+func optimizePickingAndDelivery(
+	s httpserver.Server,
+	optimize usecase.OptimizePickingAndDelivery,
+	obs observability.Observability) {
 	fuego.Post(s.Manager, "/optimize/picking-and-delivery",
-		func(c fuego.ContextWithBody[request.OptimizePickingAndDeliveryRequest]) (any, error) {
+		func(c fuego.ContextWithBody[request.OptimizePickingAndDeliveryRequest]) (request.UpsertRouteRequest, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "optimizePickingAndDelivery")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return request.UpsertRouteRequest{}, err
+			}
+			if requestBody.Container.Lpn == "" {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error optimizing picking and delivery",
+					Detail: "container.lpn is required",
+					Status: http.StatusBadRequest,
+				}
+			}
+			if len(requestBody.Visits) == 0 {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error optimizing picking and delivery",
+					Detail: "at least one visit is required",
+					Status: http.StatusBadRequest,
+				}
+			}
+			if len(requestBody.Visits) > maxPickingAndDeliveryVisits {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error optimizing picking and delivery",
+					Detail: fmt.Sprintf("at most %d visits can be optimized synchronously, use /optimize/fleet instead", maxPickingAndDeliveryVisits),
+					Status: http.StatusRequestEntityTooLarge,
+				}
+			}
+
+			ctx := sharedcontext.WithAccessToken(spanCtx, c.Header("X-Access-Token"))
+			route, err := optimize(ctx, requestBody)
+			if err != nil {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error optimizing picking and delivery",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			obs.Logger.InfoContext(spanCtx,
+				"PICKING_AND_DELIVERY_OPTIMIZED",
+				slog.String("container", requestBody.Container.Lpn),
+				slog.Int("visits", len(route.Visits)))
 
-			return "unimplemented", nil
-		}, option.Summary("optimize picking & delivery"), option.Tags("optimization"))
+			return route, nil
+		},
+		option.Summary("optimize picking & delivery"),
+		option.Description("Sequences the deliveries of a single driver container route and stores it against the container LPN"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags("optimization"))
 }
-*/
\ No newline at end of file
@@ -1,22 +1,112 @@
 package fuegoapi
 
 import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
 	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/natspublisher"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
 	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 	"github.com/go-fuego/fuego"
 	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
 )
 
 func init() {
-	ioc.Registry(fixCoordinates, httpserver.New)
+	ioc.Registry(
+		fixCoordinates,
+		httpserver.New,
+		usecase.NewFixOrderDestination,
+		natspublisher.NewApplicationEvents,
+		observability.NewObservability)
 }
-func fixCoordinates(s httpserver.Server) {
+func fixCoordinates(
+	s httpserver.Server,
+	fixOrderDestination usecase.FixOrderDestination,
+	publish natspublisher.ApplicationEvents,
+	obs observability.Observability) {
 	fuego.Post(s.Manager, "/orders/destinations/fix",
-		func(c fuego.ContextWithBody[request.OrderDestinationFixRequest]) (any, error) {
-			return "unimplemented", nil
+		func(c fuego.ContextWithBody[request.OrderDestinationFixRequest]) (response.OrderDestinationFixResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "fixOrderDestination")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return response.OrderDestinationFixResponse{}, err
+			}
+
+			if err := requestBody.Validate(); err != nil {
+				return response.OrderDestinationFixResponse{}, fuego.HTTPError{
+					Title:  "error validating destination fix",
+					Detail: err.Error(),
+					Status: http.StatusBadRequest,
+				}
+			}
+
+			orders, err := fixOrderDestination(spanCtx, requestBody.Map(spanCtx), requestBody.MapManualChange())
+			if errors.Is(err, tidbrepository.ErrOrderNotFound) {
+				return response.OrderDestinationFixResponse{}, fuego.HTTPError{
+					Title:  "error fixing destination",
+					Detail: err.Error(),
+					Status: http.StatusNotFound,
+				}
+			}
+			if err != nil {
+				return response.OrderDestinationFixResponse{}, fuego.HTTPError{
+					Title:  "error fixing destination",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			// Las rutas afectadas ya quedaron marcadas para reoptimizar; el evento informa la
+			// corrección con las coordenadas finales a los suscriptores externos
+			point := orders[0].Destination.AddressInfo.Coordinates.Point
+			requestBody.Destination.Coordinates.Latitude = point.Lat()
+			requestBody.Destination.Coordinates.Longitude = point.Lon()
+			eventPayload, _ := json.Marshal(requestBody)
+
+			eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
+				sharedcontext.EventContext{
+					EntityType: "order",
+					EventType:  "orderDestinationFixed",
+				})
+
+			if err := publish(eventCtx, domain.Outbox{
+				Payload: eventPayload,
+			}); err != nil {
+				return response.OrderDestinationFixResponse{}, fuego.HTTPError{
+					Title:  "error publishing destination fix",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			obs.Logger.InfoContext(spanCtx,
+				"ORDER_DESTINATION_FIXED",
+				slog.Int("orders", len(orders)),
+				slog.String("performedBy", requestBody.ManualChange.PerformedBy),
+				slog.String("reason", requestBody.ManualChange.Reason))
+
+			res := response.OrderDestinationFixResponse{
+				Message: "Destination fixed successfully",
+			}
+			res.Coordinates.Latitude = point.Lat()
+			res.Coordinates.Longitude = point.Lon()
+			return res, nil
 		},
 		option.Summary("fix destination"),
+		option.Description("Replaces the destination address of the given orders, geocoding it when no coordinates are supplied"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
 		option.Tags(tagOrders))
 }
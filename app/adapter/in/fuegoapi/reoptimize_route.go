@@ -0,0 +1,85 @@
+package fuegoapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		reoptimizeRoute,
+		httpserver.New,
+		usecase.NewReoptimizeRoute,
+		observability.NewObservability)
+}
+
+func reoptimizeRoute(
+	s httpserver.Server,
+	reoptimize usecase.ReoptimizeRoute,
+	obs observability.Observability) {
+	fuego.Post(s.Manager, "/routes/{id}/reoptimize",
+		func(c fuego.ContextWithBody[request.ReoptimizeRouteRequest]) (request.UpsertRouteRequest, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "reoptimizeRoute")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return request.UpsertRouteRequest{}, err
+			}
+			if requestBody.CurrentLocation.Latitude == 0 && requestBody.CurrentLocation.Longitude == 0 {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error reoptimizing route",
+					Detail: "currentLocation is required",
+					Status: http.StatusBadRequest,
+				}
+			}
+
+			routeID := c.PathParam("id")
+			ctx := sharedcontext.WithAccessToken(spanCtx, c.Header("X-Access-Token"))
+			revision, err := reoptimize(ctx, routeID, requestBody)
+			if errors.Is(err, usecase.ErrRouteNotFound) {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error reoptimizing route",
+					Detail: err.Error(),
+					Status: http.StatusNotFound,
+				}
+			}
+			if errors.Is(err, usecase.ErrNoPendingVisits) {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error reoptimizing route",
+					Detail: err.Error(),
+					Status: http.StatusConflict,
+				}
+			}
+			if err != nil {
+				return request.UpsertRouteRequest{}, fuego.HTTPError{
+					Title:  "error reoptimizing route",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			obs.Logger.InfoContext(spanCtx,
+				"ROUTE_REOPTIMIZED",
+				slog.String("route", routeID),
+				slog.Int("revision", revision.Revision))
+
+			return revision, nil
+		},
+		option.Summary("reoptimize started route"),
+		option.Description("Re-sequences the pending visits of a started route from the driver's current position and stores a new route revision"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagRoutes))
+}
@@ -11,6 +11,10 @@ import (
 type CancelOrdersRequest struct {
 	ManualChange struct {
 		PerformedBy string `json:"performedBy" example:"juan@example.com"`
+		Reason      string `json:"reason" example:"Corrección solicitada por soporte"`
+		// Permite cancelar unidades cuyo estado actual no lo admite; exige reason y un token
+		// con scope admin
+		Override bool `json:"override" example:"false"`
 	} `json:"manualChange"`
 	Orders []struct {
 		BusinessIdentifiers struct {
@@ -58,6 +62,8 @@ func (r CancelOrdersRequest) Map(ctx context.Context) domain.Route {
 				ConfirmDelivery: domain.ConfirmDelivery{
 					ManualChange: domain.ManualChange{
 						PerformedBy: r.ManualChange.PerformedBy,
+						Reason:      r.ManualChange.Reason,
+						Override:    r.ManualChange.Override,
 					},
 					HandledAt: time.Now(),
 					NonDeliveryReason: domain.NonDeliveryReason{
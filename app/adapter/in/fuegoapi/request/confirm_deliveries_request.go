@@ -12,6 +12,9 @@ type ConfirmDeliveriesRequest struct {
 	ManualChange struct {
 		PerformedBy string `json:"performedBy" example:"juan@example.com"`
 		Reason      string `json:"reason" example:"Corrección tras reclamo de transporte"`
+		// Permite registrar la entrega aunque el estado actual de la unidad no lo admita; exige reason
+		// y un token con scope admin
+		Override bool `json:"override" example:"false"`
 	} `json:"manualChange"`
 
 	Carrier struct {
@@ -56,6 +59,8 @@ type ConfirmDeliveriesRequest struct {
 				Latitude  float64 `json:"latitude" example:"19.432607"`
 				Longitude float64 `json:"longitude" example:"-99.133209"`
 			} `json:"location"`
+			// PIN que el destinatario entrega al conductor cuando el tipo de orden lo exige
+			Pin     string `json:"pin,omitempty" example:"482913"`
 			Failure *struct {
 				Detail      string `json:"detail" example:"no quiso recibir producto porque la caja estaba dañada"`
 				Reason      string `json:"reason" example:"CLIENTE_RECHAZA_ENTREGA"`
@@ -77,7 +82,9 @@ type ConfirmDeliveriesRequest struct {
 			Description string `json:"description" example:"Descripción del producto"`
 			Quantity    int    `json:"quantity" example:"2"`
 			// Para entregas parciales de items
-			DeliveredQuantity int `json:"deliveredQuantity" example:"1"`
+			DeliveredQuantity int    `json:"deliveredQuantity" example:"1"`
+			RejectedQuantity  int    `json:"rejectedQuantity" example:"1"`
+			ReasonCode        string `json:"reasonCode" example:"PRODUCTO_DANADO"`
 		} `json:"items"`
 	} `json:"deliveryUnits"`
 }
@@ -131,10 +138,24 @@ func (r ConfirmDeliveriesRequest) Map(ctx context.Context) domain.Route {
 
 		// Crear los items de la unidad de entrega
 		items := make([]domain.Item, 0, len(du.Items))
+		itemConfirmations := make(domain.ItemConfirmations, 0, len(du.Items))
 		for _, item := range du.Items {
 			items = append(items, domain.Item{
 				Sku: item.Sku,
 			})
+			// Solo los items con cantidades informadas forman parte de una entrega parcial
+			if item.DeliveredQuantity == 0 && item.RejectedQuantity == 0 {
+				continue
+			}
+			itemConfirmations = append(itemConfirmations, domain.ItemConfirmation{
+				Sku:               item.Sku,
+				DeliveredQuantity: item.DeliveredQuantity,
+				RejectedQuantity:  item.RejectedQuantity,
+				ReasonCode:        item.ReasonCode,
+			})
+		}
+		if len(itemConfirmations) == 0 {
+			itemConfirmations = nil
 		}
 
 		// Parsear la fecha de manejo
@@ -151,6 +172,7 @@ func (r ConfirmDeliveriesRequest) Map(ctx context.Context) domain.Route {
 				ManualChange: domain.ManualChange{
 					PerformedBy: r.ManualChange.PerformedBy,
 					Reason:      r.ManualChange.Reason,
+					Override:    r.ManualChange.Override,
 				},
 				HandledAt: handledAt,
 				Latitude:  du.Delivery.Location.Latitude,
@@ -171,6 +193,8 @@ func (r ConfirmDeliveriesRequest) Map(ctx context.Context) domain.Route {
 					FullName:   du.Recipient.FullName,
 					NationalID: du.Recipient.NationalID,
 				},
+				Items:       itemConfirmations,
+				DeliveryPin: du.Delivery.Pin,
 			},
 		}
 
@@ -0,0 +1,71 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"transport-app/app/domain"
+)
+
+func TestConfirmDeliveriesRequest_MapItemConfirmations(t *testing.T) {
+	var input ConfirmDeliveriesRequest
+	if err := json.Unmarshal([]byte(`{
+		"route": {"referenceID": "ROUTE-1"},
+		"deliveryUnits": [{
+			"orderReferenceID": "ORD-1",
+			"lpn": "LPN-1",
+			"delivery": {"handledAt": "2025-06-19T10:00:00Z", "status": "PARTIAL", "pin": "482913"},
+			"items": [
+				{"sku": "SKU-1", "quantity": 2, "deliveredQuantity": 2},
+				{"sku": "SKU-2", "quantity": 3, "deliveredQuantity": 1, "rejectedQuantity": 2, "reasonCode": "PRODUCTO_DANADO"},
+				{"sku": "SKU-3", "quantity": 1}
+			]
+		}, {
+			"orderReferenceID": "ORD-2",
+			"lpn": "LPN-2",
+			"items": [{"sku": "SKU-4", "quantity": 1}]
+		}]
+	}`), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	route := input.Map(context.Background())
+
+	units := map[string]domain.DeliveryUnit{}
+	for _, order := range route.Orders {
+		for _, du := range order.DeliveryUnits {
+			units[du.Lpn] = du
+		}
+	}
+
+	partial := units["LPN-1"]
+	if len(partial.Items) != 3 {
+		t.Errorf("items = %d, want 3", len(partial.Items))
+	}
+	want := domain.ItemConfirmations{
+		{Sku: "SKU-1", DeliveredQuantity: 2},
+		{Sku: "SKU-2", DeliveredQuantity: 1, RejectedQuantity: 2, ReasonCode: "PRODUCTO_DANADO"},
+	}
+	if len(partial.ConfirmDelivery.Items) != len(want) {
+		t.Fatalf("item confirmations = %+v, want %+v", partial.ConfirmDelivery.Items, want)
+	}
+	for i := range want {
+		if partial.ConfirmDelivery.Items[i] != want[i] {
+			t.Errorf("item confirmation %d = %+v, want %+v", i, partial.ConfirmDelivery.Items[i], want[i])
+		}
+	}
+	if partial.ConfirmDelivery.DeliveryPin != "482913" {
+		t.Errorf("delivery pin = %q, want %q", partial.ConfirmDelivery.DeliveryPin, "482913")
+	}
+	if got := partial.DeliveryOutcome(); got != domain.StatusPartiallyDelivered {
+		t.Errorf("outcome = %q, want %q", got, domain.StatusPartiallyDelivered)
+	}
+
+	full := units["LPN-2"]
+	if full.ConfirmDelivery.Items != nil {
+		t.Errorf("unexpected item confirmations %+v", full.ConfirmDelivery.Items)
+	}
+	if got := full.DeliveryOutcome(); got != domain.StatusFinished {
+		t.Errorf("outcome = %q, want %q", got, domain.StatusFinished)
+	}
+}
@@ -0,0 +1,27 @@
+package request
+
+import "transport-app/app/domain"
+
+type DeliveryPinGeneratedWebhookBody struct {
+	OrderReferenceID string `json:"orderReferenceID"`
+	Recipient        struct {
+		FullName string `json:"fullName"`
+		Email    string `json:"email,omitempty"`
+		Phone    string `json:"phone,omitempty"`
+	} `json:"recipient"`
+	Pin string `json:"pin"`
+}
+
+// MapDeliveryPinGeneratedWebhookBody entrega el PIN al integrador para que lo haga llegar
+// al destinatario por su propio canal (SMS, WhatsApp)
+func MapDeliveryPinGeneratedWebhookBody(order domain.Order, pin string) DeliveryPinGeneratedWebhookBody {
+	contact := order.Destination.AddressInfo.Contact
+	body := DeliveryPinGeneratedWebhookBody{
+		OrderReferenceID: order.ReferenceID.String(),
+		Pin:              pin,
+	}
+	body.Recipient.FullName = contact.FullName
+	body.Recipient.Email = contact.PrimaryEmail
+	body.Recipient.Phone = contact.PrimaryPhone
+	return body
+}
@@ -0,0 +1,64 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"transport-app/app/domain"
+
+	"github.com/paulmach/orb"
+)
+
+type DriverLocationsRequest struct {
+	Route struct {
+		ReferenceID string `json:"referenceID" example:"ROUTE-001"`
+	} `json:"route"`
+	Vehicle struct {
+		Plate string `json:"plate" example:"ABC123"`
+	} `json:"vehicle"`
+	Driver struct {
+		Email      string `json:"email" example:"juan@example.com"`
+		NationalID string `json:"nationalID" example:"1234567890"`
+	} `json:"driver"`
+	Locations []struct {
+		RecordedAt     string  `json:"recordedAt" example:"2025-06-06T14:30:00Z"`
+		Latitude       float64 `json:"latitude" example:"-33.5147889"`
+		Longitude      float64 `json:"longitude" example:"-70.6130425"`
+		SpeedKmh       float64 `json:"speedKmh" example:"42.5"`
+		AccuracyMeters float64 `json:"accuracyMeters" example:"8"`
+	} `json:"locations"`
+}
+
+func (req DriverLocationsRequest) Validate() error {
+	if len(req.Locations) == 0 {
+		return errors.New("at least one location is required")
+	}
+	for i, location := range req.Map() {
+		if err := location.Validate(); err != nil {
+			return fmt.Errorf("locations[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Map convierte el lote en un ping por ubicación, todos asociados a la misma ruta y vehículo
+func (req DriverLocationsRequest) Map() []domain.DriverLocation {
+	locations := make([]domain.DriverLocation, 0, len(req.Locations))
+	for _, location := range req.Locations {
+		// Un timestamp inválido queda en cero y es rechazado por la validación del dominio
+		recordedAt, _ := time.Parse(time.RFC3339, location.RecordedAt)
+		locations = append(locations, domain.DriverLocation{
+			RouteReferenceID: req.Route.ReferenceID,
+			VehiclePlate:     req.Vehicle.Plate,
+			Driver: domain.Driver{
+				Email:      req.Driver.Email,
+				NationalID: req.Driver.NationalID,
+			},
+			Point:          orb.Point{location.Longitude, location.Latitude},
+			RecordedAt:     recordedAt,
+			SpeedKmh:       location.SpeedKmh,
+			AccuracyMeters: location.AccuracyMeters,
+		})
+	}
+	return locations
+}
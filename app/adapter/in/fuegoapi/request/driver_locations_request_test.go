@@ -0,0 +1,62 @@
+package request
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDriverLocationsRequest_Map(t *testing.T) {
+	var input DriverLocationsRequest
+	if err := json.Unmarshal([]byte(`{
+		"route": {"referenceID": "ROUTE-001"},
+		"vehicle": {"plate": "ABC123"},
+		"driver": {"nationalID": "1234567890"},
+		"locations": [
+			{"recordedAt": "2025-06-06T14:30:00Z", "latitude": -33.5147889, "longitude": -70.6130425, "speedKmh": 42.5, "accuracyMeters": 8},
+			{"recordedAt": "2025-06-06T14:30:10Z", "latitude": -33.5150, "longitude": -70.6132}
+		]
+	}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := input.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	locations := input.Map()
+
+	if len(locations) != 2 {
+		t.Fatalf("locations = %d, want 2", len(locations))
+	}
+	first := locations[0]
+	if first.RouteReferenceID != "ROUTE-001" || first.VehiclePlate != "ABC123" || first.Driver.NationalID != "1234567890" {
+		t.Errorf("unexpected location %+v", first)
+	}
+	if first.Point.Lat() != -33.5147889 || first.Point.Lon() != -70.6130425 {
+		t.Errorf("unexpected point %v", first.Point)
+	}
+	if !first.RecordedAt.Equal(time.Date(2025, 6, 6, 14, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected recordedAt %v", first.RecordedAt)
+	}
+	if first.SpeedKmh != 42.5 || first.AccuracyMeters != 8 {
+		t.Errorf("unexpected speed/accuracy %+v", first)
+	}
+}
+
+func TestDriverLocationsRequest_Validate(t *testing.T) {
+	cases := map[string]string{
+		"empty batch":       `{"route": {"referenceID": "ROUTE-001"}, "locations": []}`,
+		"missing route":     `{"locations": [{"recordedAt": "2025-06-06T14:30:00Z", "latitude": -33.5, "longitude": -70.6}]}`,
+		"invalid timestamp": `{"route": {"referenceID": "ROUTE-001"}, "locations": [{"recordedAt": "06/06/2025", "latitude": -33.5, "longitude": -70.6}]}`,
+		"invalid latitude":  `{"route": {"referenceID": "ROUTE-001"}, "locations": [{"recordedAt": "2025-06-06T14:30:00Z", "latitude": -133.5, "longitude": -70.6}]}`,
+	}
+	for name, body := range cases {
+		var input DriverLocationsRequest
+		if err := json.Unmarshal([]byte(body), &input); err != nil {
+			t.Fatal(err)
+		}
+		if err := input.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
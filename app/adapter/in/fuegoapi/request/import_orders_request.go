@@ -0,0 +1,308 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Claves canónicas de importación: son los paths json de UpsertOrderRequest
+// que puede alimentar una columna del archivo. Cada fila describe una unidad
+// de entrega (y opcionalmente un item) de la orden indicada por referenceID.
+const (
+	OrderImportKeyReferenceID          = "referenceID"
+	OrderImportKeyOrderType            = "orderType.type"
+	OrderImportKeyOriginNodeReference  = "origin.nodeInfo.referenceID"
+	OrderImportKeyDeliveryInstructions = "destination.deliveryInstructions"
+	OrderImportKeyAddressLine1         = "destination.addressInfo.addressLine1"
+	OrderImportKeyAddressLine2         = "destination.addressInfo.addressLine2"
+	OrderImportKeyZipCode              = "destination.addressInfo.zipCode"
+	OrderImportKeyContactFullName      = "destination.addressInfo.contact.fullName"
+	OrderImportKeyContactPhone         = "destination.addressInfo.contact.phone"
+	OrderImportKeyContactEmail         = "destination.addressInfo.contact.email"
+	OrderImportKeyContactNationalID    = "destination.addressInfo.contact.nationalID"
+	OrderImportKeyLatitude             = "destination.addressInfo.coordinates.latitude"
+	OrderImportKeyLongitude            = "destination.addressInfo.coordinates.longitude"
+	OrderImportKeyAdminAreaLevel1      = "destination.addressInfo.politicalArea.adminAreaLevel1"
+	OrderImportKeyAdminAreaLevel2      = "destination.addressInfo.politicalArea.adminAreaLevel2"
+	OrderImportKeyAdminAreaLevel3      = "destination.addressInfo.politicalArea.adminAreaLevel3"
+	OrderImportKeyPromisedStartDate    = "promisedDate.dateRange.startDate"
+	OrderImportKeyPromisedEndDate      = "promisedDate.dateRange.endDate"
+	OrderImportKeyPromisedStartTime    = "promisedDate.timeRange.startTime"
+	OrderImportKeyPromisedEndTime      = "promisedDate.timeRange.endTime"
+	OrderImportKeyServiceCategory      = "promisedDate.serviceCategory"
+	OrderImportKeyLpn                  = "deliveryUnits.lpn"
+	OrderImportKeyVolume               = "deliveryUnits.volume"
+	OrderImportKeyWeight               = "deliveryUnits.weight"
+	OrderImportKeyPrice                = "deliveryUnits.price"
+	OrderImportKeyItemSku              = "deliveryUnits.items.sku"
+	OrderImportKeyItemDescription      = "deliveryUnits.items.description"
+	OrderImportKeyItemQuantity         = "deliveryUnits.items.quantity"
+)
+
+// OrderImportKeys lista las claves canónicas en el orden en que se presentan al usuario
+var OrderImportKeys = []string{
+	OrderImportKeyReferenceID,
+	OrderImportKeyOrderType,
+	OrderImportKeyOriginNodeReference,
+	OrderImportKeyDeliveryInstructions,
+	OrderImportKeyAddressLine1,
+	OrderImportKeyAddressLine2,
+	OrderImportKeyZipCode,
+	OrderImportKeyContactFullName,
+	OrderImportKeyContactPhone,
+	OrderImportKeyContactEmail,
+	OrderImportKeyContactNationalID,
+	OrderImportKeyLatitude,
+	OrderImportKeyLongitude,
+	OrderImportKeyAdminAreaLevel1,
+	OrderImportKeyAdminAreaLevel2,
+	OrderImportKeyAdminAreaLevel3,
+	OrderImportKeyPromisedStartDate,
+	OrderImportKeyPromisedEndDate,
+	OrderImportKeyPromisedStartTime,
+	OrderImportKeyPromisedEndTime,
+	OrderImportKeyServiceCategory,
+	OrderImportKeyLpn,
+	OrderImportKeyVolume,
+	OrderImportKeyWeight,
+	OrderImportKeyPrice,
+	OrderImportKeyItemSku,
+	OrderImportKeyItemDescription,
+	OrderImportKeyItemQuantity,
+}
+
+var ErrMissingImportReferenceID = errors.New("missing referenceID: every row must reference an order")
+
+// ImportedOrder agrupa las filas del archivo que pertenecen a una misma orden.
+// Rows son los números de fila del archivo (la cabecera es la fila 1).
+type ImportedOrder struct {
+	Rows    []int
+	Request UpsertOrderRequest
+}
+
+// OrderImportRowError describe una fila que no pudo convertirse en orden
+type OrderImportRowError struct {
+	Row         int
+	ReferenceID string
+	Error       string
+}
+
+// MapOrderImportRows convierte las filas del archivo en órdenes usando el mapeo
+// clave canónica -> cabecera. Las filas con el mismo referenceID se agrupan en una
+// sola orden y las filas con el mismo LPN acumulan items en la misma unidad. rowNumbers
+// indica el número de cada fila en el archivo; sin él se asumen consecutivas desde la fila 2.
+func MapOrderImportRows(
+	headers []string,
+	rows [][]string,
+	rowNumbers []int,
+	mapping map[string]string) ([]ImportedOrder, []OrderImportRowError) {
+	columns := make(map[string]int, len(mapping))
+	for key, header := range mapping {
+		for i, h := range headers {
+			if header != "" && strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(header)) {
+				columns[key] = i
+				break
+			}
+		}
+	}
+
+	var (
+		orders    []ImportedOrder
+		rowErrors []OrderImportRowError
+		byRef     = make(map[string]int)
+	)
+
+	for i, row := range rows {
+		rowNumber := i + 2
+		if i < len(rowNumbers) {
+			rowNumber = rowNumbers[i]
+		}
+		values := make(map[string]string, len(columns))
+		for key, col := range columns {
+			if col < len(row) {
+				if v := strings.TrimSpace(row[col]); v != "" {
+					values[key] = v
+				}
+			}
+		}
+		if len(values) == 0 {
+			// Filas vacías al final de las planillas
+			continue
+		}
+
+		referenceID := values[OrderImportKeyReferenceID]
+		if referenceID == "" {
+			rowErrors = append(rowErrors, OrderImportRowError{
+				Row:   rowNumber,
+				Error: ErrMissingImportReferenceID.Error(),
+			})
+			continue
+		}
+
+		idx, exists := byRef[referenceID]
+		if !exists {
+			idx = len(orders)
+			byRef[referenceID] = idx
+			orders = append(orders, ImportedOrder{
+				Request: UpsertOrderRequest{ReferenceID: referenceID},
+			})
+		}
+
+		if err := applyOrderImportRow(&orders[idx].Request, values); err != nil {
+			rowErrors = append(rowErrors, OrderImportRowError{
+				Row:         rowNumber,
+				ReferenceID: referenceID,
+				Error:       err.Error(),
+			})
+			continue
+		}
+		orders[idx].Rows = append(orders[idx].Rows, rowNumber)
+	}
+
+	// Órdenes cuyas filas fallaron todas en la conversión
+	valid := orders[:0]
+	for _, o := range orders {
+		if len(o.Rows) > 0 {
+			valid = append(valid, o)
+		}
+	}
+	return valid, rowErrors
+}
+
+// applyOrderImportRow aplica una fila sobre la orden. Los campos de orden se toman
+// de la primera fila que los informe; la fila aporta una unidad de entrega.
+func applyOrderImportRow(req *UpsertOrderRequest, values map[string]string) error {
+	volume, err := parseImportInt64(values, OrderImportKeyVolume)
+	if err != nil {
+		return err
+	}
+	weight, err := parseImportInt64(values, OrderImportKeyWeight)
+	if err != nil {
+		return err
+	}
+	price, err := parseImportInt64(values, OrderImportKeyPrice)
+	if err != nil {
+		return err
+	}
+	latitude, err := parseImportFloat(values, OrderImportKeyLatitude)
+	if err != nil {
+		return err
+	}
+	longitude, err := parseImportFloat(values, OrderImportKeyLongitude)
+	if err != nil {
+		return err
+	}
+	quantity := 0
+	if v, ok := values[OrderImportKeyItemQuantity]; ok {
+		q, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be an integer", OrderImportKeyItemQuantity, v)
+		}
+		quantity = q
+	}
+
+	setIfEmpty(&req.OrderType.Type, values[OrderImportKeyOrderType])
+	setIfEmpty(&req.Origin.NodeInfo.ReferenceID, values[OrderImportKeyOriginNodeReference])
+	setIfEmpty(&req.Destination.DeliveryInstructions, values[OrderImportKeyDeliveryInstructions])
+
+	address := &req.Destination.AddressInfo
+	setIfEmpty(&address.AddressLine1, values[OrderImportKeyAddressLine1])
+	setIfEmpty(&address.AddressLine2, values[OrderImportKeyAddressLine2])
+	setIfEmpty(&address.ZipCode, values[OrderImportKeyZipCode])
+	setIfEmpty(&address.Contact.FullName, values[OrderImportKeyContactFullName])
+	setIfEmpty(&address.Contact.Phone, values[OrderImportKeyContactPhone])
+	setIfEmpty(&address.Contact.Email, values[OrderImportKeyContactEmail])
+	setIfEmpty(&address.Contact.NationalID, values[OrderImportKeyContactNationalID])
+	setIfEmpty(&address.PoliticalArea.AdminAreaLevel1, values[OrderImportKeyAdminAreaLevel1])
+	setIfEmpty(&address.PoliticalArea.AdminAreaLevel2, values[OrderImportKeyAdminAreaLevel2])
+	setIfEmpty(&address.PoliticalArea.AdminAreaLevel3, values[OrderImportKeyAdminAreaLevel3])
+	if latitude != nil && longitude != nil && address.Coordinates.Latitude == 0 && address.Coordinates.Longitude == 0 {
+		address.Coordinates.Latitude = *latitude
+		address.Coordinates.Longitude = *longitude
+	}
+
+	promised := &req.PromisedDate
+	setIfEmpty(&promised.DateRange.StartDate, values[OrderImportKeyPromisedStartDate])
+	setIfEmpty(&promised.DateRange.EndDate, values[OrderImportKeyPromisedEndDate])
+	setIfEmpty(&promised.TimeRange.StartTime, values[OrderImportKeyPromisedStartTime])
+	setIfEmpty(&promised.TimeRange.EndTime, values[OrderImportKeyPromisedEndTime])
+	setIfEmpty(&promised.ServiceCategory, values[OrderImportKeyServiceCategory])
+
+	var item *UpsertOrderItem
+	if values[OrderImportKeyItemSku] != "" || values[OrderImportKeyItemDescription] != "" || quantity != 0 {
+		item = &UpsertOrderItem{
+			Sku:         values[OrderImportKeyItemSku],
+			Description: values[OrderImportKeyItemDescription],
+			Quantity:    quantity,
+		}
+	}
+
+	lpn := values[OrderImportKeyLpn]
+	if lpn != "" {
+		for i := range req.DeliveryUnits {
+			du := &req.DeliveryUnits[i]
+			if du.Lpn != lpn {
+				continue
+			}
+			if du.Volume == nil {
+				du.Volume = volume
+			}
+			if du.Weight == nil {
+				du.Weight = weight
+			}
+			if du.Price == nil {
+				du.Price = price
+			}
+			if item != nil {
+				du.Items = append(du.Items, *item)
+			}
+			return nil
+		}
+	}
+
+	deliveryUnit := UpsertOrderDeliveryUnit{
+		Lpn:    lpn,
+		Volume: volume,
+		Weight: weight,
+		Price:  price,
+	}
+	if item != nil {
+		deliveryUnit.Items = []UpsertOrderItem{*item}
+	}
+	req.DeliveryUnits = append(req.DeliveryUnits, deliveryUnit)
+	return nil
+}
+
+func setIfEmpty(target *string, value string) {
+	if *target == "" {
+		*target = value
+	}
+}
+
+func parseImportInt64(values map[string]string, key string) (*int64, error) {
+	v, ok := values[key]
+	if !ok {
+		return nil, nil
+	}
+	// Las planillas suelen exportar enteros como "1500.0"
+	f, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: must be a number", key, v)
+	}
+	n := int64(f)
+	return &n, nil
+}
+
+func parseImportFloat(values map[string]string, key string) (*float64, error) {
+	v, ok := values[key]
+	if !ok {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: must be a number", key, v)
+	}
+	return &f, nil
+}
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapOrderImportRows_GroupsRowsByOrderAndLpn(t *testing.T) {
+	headers := []string{"Folio", "Dirección", "Comuna", "Bulto", "Peso", "SKU", "Cantidad"}
+	rows := [][]string{
+		{"ORD-1", "Av. Providencia 1234", "Providencia", "LPN-1", "1500", "SKU-1", "2"},
+		{"ORD-1", "", "", "LPN-1", "", "SKU-2", "1"},
+		{"ORD-1", "", "", "LPN-2", "800.0", "SKU-3", "1"},
+		{"ORD-2", "Los Leones 45", "Ñuñoa", "LPN-3", "500", "", ""},
+		{"", "", "", "", "", "", ""},
+	}
+	mapping := map[string]string{
+		OrderImportKeyReferenceID:     "folio",
+		OrderImportKeyAddressLine1:    "Dirección",
+		OrderImportKeyAdminAreaLevel2: "Comuna",
+		OrderImportKeyLpn:             "Bulto",
+		OrderImportKeyWeight:          "Peso",
+		OrderImportKeyItemSku:         "SKU",
+		OrderImportKeyItemQuantity:    "Cantidad",
+	}
+
+	orders, rowErrors := MapOrderImportRows(headers, rows, nil, mapping)
+	if len(rowErrors) != 0 {
+		t.Fatalf("row errors = %+v, want none", rowErrors)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("orders = %d, want 2", len(orders))
+	}
+
+	first := orders[0]
+	if got := first.Rows; len(got) != 3 || got[0] != 2 || got[2] != 4 {
+		t.Errorf("rows = %v, want [2 3 4]", got)
+	}
+	if first.Request.Destination.AddressInfo.AddressLine1 != "Av. Providencia 1234" {
+		t.Errorf("addressLine1 = %q", first.Request.Destination.AddressInfo.AddressLine1)
+	}
+	if len(first.Request.DeliveryUnits) != 2 {
+		t.Fatalf("delivery units = %d, want 2", len(first.Request.DeliveryUnits))
+	}
+	lpn1 := first.Request.DeliveryUnits[0]
+	if lpn1.Lpn != "LPN-1" || len(lpn1.Items) != 2 || *lpn1.Weight != 1500 {
+		t.Errorf("LPN-1 = %+v, want two items and weight 1500", lpn1)
+	}
+	if lpn2 := first.Request.DeliveryUnits[1]; *lpn2.Weight != 800 {
+		t.Errorf("LPN-2 weight = %d, want 800", *lpn2.Weight)
+	}
+
+	second := orders[1]
+	if second.Request.Destination.AddressInfo.PoliticalArea.AdminAreaLevel2 != "Ñuñoa" {
+		t.Errorf("adminAreaLevel2 = %q", second.Request.Destination.AddressInfo.PoliticalArea.AdminAreaLevel2)
+	}
+	if items := second.Request.DeliveryUnits[0].Items; len(items) != 0 {
+		t.Errorf("items = %+v, want none", items)
+	}
+}
+
+func TestMapOrderImportRows_ReportsInvalidRows(t *testing.T) {
+	headers := []string{"id", "weight", "lat", "lng"}
+	rows := [][]string{
+		{"", "100", "", ""},
+		{"ORD-1", "heavy", "", ""},
+		{"ORD-2", "100", "-33,45", "-70.66"},
+	}
+	mapping := map[string]string{
+		OrderImportKeyReferenceID: "id",
+		OrderImportKeyWeight:      "weight",
+		OrderImportKeyLatitude:    "lat",
+		OrderImportKeyLongitude:   "lng",
+	}
+
+	orders, rowErrors := MapOrderImportRows(headers, rows, nil, mapping)
+	if len(orders) != 1 || orders[0].Request.ReferenceID != "ORD-2" {
+		t.Fatalf("orders = %+v, want only ORD-2", orders)
+	}
+	if lat := orders[0].Request.Destination.AddressInfo.Coordinates.Latitude; lat != -33.45 {
+		t.Errorf("latitude = %v, want -33.45", lat)
+	}
+	if len(rowErrors) != 2 {
+		t.Fatalf("row errors = %+v, want 2", rowErrors)
+	}
+	if rowErrors[0].Row != 2 || rowErrors[0].Error != ErrMissingImportReferenceID.Error() {
+		t.Errorf("first error = %+v", rowErrors[0])
+	}
+	if rowErrors[1].Row != 3 || rowErrors[1].ReferenceID != "ORD-1" || !strings.Contains(rowErrors[1].Error, OrderImportKeyWeight) {
+		t.Errorf("second error = %+v", rowErrors[1])
+	}
+}
+
+func TestMapOrderImportRows_UsesSourceRowNumbers(t *testing.T) {
+	headers := []string{"id", "weight"}
+	rows := [][]string{
+		{"ORD-1", "100"},
+		{"ORD-1", "heavy"},
+	}
+	mapping := map[string]string{
+		OrderImportKeyReferenceID: "id",
+		OrderImportKeyWeight:      "weight",
+	}
+
+	orders, rowErrors := MapOrderImportRows(headers, rows, []int{3, 7}, mapping)
+	if len(orders) != 1 || len(orders[0].Rows) != 1 || orders[0].Rows[0] != 3 {
+		t.Fatalf("orders = %+v, want ORD-1 from row 3", orders)
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Row != 7 {
+		t.Errorf("row errors = %+v, want row 7", rowErrors)
+	}
+}
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"time"
 	"transport-app/app/domain/optimization"
 )
 
@@ -10,6 +11,7 @@ type OptimizeFleetRequest struct {
 	VehicleKeyMapping map[string]string
 	VisitKeyMapping   map[string]string
 	PlanReferenceID   string                 `json:"planReferenceID"`
+	PlannedDate       string                 `json:"plannedDate,omitempty" example:"2025-06-06" description:"Date the plan is executed (YYYY-MM-DD); defaults to the optimization date"`
 	Vehicles          []OptimizeFleetVehicle `json:"vehicles"`
 	Visits            []OptimizeFleetVisit   `json:"visits"`
 }
@@ -20,7 +22,26 @@ type OptimizeFleetVehicle struct {
 	EndLocation   OptimizeFleetVehicleLocation `json:"endLocation"`
 	Skills        []string                     `json:"skills"`
 	TimeWindow    OptimizeFleetTimeWindow      `json:"timeWindow"`
+	TimeWindows   []OptimizeFleetTimeWindow    `json:"timeWindows" description:"Optional list of availability windows; takes precedence over timeWindow"`
 	Capacity      OptimizeFleetVehicleCapacity `json:"capacity"`
+	Breaks        []OptimizeFleetBreak         `json:"breaks"`
+	MaxTasks      int                          `json:"maxTasks,omitempty" example:"40" description:"Maximum number of stops for the vehicle; 0 means unlimited"`
+	MaxTravelTime int64                        `json:"maxTravelTime,omitempty" example:"28800" description:"Maximum travel time in seconds; 0 means unlimited"`
+	MaxDistance   int64                        `json:"maxDistance,omitempty" example:"150000" description:"Maximum travel distance in meters; 0 means unlimited"`
+	Costs         OptimizeFleetVehicleCosts    `json:"costs"`
+}
+
+type OptimizeFleetVehicleCosts struct {
+	Fixed   int64 `json:"fixed" example:"50000" description:"Fixed cost of using the vehicle, in currency units"`
+	PerHour int64 `json:"perHour" example:"8000" description:"Cost per hour of travel, in currency units; 0 keeps the default of 3600 (cost equals travel seconds)"`
+	PerKm   int64 `json:"perKm" example:"500" description:"Cost per kilometer traveled, in currency units"`
+}
+
+type OptimizeFleetBreak struct {
+	TimeWindows []OptimizeFleetTimeWindow     `json:"timeWindows" description:"Allowed windows to start the break"`
+	Duration    int64                         `json:"duration" example:"1800" description:"Break duration in seconds"`
+	Description string                        `json:"description" example:"Lunch"`
+	MaxLoad     *OptimizeFleetVehicleCapacity `json:"maxLoad,omitempty" description:"Optional maximum load allowed in the vehicle when taking the break"`
 }
 
 type OptimizeFleetVehicleLocation struct {
@@ -55,6 +76,7 @@ type OptimizeFleetPoliticalArea struct {
 	AdminAreaLevel2 string `json:"adminAreaLevel2"`
 	AdminAreaLevel3 string `json:"adminAreaLevel3"`
 	AdminAreaLevel4 string `json:"adminAreaLevel4"`
+	TimeZone        string `json:"timeZone,omitempty" example:"America/Santiago"`
 }
 
 type OptimizeFleetVehicleCapacity struct {
@@ -68,18 +90,56 @@ type OptimizeFleetTimeWindow struct {
 	End   string `json:"end"`
 }
 
+func mapOptimizeFleetBreaks(breaks []OptimizeFleetBreak) []optimization.Break {
+	if len(breaks) == 0 {
+		return nil
+	}
+	out := make([]optimization.Break, len(breaks))
+	for i, b := range breaks {
+		out[i] = optimization.Break{
+			TimeWindows: mapOptimizeFleetTimeWindows(b.TimeWindows),
+			Duration:    b.Duration,
+			Description: b.Description,
+		}
+		if b.MaxLoad != nil {
+			out[i].MaxLoad = &optimization.Capacity{
+				Insurance: b.MaxLoad.Insurance,
+				Volume:    b.MaxLoad.Volume,
+				Weight:    b.MaxLoad.Weight,
+			}
+		}
+	}
+	return out
+}
+
+func mapOptimizeFleetTimeWindows(windows []OptimizeFleetTimeWindow) []optimization.TimeWindow {
+	if len(windows) == 0 {
+		return nil
+	}
+	out := make([]optimization.TimeWindow, len(windows))
+	for i, tw := range windows {
+		out[i] = optimization.TimeWindow{
+			Start: tw.Start,
+			End:   tw.End,
+		}
+	}
+	return out
+}
+
 type OptimizeFleetVisit struct {
 	Pickup   OptimizeFleetVisitLocation `json:"pickup"`
 	Delivery OptimizeFleetVisitLocation `json:"delivery"`
 	Orders   []OptimizeFleetOrder       `json:"orders"`
+	Priority int                        `json:"priority" example:"0" description:"Visit priority from 0 to 100; higher priorities are the last to be left unassigned"`
 }
 
 type OptimizeFleetVisitLocation struct {
-	Instructions string                   `json:"instructions"`
-	AddressInfo  OptimizeFleetAddressInfo `json:"addressInfo"`
-	NodeInfo     OptimizeFleetNodeInfo    `json:"nodeInfo"`
-	ServiceTime  int64                    `json:"serviceTime"`
-	TimeWindow   OptimizeFleetTimeWindow  `json:"timeWindow"`
+	Instructions string                    `json:"instructions"`
+	AddressInfo  OptimizeFleetAddressInfo  `json:"addressInfo"`
+	NodeInfo     OptimizeFleetNodeInfo     `json:"nodeInfo"`
+	ServiceTime  int64                     `json:"serviceTime"`
+	TimeWindow   OptimizeFleetTimeWindow   `json:"timeWindow"`
+	TimeWindows  []OptimizeFleetTimeWindow `json:"timeWindows" description:"Optional list of split windows (e.g. 09:00-12:00 and 15:00-18:00); takes precedence over timeWindow"`
 }
 
 type OptimizeFleetNodeInfo struct {
@@ -89,6 +149,37 @@ type OptimizeFleetNodeInfo struct {
 type OptimizeFleetOrder struct {
 	DeliveryUnits []OptimizeFleetDeliveryUnit `json:"deliveryUnits"`
 	ReferenceID   string                      `json:"referenceID"`
+	PromisedDate  OptimizeFleetPromisedDate   `json:"promisedDate"`
+}
+
+type OptimizeFleetPromisedDate struct {
+	DateRange       OptimizeFleetDateRange `json:"dateRange"`
+	ServiceCategory string                 `json:"serviceCategory" example:"REGULAR / SAME DAY / EXPRESS"`
+}
+
+type OptimizeFleetDateRange struct {
+	StartDate string `json:"startDate" example:"2025-03-28"`
+	EndDate   string `json:"endDate" example:"2025-03-30"`
+}
+
+func (p OptimizeFleetPromisedDate) Map() optimization.PromisedDate {
+	return optimization.PromisedDate{
+		StartDate:       parseOptimizeFleetDate(p.DateRange.StartDate),
+		EndDate:         parseOptimizeFleetDate(p.DateRange.EndDate),
+		ServiceCategory: p.ServiceCategory,
+	}
+}
+
+// parseOptimizeFleetDate convierte "2006-01-02" a time.Time, retornando cero si es inválida
+func parseOptimizeFleetDate(date string) time.Time {
+	if date == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
 }
 
 type OptimizeFleetDeliveryUnit struct {
@@ -131,6 +222,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 						AdminAreaLevel2: v.StartLocation.AddressInfo.PoliticalArea.AdminAreaLevel2,
 						AdminAreaLevel3: v.StartLocation.AddressInfo.PoliticalArea.AdminAreaLevel3,
 						AdminAreaLevel4: v.StartLocation.AddressInfo.PoliticalArea.AdminAreaLevel4,
+						TimeZone:        v.StartLocation.AddressInfo.PoliticalArea.TimeZone,
 					},
 					ZipCode: v.StartLocation.AddressInfo.ZipCode,
 				},
@@ -158,6 +250,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 						AdminAreaLevel2: v.EndLocation.AddressInfo.PoliticalArea.AdminAreaLevel2,
 						AdminAreaLevel3: v.EndLocation.AddressInfo.PoliticalArea.AdminAreaLevel3,
 						AdminAreaLevel4: v.EndLocation.AddressInfo.PoliticalArea.AdminAreaLevel4,
+						TimeZone:        v.EndLocation.AddressInfo.PoliticalArea.TimeZone,
 					},
 					ZipCode: v.EndLocation.AddressInfo.ZipCode,
 				},
@@ -170,11 +263,21 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 				Start: v.TimeWindow.Start,
 				End:   v.TimeWindow.End,
 			},
+			TimeWindows: mapOptimizeFleetTimeWindows(v.TimeWindows),
 			Capacity: optimization.Capacity{
 				Insurance: v.Capacity.Insurance,
 				Volume:    v.Capacity.Volume,
 				Weight:    v.Capacity.Weight,
 			},
+			Breaks:        mapOptimizeFleetBreaks(v.Breaks),
+			MaxTasks:      v.MaxTasks,
+			MaxTravelTime: v.MaxTravelTime,
+			MaxDistance:   v.MaxDistance,
+			Costs: optimization.VehicleCosts{
+				Fixed:   v.Costs.Fixed,
+				PerHour: v.Costs.PerHour,
+				PerKm:   v.Costs.PerKm,
+			},
 		}
 	}
 
@@ -201,6 +304,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 					AdminAreaLevel2: v.Pickup.AddressInfo.PoliticalArea.AdminAreaLevel2,
 					AdminAreaLevel3: v.Pickup.AddressInfo.PoliticalArea.AdminAreaLevel3,
 					AdminAreaLevel4: v.Pickup.AddressInfo.PoliticalArea.AdminAreaLevel4,
+					TimeZone:        v.Pickup.AddressInfo.PoliticalArea.TimeZone,
 				},
 				ZipCode: v.Pickup.AddressInfo.ZipCode,
 			},
@@ -212,6 +316,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 				Start: v.Pickup.TimeWindow.Start,
 				End:   v.Pickup.TimeWindow.End,
 			},
+			TimeWindows: mapOptimizeFleetTimeWindows(v.Pickup.TimeWindows),
 		}
 
 		delivery := optimization.VisitLocation{
@@ -235,6 +340,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 					AdminAreaLevel2: v.Delivery.AddressInfo.PoliticalArea.AdminAreaLevel2,
 					AdminAreaLevel3: v.Delivery.AddressInfo.PoliticalArea.AdminAreaLevel3,
 					AdminAreaLevel4: v.Delivery.AddressInfo.PoliticalArea.AdminAreaLevel4,
+					TimeZone:        v.Delivery.AddressInfo.PoliticalArea.TimeZone,
 				},
 				ZipCode: v.Delivery.AddressInfo.ZipCode,
 			},
@@ -246,6 +352,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 				Start: v.Delivery.TimeWindow.Start,
 				End:   v.Delivery.TimeWindow.End,
 			},
+			TimeWindows: mapOptimizeFleetTimeWindows(v.Delivery.TimeWindows),
 		}
 
 		orders := make([]optimization.Order, len(v.Orders))
@@ -272,6 +379,7 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 			orders[j] = optimization.Order{
 				DeliveryUnits: deliveryUnits,
 				ReferenceID:   o.ReferenceID,
+				PromisedDate:  o.PromisedDate.Map(),
 			}
 		}
 
@@ -279,11 +387,13 @@ func (r *OptimizeFleetRequest) Map() optimization.FleetOptimization {
 			Pickup:   pickup,
 			Delivery: delivery,
 			Orders:   orders,
+			Priority: v.Priority,
 		}
 	}
 
 	return optimization.FleetOptimization{
 		PlanReferenceID: r.PlanReferenceID,
+		PlannedDate:     parseOptimizeFleetDate(r.PlannedDate),
 		Vehicles:        vehicles,
 		Visits:          visits,
 	}
@@ -1,5 +1,10 @@
 package request
 
+import (
+	"transport-app/app/domain"
+	"transport-app/app/domain/optimization"
+)
+
 type OptimizePickingAndDeliveryRequest struct {
 	NodeInfo struct {
 		ReferenceID string `json:"referenceID"`
@@ -57,3 +62,90 @@ type OptimizePickingAndDeliveryRequest struct {
 		} `json:"orders"`
 	} `json:"visits"`
 }
+
+// Map arma la optimización de un único vehículo: el contenedor que lleva el conductor
+// desde el punto de inicio a cada una de las entregas
+func (r OptimizePickingAndDeliveryRequest) Map() optimization.FleetOptimization {
+	vehicle := optimization.Vehicle{
+		Plate: r.Container.Lpn,
+		StartLocation: optimization.VehicleLocation{
+			AddressInfo: optimization.AddressInfo{
+				Coordinates: optimization.Coordinates{
+					Latitude:  r.StartLocation.Latitude,
+					Longitude: r.StartLocation.Longitude,
+				},
+			},
+			NodeInfo: optimization.NodeInfo{ReferenceID: r.StartLocation.NodeInfo.ReferenceID},
+		},
+		EndLocation: optimization.VehicleLocation{
+			AddressInfo: optimization.AddressInfo{
+				Coordinates: optimization.Coordinates{
+					Latitude:  r.EndLocation.Latitude,
+					Longitude: r.EndLocation.Longitude,
+				},
+			},
+			NodeInfo: optimization.NodeInfo{ReferenceID: r.EndLocation.NodeInfo.ReferenceID},
+		},
+	}
+
+	visits := make([]optimization.Visit, 0, len(r.Visits))
+	for _, v := range r.Visits {
+		orders := make([]optimization.Order, 0, len(v.Orders))
+		for _, o := range v.Orders {
+			deliveryUnits := make([]optimization.DeliveryUnit, 0, len(o.DeliveryUnits))
+			for _, du := range o.DeliveryUnits {
+				items := make([]optimization.Item, 0, len(du.Items))
+				for _, item := range du.Items {
+					items = append(items, optimization.Item{Sku: item.Sku})
+				}
+				deliveryUnits = append(deliveryUnits, optimization.DeliveryUnit{
+					Items:  items,
+					Price:  du.Insurance,
+					Volume: du.Volume,
+					Weight: du.Weight,
+					Lpn:    du.Lpn,
+				})
+			}
+			orders = append(orders, optimization.Order{
+				ReferenceID:   o.ReferenceID,
+				DeliveryUnits: deliveryUnits,
+			})
+		}
+		visits = append(visits, optimization.Visit{
+			Delivery: optimization.VisitLocation{
+				AddressInfo: optimization.AddressInfo{
+					Contact: optimization.Contact{
+						Email:      v.Delivery.Contact.Email,
+						Phone:      v.Delivery.Contact.Phone,
+						NationalID: v.Delivery.Contact.NationalID,
+						FullName:   v.Delivery.Contact.FullName,
+					},
+					Coordinates: optimization.Coordinates{
+						Latitude:  v.Delivery.Coordinates.Latitude,
+						Longitude: v.Delivery.Coordinates.Longitude,
+					},
+				},
+				ServiceTime: v.Delivery.ServiceTime,
+			},
+			Orders: orders,
+		})
+	}
+
+	return optimization.FleetOptimization{
+		PlanReferenceID: r.Container.Lpn,
+		Vehicles:        []optimization.Vehicle{vehicle},
+		Visits:          visits,
+	}
+}
+
+// MapCarrier retorna el transportista con el conductor que recorre el contenedor
+func (r OptimizePickingAndDeliveryRequest) MapCarrier() domain.Carrier {
+	return domain.Carrier{
+		Name:       r.Carrier.Name,
+		NationalID: r.Carrier.NationalID,
+		Driver: domain.Driver{
+			Email:      r.Driver.Email,
+			NationalID: r.Driver.NationalID,
+		},
+	}
+}
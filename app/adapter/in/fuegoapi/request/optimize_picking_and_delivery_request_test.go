@@ -0,0 +1,60 @@
+package request
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptimizePickingAndDeliveryRequest_Map(t *testing.T) {
+	var input OptimizePickingAndDeliveryRequest
+	if err := json.Unmarshal([]byte(`{
+		"container": {"lpn": "CONT-001"},
+		"carrier": {"name": "Transportes Sur", "nationalID": "76.123.456-7"},
+		"driver": {"email": "driver@example.com", "nationalID": "12.345.678-9"},
+		"startLocation": {"latitude": -33.45, "longitude": -70.66, "nodeInfo": {"referenceID": "NODE-1"}},
+		"endLocation": {"latitude": -33.46, "longitude": -70.67},
+		"visits": [{
+			"delivery": {
+				"coordinates": {"latitude": -33.40, "longitude": -70.60},
+				"serviceTime": 300,
+				"contact": {"fullName": "Juan"}
+			},
+			"orders": [{
+				"referenceID": "ORD-1",
+				"deliveryUnits": [{"lpn": "LPN-1", "insurance": 1000, "weight": 500, "items": [{"sku": "SKU-1"}]}]
+			}]
+		}]
+	}`), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	fleet := input.Map()
+
+	if fleet.PlanReferenceID != "CONT-001" {
+		t.Errorf("plan reference = %q, want CONT-001", fleet.PlanReferenceID)
+	}
+	if len(fleet.Vehicles) != 1 {
+		t.Fatalf("vehicles = %d, want 1", len(fleet.Vehicles))
+	}
+	vehicle := fleet.Vehicles[0]
+	if vehicle.Plate != "CONT-001" || vehicle.StartLocation.NodeInfo.ReferenceID != "NODE-1" ||
+		vehicle.EndLocation.AddressInfo.Coordinates.Latitude != -33.46 {
+		t.Errorf("unexpected vehicle %+v", vehicle)
+	}
+	if len(fleet.Visits) != 1 {
+		t.Fatalf("visits = %d, want 1", len(fleet.Visits))
+	}
+	visit := fleet.Visits[0]
+	if visit.Delivery.ServiceTime != 300 || visit.Delivery.AddressInfo.Contact.FullName != "Juan" {
+		t.Errorf("unexpected delivery %+v", visit.Delivery)
+	}
+	du := visit.Orders[0].DeliveryUnits[0]
+	if du.Lpn != "LPN-1" || du.Price != 1000 || du.Weight != 500 || du.Items[0].Sku != "SKU-1" {
+		t.Errorf("unexpected delivery unit %+v", du)
+	}
+
+	carrier := input.MapCarrier()
+	if carrier.NationalID != "76.123.456-7" || carrier.Driver.Email != "driver@example.com" {
+		t.Errorf("unexpected carrier %+v", carrier)
+	}
+}
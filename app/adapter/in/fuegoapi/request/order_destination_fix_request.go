@@ -2,7 +2,10 @@ package request
 
 import (
 	"context"
+	"errors"
 	"transport-app/app/domain"
+
+	"github.com/paulmach/orb"
 )
 
 type OrderDestinationFixRequest struct {
@@ -47,7 +50,75 @@ type OrderDestinationFixRequest struct {
 	} `json:"orderReferenceIDs"`
 }
 
-// Map convierte el request a un objeto de dominio OrderDestinationFix
-func (req OrderDestinationFixRequest) Map(ctx context.Context) []domain.Order {
+func (req OrderDestinationFixRequest) Validate() error {
+	if len(req.OrderReferenceIDs) == 0 {
+		return errors.New("at least one order reference is required")
+	}
+	for _, order := range req.OrderReferenceIDs {
+		if order.ReferenceID == "" {
+			return errors.New("order referenceId is required")
+		}
+	}
+	if req.Destination.AddressLine1 == "" &&
+		req.Destination.Coordinates.Latitude == 0 && req.Destination.Coordinates.Longitude == 0 {
+		return errors.New("destination addressLine1 or coordinates are required")
+	}
+	if req.ManualChange.PerformedBy == "" {
+		return errors.New("manualChange performedBy is required")
+	}
 	return nil
 }
+
+// Map convierte el request en las órdenes a corregir, todas con la misma dirección de destino
+func (req OrderDestinationFixRequest) Map(ctx context.Context) []domain.Order {
+	destination := domain.AddressInfo{
+		AddressLine1: req.Destination.AddressLine1,
+		AddressLine2: req.Destination.AddressLine2,
+		ZipCode:      req.Destination.ZipCode,
+		Coordinates: domain.Coordinates{
+			Point:  orb.Point{req.Destination.Coordinates.Longitude, req.Destination.Coordinates.Latitude},
+			Source: req.Destination.Coordinates.Source,
+			Confidence: domain.CoordinatesConfidence{
+				Level:   req.Destination.Coordinates.Confidence.Level,
+				Message: req.Destination.Coordinates.Confidence.Message,
+				Reason:  req.Destination.Coordinates.Confidence.Reason,
+			},
+		},
+		PoliticalArea: domain.PoliticalArea{
+			Code:            req.Destination.PoliticalArea.Code,
+			AdminAreaLevel1: req.Destination.PoliticalArea.AdminAreaLevel1,
+			AdminAreaLevel2: req.Destination.PoliticalArea.AdminAreaLevel2,
+			AdminAreaLevel3: req.Destination.PoliticalArea.AdminAreaLevel3,
+			AdminAreaLevel4: req.Destination.PoliticalArea.AdminAreaLevel4,
+			TimeZone:        req.Destination.PoliticalArea.TimeZone,
+			Confidence: domain.CoordinatesConfidence{
+				Level:   req.Destination.PoliticalArea.Confidence.Level,
+				Message: req.Destination.PoliticalArea.Confidence.Message,
+				Reason:  req.Destination.PoliticalArea.Confidence.Reason,
+			},
+		},
+	}
+
+	orders := make([]domain.Order, 0, len(req.OrderReferenceIDs))
+	for _, o := range req.OrderReferenceIDs {
+		orders = append(orders, domain.Order{
+			Headers: domain.Headers{
+				Commerce: o.BusinessIdentifiers.Commerce,
+				Consumer: o.BusinessIdentifiers.Consumer,
+			},
+			ReferenceID: domain.ReferenceID(o.ReferenceID),
+			Destination: domain.NodeInfo{
+				AddressInfo: destination,
+			},
+		})
+	}
+	return orders
+}
+
+// MapManualChange retorna quién corrigió el destino y por qué
+func (req OrderDestinationFixRequest) MapManualChange() domain.ManualChange {
+	return domain.ManualChange{
+		PerformedBy: req.ManualChange.PerformedBy,
+		Reason:      req.ManualChange.Reason,
+	}
+}
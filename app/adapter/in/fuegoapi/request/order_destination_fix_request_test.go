@@ -0,0 +1,56 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderDestinationFixRequest_Map(t *testing.T) {
+	var input OrderDestinationFixRequest
+	if err := json.Unmarshal([]byte(`{
+		"manualChange": {"performedBy": "juan@example.com", "reason": "PROVIDER_RESULT_OUT_OF_DISTRICT"},
+		"destination": {
+			"addressLine1": "Inglaterra 59",
+			"coordinates": {"latitude": -33.5147889, "longitude": -70.6130425, "source": "GOOGLE_MAPS"},
+			"politicalArea": {"adminAreaLevel3": "la florida", "timeZone": "America/Santiago"}
+		},
+		"orderReferenceIDs": [
+			{"referenceId": "ORD-1", "businessIdentifiers": {"commerce": "C1", "consumer": "K1"}},
+			{"referenceId": "ORD-2"}
+		]
+	}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := input.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	orders := input.Map(context.Background())
+
+	if len(orders) != 2 {
+		t.Fatalf("orders = %d, want 2", len(orders))
+	}
+	destination := orders[1].Destination.AddressInfo
+	if destination.AddressLine1 != "Inglaterra 59" || destination.PoliticalArea.TimeZone != "America/Santiago" {
+		t.Errorf("unexpected destination %+v", destination)
+	}
+	if destination.Coordinates.Point.Lat() != -33.5147889 || destination.Coordinates.Point.Lon() != -70.6130425 {
+		t.Errorf("unexpected point %v", destination.Coordinates.Point)
+	}
+	if orders[0].Headers.Commerce != "C1" || orders[0].ReferenceID != "ORD-1" {
+		t.Errorf("unexpected order %+v", orders[0])
+	}
+	if change := input.MapManualChange(); change.PerformedBy != "juan@example.com" {
+		t.Errorf("unexpected manual change %+v", change)
+	}
+}
+
+func TestOrderDestinationFixRequest_Validate(t *testing.T) {
+	var input OrderDestinationFixRequest
+	input.ManualChange.PerformedBy = "juan@example.com"
+	input.Destination.AddressLine1 = "Inglaterra 59"
+	if err := input.Validate(); err == nil {
+		t.Error("expected error when no orders are informed")
+	}
+}
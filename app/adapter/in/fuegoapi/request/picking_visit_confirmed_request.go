@@ -1,5 +1,15 @@
 package request
 
+import (
+	"context"
+	"errors"
+	"time"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+
+	"github.com/paulmach/orb"
+)
+
 type PickingVisitConfirmedRequest struct {
 	Carrier struct {
 		Name       string `json:"name"`
@@ -30,6 +40,12 @@ type PickingVisitConfirmedRequest struct {
 		} `json:"coordinates"`
 		VisitDate        string `json:"visitDate" example:"2025-06-19T10:00:00Z" description:"Visit date"`
 		ConfirmationDate string `json:"confirmationDate" example:"2025-06-19T10:00:00Z" description:"Confirmation date"`
+		// Fotos que respaldan el retiro en el seller
+		EvidencePhotos []struct {
+			TakenAt string `json:"takenAt" example:"2025-06-19T10:00:00Z"`
+			Type    string `json:"type" example:"PICKUP_PHOTO"`
+			URL     string `json:"url" example:"https://ignaciojeria.github.io/"`
+		} `json:"evidencePhotos"`
 	} `json:"visit"`
 	Orders []struct {
 		DeliveryUnits []struct {
@@ -44,3 +60,101 @@ type PickingVisitConfirmedRequest struct {
 		ReferenceID string `json:"referenceID"`
 	} `json:"orders"`
 }
+
+func (r PickingVisitConfirmedRequest) Validate() error {
+	if len(r.Orders) == 0 {
+		return errors.New("at least one order is required")
+	}
+	for _, order := range r.Orders {
+		if order.ReferenceID == "" {
+			return errors.New("order referenceID is required")
+		}
+	}
+	if r.Visit.ConfirmationDate != "" {
+		if _, err := time.Parse(time.RFC3339, r.Visit.ConfirmationDate); err != nil {
+			return errors.New("visit confirmationDate must be RFC3339")
+		}
+	}
+	return nil
+}
+
+// Map convierte el retiro confirmado en una ruta cuyas unidades llevan la confirmación
+// del retiro: fecha, coordenadas y evidencias de la visita al seller
+func (r PickingVisitConfirmedRequest) Map(ctx context.Context) domain.Route {
+	handledAt, _ := time.Parse(time.RFC3339, r.Visit.ConfirmationDate)
+	photos := make(domain.EvidencePhotos, 0, len(r.Visit.EvidencePhotos))
+	for _, photo := range r.Visit.EvidencePhotos {
+		takenAt, _ := time.Parse(time.RFC3339, photo.TakenAt)
+		photos = append(photos, domain.EvidencePhoto{
+			TakenAt: takenAt,
+			Type:    photo.Type,
+			URL:     photo.URL,
+		})
+	}
+	confirmPickup := domain.ConfirmDelivery{
+		HandledAt:      handledAt,
+		Latitude:       r.Visit.Coordinates.Latitude,
+		Longitude:      r.Visit.Coordinates.Longitude,
+		EvidencePhotos: photos,
+	}
+
+	origin := domain.NodeInfo{
+		ReferenceID: domain.ReferenceID(r.Visit.NodeInfo.ReferenceID),
+		AddressInfo: domain.AddressInfo{
+			Contact: domain.Contact{
+				FullName:     r.Visit.Contact.FullName,
+				PrimaryEmail: r.Visit.Contact.Email,
+				PrimaryPhone: r.Visit.Contact.Phone,
+				NationalID:   r.Visit.Contact.NationalID,
+			},
+			Coordinates: domain.Coordinates{
+				Point: orb.Point{r.Visit.Coordinates.Longitude, r.Visit.Coordinates.Latitude},
+			},
+		},
+	}
+
+	orders := make([]domain.Order, 0, len(r.Orders))
+	for _, o := range r.Orders {
+		order := domain.Order{
+			Headers: domain.Headers{
+				Channel: sharedcontext.ChannelFromContext(ctx),
+			},
+			ReferenceID:   domain.ReferenceID(o.ReferenceID),
+			Origin:        origin,
+			DeliveryUnits: make(domain.DeliveryUnits, 0, len(o.DeliveryUnits)),
+		}
+		for _, du := range o.DeliveryUnits {
+			items := make([]domain.Item, 0, len(du.Items))
+			for _, item := range du.Items {
+				items = append(items, domain.Item{Sku: item.Sku})
+			}
+			order.DeliveryUnits = append(order.DeliveryUnits, domain.DeliveryUnit{
+				Lpn:             du.Lpn,
+				Items:           items,
+				ConfirmDelivery: confirmPickup,
+			})
+		}
+		// Sin unidades informadas se confirma el retiro de todas las unidades de la orden
+		if len(order.DeliveryUnits) == 0 {
+			order.DeliveryUnits = append(order.DeliveryUnits, domain.DeliveryUnit{
+				ConfirmDelivery: confirmPickup,
+			})
+		}
+		orders = append(orders, order)
+	}
+
+	return domain.Route{
+		Vehicle: domain.Vehicle{
+			Plate: r.Vehicle.Plate,
+			Carrier: domain.Carrier{
+				Name:       r.Carrier.Name,
+				NationalID: r.Carrier.NationalID,
+				Driver: domain.Driver{
+					Email:      r.Driver.Email,
+					NationalID: r.Driver.NationalID,
+				},
+			},
+		},
+		Orders: orders,
+	}
+}
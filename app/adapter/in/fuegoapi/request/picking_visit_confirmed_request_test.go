@@ -0,0 +1,65 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPickingVisitConfirmedRequest_Map(t *testing.T) {
+	var input PickingVisitConfirmedRequest
+	if err := json.Unmarshal([]byte(`{
+		"vehicle": {"plate": "ABCD12"},
+		"driver": {"email": "driver@example.com", "nationalID": "12.345.678-9"},
+		"visit": {
+			"nodeInfo": {"referenceID": "SELLER-1"},
+			"coordinates": {"latitude": -33.45, "longitude": -70.66},
+			"confirmationDate": "2025-06-19T10:00:00Z",
+			"evidencePhotos": [{"url": "https://example.com/photo.jpg", "type": "PICKUP_PHOTO", "takenAt": "2025-06-19T09:59:00Z"}]
+		},
+		"orders": [
+			{"referenceID": "ORD-1", "deliveryUnits": [{"lpn": "LPN-1", "items": [{"sku": "SKU-1"}]}]},
+			{"referenceID": "ORD-2"}
+		]
+	}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := input.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	route := input.Map(context.Background())
+
+	if route.Vehicle.Plate != "ABCD12" || route.Vehicle.Carrier.Driver.Email != "driver@example.com" {
+		t.Errorf("unexpected vehicle %+v", route.Vehicle)
+	}
+	if len(route.Orders) != 2 {
+		t.Fatalf("orders = %d, want 2", len(route.Orders))
+	}
+	du := route.Orders[0].DeliveryUnits[0]
+	wantHandledAt := time.Date(2025, 6, 19, 10, 0, 0, 0, time.UTC)
+	if !du.ConfirmDelivery.HandledAt.Equal(wantHandledAt) ||
+		du.ConfirmDelivery.Latitude != -33.45 || du.ConfirmDelivery.Longitude != -70.66 ||
+		len(du.ConfirmDelivery.EvidencePhotos) != 1 {
+		t.Errorf("unexpected pickup confirmation %+v", du.ConfirmDelivery)
+	}
+	if route.Orders[0].Origin.ReferenceID != "SELLER-1" {
+		t.Errorf("origin = %q, want SELLER-1", route.Orders[0].Origin.ReferenceID)
+	}
+	// La orden sin unidades queda con una unidad vacía para hidratarla desde el proyectado
+	if len(route.Orders[1].DeliveryUnits) != 1 || route.Orders[1].DeliveryUnits[0].Lpn != "" {
+		t.Errorf("order without units should be hydrated later, got %+v", route.Orders[1].DeliveryUnits)
+	}
+
+	body := MapSellerPickupConfirmedWebhookBody(route)
+	if body.Node != "SELLER-1" || len(body.Orders) != 2 || body.PickedAt != "2025-06-19T10:00:00Z" {
+		t.Errorf("unexpected webhook body %+v", body)
+	}
+}
+
+func TestPickingVisitConfirmedRequest_Validate(t *testing.T) {
+	if err := (PickingVisitConfirmedRequest{}).Validate(); err == nil {
+		t.Error("expected error when no orders are informed")
+	}
+}
@@ -0,0 +1,216 @@
+package request
+
+import (
+	"fmt"
+	"time"
+	"transport-app/app/domain/optimization"
+)
+
+// minutesPerDay permite desplazar al día siguiente las ventanas que cruzan la medianoche
+const minutesPerDay = 24 * 60
+
+type ReoptimizeRouteRequest struct {
+	// Posición actual del conductor, desde donde se vuelve a secuenciar la ruta
+	CurrentLocation struct {
+		Latitude  float64 `json:"latitude" example:"-33.4372"`
+		Longitude float64 `json:"longitude" example:"-70.6506"`
+	} `json:"currentLocation"`
+	// Zona horaria IANA en que se interpretan las ventanas horarias de la ruta. Si no se
+	// indica se usa la de las direcciones de la ruta.
+	TimeZone string `json:"timeZone,omitempty" example:"America/Santiago"`
+}
+
+// Location retorna la zona horaria de la ruta: la indicada en la solicitud, si no la
+// primera informada en sus direcciones y en último caso UTC.
+func (r ReoptimizeRouteRequest) Location(route UpsertRouteRequest) *time.Location {
+	for _, name := range append([]string{r.TimeZone}, route.timeZones()...) {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// Map arma la optimización de un solo vehículo que parte ahora desde la posición actual
+// del conductor, considerando solo las órdenes de la ruta que siguen pendientes.
+// isClosed indica si una unidad de entrega (orden, lpn) ya fue resuelta en ruta. now se
+// interpreta en la zona horaria de la ruta.
+func (r ReoptimizeRouteRequest) Map(
+	route UpsertRouteRequest,
+	isClosed func(orderReferenceID, lpn string) bool,
+	now time.Time) optimization.FleetOptimization {
+	now = now.In(r.Location(route))
+	vehicle := optimization.Vehicle{
+		Plate: route.Vehicle.Plate,
+		StartLocation: optimization.VehicleLocation{
+			AddressInfo: optimization.AddressInfo{
+				Coordinates: optimization.Coordinates{
+					Latitude:  r.CurrentLocation.Latitude,
+					Longitude: r.CurrentLocation.Longitude,
+				},
+			},
+		},
+		EndLocation: optimization.VehicleLocation{
+			AddressInfo: route.Vehicle.EndLocation.AddressInfo.mapOptimization(),
+			NodeInfo: optimization.NodeInfo{
+				ReferenceID: route.Vehicle.EndLocation.NodeInfo.ReferenceID,
+			},
+		},
+		Skills: route.Vehicle.Skills,
+		Capacity: optimization.Capacity{
+			Volume:    route.Vehicle.Capacity.Volume,
+			Weight:    route.Vehicle.Capacity.Weight,
+			Insurance: route.Vehicle.Capacity.Insurance,
+		},
+		TimeWindows: remainingTimeWindows(route.Vehicle, now),
+	}
+
+	var visits []optimization.Visit
+	for _, v := range route.Visits {
+		// Las pausas se replanifican y los retiros ya ocurrieron al iniciar la ruta. Las
+		// visitas que antes quedaron sin asignar se vuelven a intentar.
+		if v.Type == "break" || v.Type == "pickup" {
+			continue
+		}
+		var orders []optimization.Order
+		for _, o := range v.Orders {
+			var deliveryUnits []optimization.DeliveryUnit
+			for _, du := range o.DeliveryUnits {
+				if isClosed(o.ReferenceID, du.Lpn) {
+					continue
+				}
+				deliveryUnits = append(deliveryUnits, du.mapOptimization())
+			}
+			if len(deliveryUnits) == 0 && (len(o.DeliveryUnits) > 0 || isClosed(o.ReferenceID, "")) {
+				continue
+			}
+			orders = append(orders, optimization.Order{
+				ReferenceID:   o.ReferenceID,
+				DeliveryUnits: deliveryUnits,
+			})
+		}
+		if len(orders) == 0 {
+			continue
+		}
+		addressInfo := v.AddressInfo.mapOptimization()
+		addressInfo.Contact = optimization.Contact{
+			Email:      v.Orders[0].Contact.Email,
+			Phone:      v.Orders[0].Contact.Phone,
+			NationalID: v.Orders[0].Contact.NationalID,
+			FullName:   v.Orders[0].Contact.FullName,
+		}
+		visits = append(visits, optimization.Visit{
+			Delivery: optimization.VisitLocation{
+				Instructions: v.Orders[0].DeliveryInstructions,
+				AddressInfo:  addressInfo,
+				NodeInfo:     optimization.NodeInfo{ReferenceID: v.NodeInfo.ReferenceID},
+				ServiceTime:  v.ServiceTime,
+				TimeWindows:  mapRouteTimeWindows(v.TimeWindows),
+			},
+			Orders: orders,
+		})
+	}
+
+	return optimization.FleetOptimization{
+		PlanReferenceID: route.PlanReferenceID,
+		Vehicles:        []optimization.Vehicle{vehicle},
+		Visits:          visits,
+	}
+}
+
+// remainingTimeWindows recorta las ventanas del vehículo para que comiencen ahora. Las
+// ventanas cuyo fin es anterior a su inicio terminan al día siguiente.
+func remainingTimeWindows(v UpsertRouteVehicle, now time.Time) []optimization.TimeWindow {
+	windows := v.TimeWindows
+	if len(windows) == 0 && v.TimeWindow.Start != "" && v.TimeWindow.End != "" {
+		windows = []UpsertRouteTimeWindow{v.TimeWindow}
+	}
+	current := now.Hour()*60 + now.Minute()
+	var out []optimization.TimeWindow
+	for _, tw := range windows {
+		start, end := clockMinutes(tw.Start), clockMinutes(tw.End)
+		if end <= start {
+			end += minutesPerDay
+		}
+		elapsed := current
+		if elapsed+minutesPerDay < end {
+			// Ya pasó la medianoche de una ventana que comenzó ayer
+			elapsed += minutesPerDay
+		}
+		if end <= elapsed {
+			continue
+		}
+		out = append(out, optimization.TimeWindow{
+			Start: formatClockMinutes(max(start, elapsed)),
+			End:   formatClockMinutes(end),
+		})
+	}
+	if len(out) == 0 {
+		// Sin ventanas o con la jornada ya terminada se secuencia igual hasta el fin del día
+		out = append(out, optimization.TimeWindow{Start: formatClockMinutes(current), End: "23:59"})
+	}
+	return out
+}
+
+// clockMinutes convierte "HH:MM" a minutos desde medianoche
+func clockMinutes(clock string) int {
+	var h, m int
+	fmt.Sscanf(clock, "%02d:%02d", &h, &m)
+	return h*60 + m
+}
+
+// formatClockMinutes convierte minutos desde medianoche, aún del día siguiente, a "HH:MM"
+func formatClockMinutes(minutes int) string {
+	minutes %= minutesPerDay
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func mapRouteTimeWindows(windows []UpsertRouteTimeWindow) []optimization.TimeWindow {
+	var out []optimization.TimeWindow
+	for _, tw := range windows {
+		out = append(out, optimization.TimeWindow{Start: tw.Start, End: tw.End})
+	}
+	return out
+}
+
+func (a UpsertRouteAddressInfo) mapOptimization() optimization.AddressInfo {
+	return optimization.AddressInfo{
+		AddressLine1: a.AddressLine1,
+		AddressLine2: a.AddressLine2,
+		Coordinates: optimization.Coordinates{
+			Latitude:  a.Coordinates.Latitude,
+			Longitude: a.Coordinates.Longitude,
+		},
+		PoliticalArea: optimization.PoliticalArea{
+			Code:            a.PoliticalArea.Code,
+			AdminAreaLevel1: a.PoliticalArea.AdminAreaLevel1,
+			AdminAreaLevel2: a.PoliticalArea.AdminAreaLevel2,
+			AdminAreaLevel3: a.PoliticalArea.AdminAreaLevel3,
+			AdminAreaLevel4: a.PoliticalArea.AdminAreaLevel4,
+			TimeZone:        a.PoliticalArea.TimeZone,
+		},
+		ZipCode: a.ZipCode,
+	}
+}
+
+func (du UpsertRouteDeliveryUnit) mapOptimization() optimization.DeliveryUnit {
+	items := make([]optimization.Item, 0, len(du.Items))
+	for _, item := range du.Items {
+		items = append(items, optimization.Item{
+			Sku:         item.Sku,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+		})
+	}
+	return optimization.DeliveryUnit{
+		Items:  items,
+		Price:  du.Price,
+		Volume: du.Volume,
+		Weight: du.Weight,
+		Lpn:    du.Lpn,
+		Skills: du.Skills,
+	}
+}
@@ -0,0 +1,147 @@
+package request
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReoptimizeRouteRequest_Map(t *testing.T) {
+	route := UpsertRouteRequest{
+		ReferenceID:     "ROUTE-001",
+		PlanReferenceID: "PLAN-001",
+		Vehicle: UpsertRouteVehicle{
+			Plate:       "ABCD12",
+			TimeWindows: []UpsertRouteTimeWindow{{Start: "08:00", End: "18:00"}},
+		},
+		Visits: []UpsertRouteVisit{
+			{
+				Type:           "delivery",
+				SequenceNumber: 1,
+				Orders: []UpsertRouteOrder{{
+					ReferenceID:   "ORDER-DELIVERED",
+					DeliveryUnits: []UpsertRouteDeliveryUnit{{Lpn: "LPN-1"}},
+				}},
+			},
+			{Type: "break", SequenceNumber: 2},
+			{
+				Type:           "delivery",
+				SequenceNumber: 3,
+				AddressInfo: UpsertRouteAddressInfo{
+					Coordinates: UpsertRouteCoordinates{Latitude: -33.45, Longitude: -70.66},
+				},
+				ServiceTime: 300,
+				Orders: []UpsertRouteOrder{{
+					ReferenceID: "ORDER-PARTIAL",
+					Contact:     UpsertRouteContact{FullName: "Juan"},
+					DeliveryUnits: []UpsertRouteDeliveryUnit{
+						{Lpn: "LPN-2"},
+						{Lpn: "LPN-3", Weight: 1000},
+					},
+				}},
+			},
+		},
+	}
+	closed := map[string]bool{
+		"ORDER-DELIVERED/LPN-1": true,
+		"ORDER-DELIVERED/":      true,
+		"ORDER-PARTIAL/LPN-2":   true,
+	}
+	isClosed := func(orderReferenceID, lpn string) bool {
+		return closed[orderReferenceID+"/"+lpn]
+	}
+
+	var input ReoptimizeRouteRequest
+	input.CurrentLocation.Latitude = -33.44
+	input.CurrentLocation.Longitude = -70.65
+	now := time.Date(2025, 3, 28, 12, 30, 0, 0, time.UTC)
+
+	fleet := input.Map(route, isClosed, now)
+
+	if fleet.PlanReferenceID != "PLAN-001" {
+		t.Errorf("plan reference = %q, want PLAN-001", fleet.PlanReferenceID)
+	}
+	if len(fleet.Vehicles) != 1 {
+		t.Fatalf("vehicles = %d, want 1", len(fleet.Vehicles))
+	}
+	vehicle := fleet.Vehicles[0]
+	if vehicle.StartLocation.AddressInfo.Coordinates.Latitude != -33.44 ||
+		vehicle.StartLocation.AddressInfo.Coordinates.Longitude != -70.65 {
+		t.Errorf("vehicle should start at the driver's current location, got %+v", vehicle.StartLocation.AddressInfo.Coordinates)
+	}
+	if len(vehicle.TimeWindows) != 1 || vehicle.TimeWindows[0].Start != "12:30" || vehicle.TimeWindows[0].End != "18:00" {
+		t.Errorf("vehicle windows = %+v, want [12:30-18:00]", vehicle.TimeWindows)
+	}
+
+	if len(fleet.Visits) != 1 {
+		t.Fatalf("visits = %d, want 1 pending visit", len(fleet.Visits))
+	}
+	visit := fleet.Visits[0]
+	if visit.Delivery.ServiceTime != 300 || visit.Delivery.AddressInfo.Contact.FullName != "Juan" {
+		t.Errorf("unexpected pending visit %+v", visit.Delivery)
+	}
+	if len(visit.Orders) != 1 || len(visit.Orders[0].DeliveryUnits) != 1 || visit.Orders[0].DeliveryUnits[0].Lpn != "LPN-3" {
+		t.Errorf("only the pending delivery unit should remain, got %+v", visit.Orders)
+	}
+}
+
+func TestReoptimizeRouteRequest_MapAfterShift(t *testing.T) {
+	route := UpsertRouteRequest{
+		Vehicle: UpsertRouteVehicle{
+			TimeWindow: UpsertRouteTimeWindow{Start: "08:00", End: "18:00"},
+		},
+	}
+	fleet := ReoptimizeRouteRequest{}.Map(route, func(string, string) bool { return false },
+		time.Date(2025, 3, 28, 19, 0, 0, 0, time.UTC))
+
+	windows := fleet.Vehicles[0].TimeWindows
+	if len(windows) != 1 || windows[0].Start != "19:00" || windows[0].End != "23:59" {
+		t.Errorf("vehicle windows = %+v, want [19:00-23:59]", windows)
+	}
+}
+
+func TestReoptimizeRouteRequest_MapAcrossMidnight(t *testing.T) {
+	route := UpsertRouteRequest{
+		Vehicle: UpsertRouteVehicle{
+			TimeWindows: []UpsertRouteTimeWindow{{Start: "22:00", End: "02:00"}},
+		},
+	}
+	cases := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2025, 3, 28, 21, 0, 0, 0, time.UTC), "22:00-02:00"},
+		{time.Date(2025, 3, 28, 23, 15, 0, 0, time.UTC), "23:15-02:00"},
+		{time.Date(2025, 3, 29, 1, 30, 0, 0, time.UTC), "01:30-02:00"},
+	}
+	for _, c := range cases {
+		windows := ReoptimizeRouteRequest{}.Map(route, func(string, string) bool { return false }, c.now).Vehicles[0].TimeWindows
+		if len(windows) != 1 || windows[0].Start+"-"+windows[0].End != c.want {
+			t.Errorf("at %s vehicle windows = %+v, want [%s]", c.now.Format("15:04"), windows, c.want)
+		}
+	}
+}
+
+func TestReoptimizeRouteRequest_MapInRouteTimeZone(t *testing.T) {
+	route := UpsertRouteRequest{
+		Vehicle: UpsertRouteVehicle{
+			StartLocation: UpsertRouteVehicleLocation{
+				AddressInfo: UpsertRouteAddressInfo{
+					PoliticalArea: UpsertRoutePoliticalArea{TimeZone: "America/Santiago"},
+				},
+			},
+			TimeWindows: []UpsertRouteTimeWindow{{Start: "08:00", End: "18:00"}},
+		},
+	}
+	// 15:30 UTC son las 12:30 en Santiago (UTC-3 en marzo)
+	now := time.Date(2025, 3, 28, 15, 30, 0, 0, time.UTC)
+
+	windows := ReoptimizeRouteRequest{}.Map(route, func(string, string) bool { return false }, now).Vehicles[0].TimeWindows
+	if len(windows) != 1 || windows[0].Start != "12:30" || windows[0].End != "18:00" {
+		t.Errorf("vehicle windows = %+v, want [12:30-18:00]", windows)
+	}
+
+	windows = ReoptimizeRouteRequest{TimeZone: "UTC"}.Map(route, func(string, string) bool { return false }, now).Vehicles[0].TimeWindows
+	if len(windows) != 1 || windows[0].Start != "15:30" {
+		t.Errorf("the requested time zone should take precedence, got %+v", windows)
+	}
+}
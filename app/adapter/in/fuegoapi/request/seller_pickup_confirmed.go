@@ -0,0 +1,34 @@
+package request
+
+import (
+	"time"
+	"transport-app/app/domain"
+)
+
+type SellerPickupConfirmedWebhookBody struct {
+	Node          string   `json:"node"`
+	Vehicle       string   `json:"vehicle,omitempty"`
+	Orders        []string `json:"orders"`
+	DeliveryUnits []string `json:"deliveryUnits"`
+	PickedAt      string   `json:"pickedAt,omitempty"`
+}
+
+// MapSellerPickupConfirmedWebhookBody informa qué unidades van en camino al hub
+func MapSellerPickupConfirmedWebhookBody(route domain.Route) SellerPickupConfirmedWebhookBody {
+	body := SellerPickupConfirmedWebhookBody{
+		Vehicle:       route.Vehicle.Plate,
+		Orders:        make([]string, 0, len(route.Orders)),
+		DeliveryUnits: make([]string, 0),
+	}
+	for _, order := range route.Orders {
+		body.Node = order.Origin.ReferenceID.String()
+		body.Orders = append(body.Orders, order.ReferenceID.String())
+		for _, du := range order.DeliveryUnits {
+			body.DeliveryUnits = append(body.DeliveryUnits, du.Lpn)
+			if !du.ConfirmDelivery.HandledAt.IsZero() {
+				body.PickedAt = du.ConfirmDelivery.HandledAt.Format(time.RFC3339)
+			}
+		}
+	}
+	return body
+}
@@ -0,0 +1,13 @@
+package request
+
+import "transport-app/app/domain"
+
+type UpdateDeliveryGeofenceRequest struct {
+	RadiusMeters float64 `json:"radiusMeters" validate:"required,gt=0" example:"300"`
+}
+
+func (r UpdateDeliveryGeofenceRequest) Map() domain.DeliveryGeofence {
+	return domain.DeliveryGeofence{
+		RadiusMeters: r.RadiusMeters,
+	}
+}
@@ -0,0 +1,5 @@
+package request
+
+type UpdateDeliveryPinPolicyRequest struct {
+	Policy string `json:"policy" validate:"omitempty,oneof=disabled flag reject" example:"reject"`
+}
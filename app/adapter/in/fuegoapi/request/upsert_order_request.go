@@ -209,13 +209,16 @@ type UpsertOrderDestination struct {
 type UpsertOrderOrderType struct {
 	Description string `json:"description"`
 	Type        string `json:"type"`
+	// Política de PIN de entrega para el tipo de orden: disabled, flag o reject
+	DeliveryPinPolicy string `json:"deliveryPinPolicy,omitempty" example:"reject"`
 }
 
 // Map convierte a domain.OrderType
 func (o UpsertOrderOrderType) Map() domain.OrderType {
 	return domain.OrderType{
-		Type:        o.Type,
-		Description: o.Description,
+		Type:              o.Type,
+		Description:       o.Description,
+		DeliveryPinPolicy: o.DeliveryPinPolicy,
 	}
 }
 
@@ -337,7 +340,6 @@ func (d UpsertOrderDeliveryUnit) Map() domain.DeliveryUnit {
 		Volume:       volumePtr,
 		Weight:       weightPtr,
 		Price:        d.Price,
-		Status:       domain.Status{Status: domain.StatusAvailable},
 		Skills:       skills,
 		Labels:       labels,
 		Items:        items,
@@ -0,0 +1,25 @@
+package request
+
+import "transport-app/app/domain"
+
+type UpsertPriorityRulesRequest struct {
+	Rules []UpsertPriorityRule `json:"rules"`
+}
+
+type UpsertPriorityRule struct {
+	ServiceCategory       string `json:"serviceCategory" example:"EXPRESS" description:"Service category of the order; empty applies to every category"`
+	MaxDaysToPromisedDate *int   `json:"maxDaysToPromisedDate,omitempty" example:"0" description:"Applies when the promised date is at most this many days away; omit to ignore the date"`
+	Priority              int    `json:"priority" example:"100" description:"Visit priority from 0 to 100"`
+}
+
+func (req UpsertPriorityRulesRequest) Map() domain.PriorityRules {
+	rules := make(domain.PriorityRules, len(req.Rules))
+	for i, r := range req.Rules {
+		rules[i] = domain.PriorityRule{
+			ServiceCategory:       r.ServiceCategory,
+			MaxDaysToPromisedDate: r.MaxDaysToPromisedDate,
+			Priority:              r.Priority,
+		}
+	}
+	return rules
+}
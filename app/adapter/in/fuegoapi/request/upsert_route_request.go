@@ -12,9 +12,15 @@ type UpsertRouteRequest struct {
 	// Información del plan de optimización original
 	PlanReferenceID string `json:"planReferenceID,omitempty" example:"PLAN-001"`
 
+	// Revisión de la ruta; aumenta cada vez que se re-optimiza en curso
+	Revision int `json:"revision,omitempty" example:"1"`
+
 	// Información del vehículo
 	Vehicle UpsertRouteVehicle `json:"vehicle,omitempty"`
 
+	// Costo de la ruta según el modelo de costos del vehículo (fijo + por hora + por km)
+	Cost int64 `json:"cost,omitempty" example:"12500"`
+
 	// Geometría de la ruta
 	Geometry UpsertRouteGeometry `json:"geometry,omitempty"`
 
@@ -28,6 +34,7 @@ type UpsertRouteVehicle struct {
 	EndLocation   UpsertRouteVehicleLocation `json:"endLocation,omitempty"`
 	Skills        []string                   `json:"skills,omitempty"`
 	TimeWindow    UpsertRouteTimeWindow      `json:"timeWindow,omitempty"`
+	TimeWindows   []UpsertRouteTimeWindow    `json:"timeWindows,omitempty"`
 	Capacity      UpsertRouteVehicleCapacity `json:"capacity,omitempty"`
 }
 
@@ -67,6 +74,7 @@ type UpsertRoutePoliticalArea struct {
 	AdminAreaLevel2 string `json:"adminAreaLevel2,omitempty"`
 	AdminAreaLevel3 string `json:"adminAreaLevel3,omitempty"`
 	AdminAreaLevel4 string `json:"adminAreaLevel4,omitempty"`
+	TimeZone        string `json:"timeZone,omitempty" example:"America/Santiago"`
 }
 
 type UpsertRouteVehicleCapacity struct {
@@ -87,13 +95,21 @@ type UpsertRouteGeometry struct {
 }
 
 type UpsertRouteVisit struct {
+	// delivery, pickup o break (pausa del conductor)
 	Type           string                 `json:"type,omitempty" example:"delivery"`
 	AddressInfo    UpsertRouteAddressInfo `json:"addressInfo,omitempty"`
 	NodeInfo       UpsertRouteNodeInfo    `json:"nodeInfo,omitempty"`
 	SequenceNumber int                    `json:"sequenceNumber,omitempty" example:"1"`
 	ServiceTime    int64                  `json:"serviceTime,omitempty"`
-	TimeWindow     UpsertRouteTimeWindow  `json:"timeWindow,omitempty"`
-	Orders         []UpsertRouteOrder     `json:"orders,omitempty"`
+	// Ventana en la que quedó planificado el servicio
+	TimeWindow UpsertRouteTimeWindow `json:"timeWindow,omitempty"`
+	// Ventanas ofrecidas por el cliente para la visita
+	TimeWindows []UpsertRouteTimeWindow `json:"timeWindows,omitempty"`
+	// Hora planificada de inicio del servicio o de la pausa (HH:MM)
+	PlannedTime string `json:"plannedTime,omitempty" example:"13:00"`
+	// Descripción de la pausa (solo visitas de tipo break)
+	Description string             `json:"description,omitempty" example:"Lunch"`
+	Orders      []UpsertRouteOrder `json:"orders,omitempty"`
 	// Motivo de no asignación (solo para rutas UNASSIGNED)
 	UnassignedReason string `json:"unassignedReason,omitempty" example:"Vehicle capacity exceeded"`
 }
@@ -174,7 +190,12 @@ func (r *UpsertRouteRequest) Map() (domain.Route, error) {
 	for _, v := range r.Visits {
 		for _, o := range v.Orders {
 			order := domain.Order{
-				ReferenceID: domain.ReferenceID(o.ReferenceID),
+				ReferenceID:          domain.ReferenceID(o.ReferenceID),
+				DeliveryInstructions: o.DeliveryInstructions,
+				UnassignedReason:     v.UnassignedReason,
+			}
+			for _, du := range o.DeliveryUnits {
+				order.DeliveryUnits = append(order.DeliveryUnits, du.mapDomain())
 			}
 			orders = append(orders, order)
 		}
@@ -194,3 +215,33 @@ func (r *UpsertRouteRequest) Map() (domain.Route, error) {
 
 	return route, nil
 }
+
+func (du UpsertRouteDeliveryUnit) mapDomain() domain.DeliveryUnit {
+	items := make([]domain.Item, 0, len(du.Items))
+	for _, item := range du.Items {
+		items = append(items, domain.Item{
+			Sku:         item.Sku,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+		})
+	}
+	deliveryUnit := domain.DeliveryUnit{
+		Lpn:   du.Lpn,
+		Items: items,
+	}
+	deliveryUnit.SetValues(du.Volume, du.Weight, du.Price)
+	return deliveryUnit
+}
+
+// timeZones retorna las zonas horarias informadas en las direcciones de la ruta,
+// comenzando por las del vehículo
+func (r UpsertRouteRequest) timeZones() []string {
+	zones := []string{
+		r.Vehicle.StartLocation.AddressInfo.PoliticalArea.TimeZone,
+		r.Vehicle.EndLocation.AddressInfo.PoliticalArea.TimeZone,
+	}
+	for _, v := range r.Visits {
+		zones = append(zones, v.AddressInfo.PoliticalArea.TimeZone)
+	}
+	return zones
+}
@@ -0,0 +1,6 @@
+package response
+
+type DeletePriorityRuleResponse struct {
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
@@ -0,0 +1,6 @@
+package response
+
+type DriverLocationsResponse struct {
+	Accepted int    `json:"accepted" example:"20"`
+	Message  string `json:"message" example:"Driver locations submitted successfully"`
+}
@@ -0,0 +1,19 @@
+package response
+
+type ImportOrdersRowError struct {
+	Row         int    `json:"row" example:"3"`
+	ReferenceID string `json:"referenceID,omitempty" example:"ORD-1001"`
+	Error       string `json:"error" example:"invalid deliveryUnits.weight \"abc\": must be a number"`
+}
+
+type ImportOrdersResponse struct {
+	Message        string                 `json:"message" example:"Orders import submitted"`
+	DryRun         bool                   `json:"dryRun"`
+	MappingSource  string                 `json:"mappingSource" example:"ai"`
+	Mapping        map[string]string      `json:"mapping"`
+	TotalRows      int                    `json:"totalRows" example:"120"`
+	AcceptedOrders int                    `json:"acceptedOrders" example:"98"`
+	RejectedRows   int                    `json:"rejectedRows" example:"4"`
+	Errors         []ImportOrdersRowError `json:"errors,omitempty"`
+	ErrorReportURL string                 `json:"errorReportUrl,omitempty" example:"https://gateway.storjshare.io/bucket/orders/imports/abc/errors.csv?signature=download"`
+}
@@ -0,0 +1,48 @@
+package response
+
+import (
+	"time"
+	"transport-app/app/domain"
+)
+
+type LiveDriverPositionsResponse struct {
+	Positions []DriverPositionResponse `json:"positions"`
+}
+
+type DriverPositionResponse struct {
+	Route struct {
+		ReferenceID string `json:"referenceID" example:"ROUTE-001"`
+	} `json:"route"`
+	Vehicle struct {
+		Plate string `json:"plate" example:"ABC123"`
+	} `json:"vehicle"`
+	Driver struct {
+		Email      string `json:"email,omitempty" example:"juan@example.com"`
+		NationalID string `json:"nationalID,omitempty" example:"1234567890"`
+	} `json:"driver"`
+	RecordedAt     string  `json:"recordedAt" example:"2025-06-06T14:30:00Z"`
+	Latitude       float64 `json:"latitude" example:"-33.5147889"`
+	Longitude      float64 `json:"longitude" example:"-70.6130425"`
+	SpeedKmh       float64 `json:"speedKmh" example:"42.5"`
+	AccuracyMeters float64 `json:"accuracyMeters" example:"8"`
+}
+
+func MapLiveDriverPositionsResponse(positions []domain.DriverLocation) LiveDriverPositionsResponse {
+	res := LiveDriverPositionsResponse{
+		Positions: make([]DriverPositionResponse, 0, len(positions)),
+	}
+	for _, position := range positions {
+		var p DriverPositionResponse
+		p.Route.ReferenceID = position.RouteReferenceID
+		p.Vehicle.Plate = position.VehiclePlate
+		p.Driver.Email = position.Driver.Email
+		p.Driver.NationalID = position.Driver.NationalID
+		p.RecordedAt = position.RecordedAt.UTC().Format(time.RFC3339)
+		p.Latitude = position.Point.Lat()
+		p.Longitude = position.Point.Lon()
+		p.SpeedKmh = position.SpeedKmh
+		p.AccuracyMeters = position.AccuracyMeters
+		res.Positions = append(res.Positions, p)
+	}
+	return res
+}
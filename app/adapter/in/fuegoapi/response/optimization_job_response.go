@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+	"transport-app/app/domain"
+)
+
+type OptimizationJobResponse struct {
+	JobID string `json:"jobID" example:"optimize_fleet:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	// queued, running, succeeded o failed
+	Status          string   `json:"status" example:"succeeded"`
+	QueuedAt        string   `json:"queuedAt,omitempty" example:"2025-01-15T10:30:00Z"`
+	StartedAt       string   `json:"startedAt,omitempty" example:"2025-01-15T10:30:02Z"`
+	FinishedAt      string   `json:"finishedAt,omitempty" example:"2025-01-15T10:30:40Z"`
+	DurationSeconds float64  `json:"durationSeconds,omitempty" example:"38"`
+	Error           string   `json:"error,omitempty"`
+	PlanReferenceID string   `json:"planReferenceID,omitempty" example:"PLAN-001"`
+	Routes          []string `json:"routes,omitempty"`
+}
+
+// MapOptimizationJobResponse expone el job con tiempos en RFC3339 y la duración calculada a now
+func MapOptimizationJobResponse(job domain.OptimizationJob, now time.Time) OptimizationJobResponse {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return OptimizationJobResponse{
+		JobID:           job.ID,
+		Status:          job.Status,
+		QueuedAt:        formatTime(job.QueuedAt),
+		StartedAt:       formatTime(job.StartedAt),
+		FinishedAt:      formatTime(job.FinishedAt),
+		DurationSeconds: job.Duration(now).Seconds(),
+		Error:           job.Error,
+		PlanReferenceID: job.PlanReferenceID,
+		Routes:          job.Routes,
+	}
+}
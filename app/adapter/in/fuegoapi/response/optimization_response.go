@@ -2,4 +2,6 @@ package response
 
 type OptimizationResponse struct {
 	TraceID string `json:"traceID" example:"123e4567-e89b-12d3-a456-426614174000"`
+	// Identificador para consultar el estado en GET /optimize/fleet/{jobId}
+	JobID string `json:"jobID,omitempty" example:"optimize_fleet:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
 }
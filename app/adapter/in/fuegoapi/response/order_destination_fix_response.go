@@ -0,0 +1,9 @@
+package response
+
+type OrderDestinationFixResponse struct {
+	Message     string `json:"message"`
+	Coordinates struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"coordinates"`
+}
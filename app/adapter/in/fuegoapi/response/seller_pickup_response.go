@@ -0,0 +1,5 @@
+package response
+
+type SellerPickupResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,6 @@
+package response
+
+type UpdateDeliveryGeofenceResponse struct {
+	RadiusMeters float64 `json:"radiusMeters" example:"300"`
+	Message      string  `json:"message" example:"Delivery geofence updated successfully"`
+}
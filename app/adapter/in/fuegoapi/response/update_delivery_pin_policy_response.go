@@ -0,0 +1,6 @@
+package response
+
+type UpdateDeliveryPinPolicyResponse struct {
+	Policy  string `json:"policy" example:"reject"`
+	Message string `json:"message" example:"Delivery pin policy updated successfully"`
+}
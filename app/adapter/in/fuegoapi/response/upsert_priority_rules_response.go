@@ -0,0 +1,6 @@
+package response
+
+type UpsertPriorityRulesResponse struct {
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
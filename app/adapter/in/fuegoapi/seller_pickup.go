@@ -1,20 +1,80 @@
 package fuegoapi
 
 import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
 	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/natspublisher"
+	"transport-app/app/domain"
 	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 	"github.com/go-fuego/fuego"
 	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
 )
 
 func init() {
-	ioc.Registry(picking, httpserver.New)
+	ioc.Registry(
+		picking,
+		httpserver.New,
+		natspublisher.NewApplicationEvents,
+		observability.NewObservability)
 }
-func picking(s httpserver.Server) {
+func picking(
+	s httpserver.Server,
+	publish natspublisher.ApplicationEvents,
+	obs observability.Observability) {
 	fuego.Post(s.Manager, "/seller/pickup",
-		func(c fuego.ContextWithBody[request.PickingVisitConfirmedRequest]) (any, error) {
-			return "unimplemented", nil
-		}, option.Summary("confirm seller pickup"), option.Tags("logistic train"))
+		func(c fuego.ContextWithBody[request.PickingVisitConfirmedRequest]) (response.SellerPickupResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "sellerPickup")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return response.SellerPickupResponse{}, err
+			}
+
+			if err := requestBody.Validate(); err != nil {
+				return response.SellerPickupResponse{}, fuego.HTTPError{
+					Title:  "error validating seller pickup",
+					Detail: err.Error(),
+					Status: http.StatusBadRequest,
+				}
+			}
+
+			eventPayload, _ := json.Marshal(requestBody)
+
+			eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
+				sharedcontext.EventContext{
+					EntityType: "pickup",
+					EventType:  "sellerPickupConfirmed",
+				})
+
+			if err := publish(eventCtx, domain.Outbox{
+				Payload: eventPayload,
+			}); err != nil {
+				return response.SellerPickupResponse{}, fuego.HTTPError{
+					Title:  "error submitting seller pickup",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+			obs.Logger.InfoContext(spanCtx,
+				"SELLER_PICKUP_SUBMITTED",
+				slog.Any("payload", requestBody))
+
+			return response.SellerPickupResponse{
+				Message: "Seller pickup submitted successfully",
+			}, nil
+		},
+		option.Summary("confirm seller pickup"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("channel", "api channel", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags("logistic train"))
 }
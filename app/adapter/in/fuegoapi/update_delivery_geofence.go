@@ -0,0 +1,66 @@
+package fuegoapi
+
+import (
+	"errors"
+	"net/http"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		updateDeliveryGeofence,
+		httpserver.New,
+		observability.NewObservability,
+		tidbrepository.NewUpdateTenantDeliveryGeofence)
+}
+
+func updateDeliveryGeofence(
+	s httpserver.Server,
+	obs observability.Observability,
+	updateGeofence tidbrepository.UpdateTenantDeliveryGeofence) {
+	fuego.Put(s.Manager, "/tenants/delivery-geofence",
+		func(c fuego.ContextWithBody[request.UpdateDeliveryGeofenceRequest]) (response.UpdateDeliveryGeofenceResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "updateDeliveryGeofence")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return response.UpdateDeliveryGeofenceResponse{}, err
+			}
+
+			err = updateGeofence(spanCtx, requestBody.Map())
+			if errors.Is(err, tidbrepository.ErrTenantNotFound) {
+				return response.UpdateDeliveryGeofenceResponse{}, fuego.HTTPError{
+					Title:  "tenant not found",
+					Detail: err.Error(),
+					Status: http.StatusNotFound,
+				}
+			}
+			if err != nil {
+				return response.UpdateDeliveryGeofenceResponse{}, fuego.HTTPError{
+					Title:  "error updating delivery geofence",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			return response.UpdateDeliveryGeofenceResponse{
+				RadiusMeters: requestBody.RadiusMeters,
+				Message:      "Delivery geofence updated successfully",
+			}, nil
+		},
+		option.Summary("update delivery geofence"),
+		option.Description("Radius in meters around the destination beyond which a delivery confirmation is flagged as suspicious"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagTenants))
+}
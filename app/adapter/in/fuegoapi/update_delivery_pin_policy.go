@@ -0,0 +1,66 @@
+package fuegoapi
+
+import (
+	"errors"
+	"net/http"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		updateDeliveryPinPolicy,
+		httpserver.New,
+		observability.NewObservability,
+		tidbrepository.NewUpdateTenantDeliveryPinPolicy)
+}
+
+func updateDeliveryPinPolicy(
+	s httpserver.Server,
+	obs observability.Observability,
+	updatePolicy tidbrepository.UpdateTenantDeliveryPinPolicy) {
+	fuego.Put(s.Manager, "/tenants/delivery-pin-policy",
+		func(c fuego.ContextWithBody[request.UpdateDeliveryPinPolicyRequest]) (response.UpdateDeliveryPinPolicyResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "updateDeliveryPinPolicy")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return response.UpdateDeliveryPinPolicyResponse{}, err
+			}
+
+			err = updatePolicy(spanCtx, requestBody.Policy)
+			if errors.Is(err, tidbrepository.ErrTenantNotFound) {
+				return response.UpdateDeliveryPinPolicyResponse{}, fuego.HTTPError{
+					Title:  "tenant not found",
+					Detail: err.Error(),
+					Status: http.StatusNotFound,
+				}
+			}
+			if err != nil {
+				return response.UpdateDeliveryPinPolicyResponse{}, fuego.HTTPError{
+					Title:  "error updating delivery pin policy",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			return response.UpdateDeliveryPinPolicyResponse{
+				Policy:  requestBody.Policy,
+				Message: "Delivery pin policy updated successfully",
+			}, nil
+		},
+		option.Summary("update delivery pin policy"),
+		option.Description("Default delivery pin policy (disabled, flag or reject) for order types that do not define their own. An empty policy leaves the decision to each order type"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagTenants))
+}
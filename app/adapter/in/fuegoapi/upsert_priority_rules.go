@@ -0,0 +1,74 @@
+package fuegoapi
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/fuegoapi/response"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/shared/infrastructure/httpserver"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-fuego/fuego"
+	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
+)
+
+func init() {
+	ioc.Registry(
+		upsertPriorityRules,
+		httpserver.New,
+		tidbrepository.NewUpsertPriorityRules,
+		observability.NewObservability)
+}
+
+func upsertPriorityRules(
+	s httpserver.Server,
+	upsertPriorityRules tidbrepository.UpsertPriorityRules,
+	obs observability.Observability) {
+	fuego.Post(s.Manager, "/optimize/priority-rules",
+		func(c fuego.ContextWithBody[request.UpsertPriorityRulesRequest]) (response.UpsertPriorityRulesResponse, error) {
+			spanCtx, span := obs.Tracer.Start(c.Context(), "upsertPriorityRules")
+			defer span.End()
+
+			requestBody, err := c.Body()
+			if err != nil {
+				return response.UpsertPriorityRulesResponse{}, err
+			}
+
+			rules := requestBody.Map()
+			for i, rule := range rules {
+				if err := rule.Validate(); err != nil {
+					return response.UpsertPriorityRulesResponse{}, fuego.HTTPError{
+						Title:  "error upserting priority rules",
+						Detail: fmt.Sprintf("rule %d: %s", i, err.Error()),
+						Status: http.StatusBadRequest,
+					}
+				}
+			}
+
+			if err := upsertPriorityRules(spanCtx, rules); err != nil {
+				return response.UpsertPriorityRulesResponse{}, fuego.HTTPError{
+					Title:  "error upserting priority rules",
+					Detail: err.Error(),
+					Status: http.StatusInternalServerError,
+				}
+			}
+
+			obs.Logger.InfoContext(spanCtx,
+				"PRIORITY_RULES_UPSERTED",
+				slog.Int("rules", len(rules)))
+
+			return response.UpsertPriorityRulesResponse{
+				Message: "Priority rules upserted successfully",
+				Status:  "upserted",
+			}, nil
+		},
+		option.Summary("upsert optimization priority rules"),
+		option.Description("Per-tenant rules that derive visit priority from the order service category and promised date"),
+		option.Header("tenant", "api tenant (required only for local development)", param.Required()),
+		option.Header("X-Access-Token", "api access token"),
+		option.Tags(tagPlanning))
+}
@@ -106,6 +106,7 @@ func (r *queryResolver) DeliveryUnitsReports(
 			deliveryUnitsFilter.Destination = mappedFilter.Destination
 			deliveryUnitsFilter.PromisedDate = mappedFilter.PromisedDate
 			deliveryUnitsFilter.CollectAvailability = mappedFilter.CollectAvailability
+			deliveryUnitsFilter.Delivery = mappedFilter.Delivery
 			deliveryUnitsFilter.OnlyLatestStatus = mappedFilter.OnlyLatestStatus
 		}
 	}
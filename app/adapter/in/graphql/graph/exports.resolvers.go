@@ -0,0 +1,75 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
+
+	"github.com/google/uuid"
+)
+
+// ExportDeliveryUnits is the resolver for the exportDeliveryUnits field.
+func (r *mutationResolver) ExportDeliveryUnits(ctx context.Context, filter *model.DeliveryUnitsReportFilterInput, format model.DeliveryUnitsExportFormat) (*model.DeliveryUnitsExportJob, error) {
+	spanCtx, span := r.obs.Tracer.Start(ctx, "graphqlExportDeliveryUnits")
+	defer span.End()
+
+	exportFormat := mapper.MapDeliveryUnitsExportFormat(format)
+	if err := exportFormat.Validate(); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+	exportRequest := domain.DeliveryUnitsExportRequest{
+		JobID:  uuid.NewString(),
+		Format: exportFormat,
+	}
+	if deliveryUnitsFilter := mapper.MapDeliveryUnitsFilter(filter); deliveryUnitsFilter != nil {
+		exportRequest.Filter = *deliveryUnitsFilter
+	}
+
+	// El job se guarda en cola antes de publicar para que pueda consultarse de inmediato
+	job := domain.NewDeliveryUnitsExportJob(exportRequest.JobID, exportFormat, time.Now())
+	if err := r.saveDeliveryUnitsExportJob(spanCtx, job); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorInternal, err)
+	}
+
+	eventPayload, _ := json.Marshal(exportRequest)
+	eventCtx := sharedcontext.AddEventContextToBaggage(spanCtx,
+		sharedcontext.EventContext{
+			EntityType: "deliveryUnits",
+			EventType:  "deliveryUnitsExportRequested",
+		})
+	if err := r.publish(eventCtx, domain.Outbox{
+		Payload: eventPayload,
+	}); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorInternal, err)
+	}
+
+	r.obs.Logger.InfoContext(spanCtx,
+		"DELIVERY_UNITS_EXPORT_REQUESTED",
+		slog.String("jobID", job.ID),
+		slog.String("format", string(job.Format)))
+
+	return mapper.MapDeliveryUnitsExportJob(job), nil
+}
+
+// DeliveryUnitsExport is the resolver for the deliveryUnitsExport field.
+func (r *queryResolver) DeliveryUnitsExport(ctx context.Context, id string) (*model.DeliveryUnitsExportJob, error) {
+	job, err := r.findDeliveryUnitsExportJob(ctx, id)
+	if errors.Is(err, usecase.ErrDeliveryUnitsExportJobNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapDeliveryUnitsExportJob(job), nil
+}
@@ -0,0 +1,110 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+)
+
+// Vehicles is the resolver for the vehicles field.
+func (r *queryResolver) Vehicles(ctx context.Context, filter *model.VehicleFilterInput, first *int, after *string, last *int, before *string) (*model.VehicleConnection, error) {
+	pagination, err := relayPagination(first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := mapper.MapVehiclesFilter(filter)
+	filters.Pagination = pagination
+	filters.RequestedFields = ConvertSelectedPathsToMap(ctx)
+
+	results, hasMore, err := r.findVehiclesProjectionResult(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := mapper.MapVehicles(results)
+	edges := make([]*model.VehicleEdge, len(nodes))
+	cursors := make([]string, len(nodes))
+	for i, node := range nodes {
+		cursors[i] = encodeCursor(results[i].ID)
+		edges[i] = &model.VehicleEdge{
+			Cursor: cursors[i],
+			Node:   node,
+		}
+	}
+
+	return &model.VehicleConnection{
+		Edges:    edges,
+		PageInfo: relayPageInfo(cursors, hasMore, last, before),
+	}, nil
+}
+
+// Drivers is the resolver for the drivers field.
+func (r *queryResolver) Drivers(ctx context.Context, filter *model.DriverFilterInput, first *int, after *string, last *int, before *string) (*model.DriverConnection, error) {
+	pagination, err := relayPagination(first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := mapper.MapDriversFilter(filter)
+	filters.Pagination = pagination
+	filters.RequestedFields = ConvertSelectedPathsToMap(ctx)
+
+	results, hasMore, err := r.findDriversProjectionResult(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := mapper.MapDrivers(results)
+	edges := make([]*model.DriverEdge, len(nodes))
+	cursors := make([]string, len(nodes))
+	for i, node := range nodes {
+		cursors[i] = encodeCursor(results[i].ID)
+		edges[i] = &model.DriverEdge{
+			Cursor: cursors[i],
+			Node:   node,
+		}
+	}
+
+	return &model.DriverConnection{
+		Edges:    edges,
+		PageInfo: relayPageInfo(cursors, hasMore, last, before),
+	}, nil
+}
+
+// Carriers is the resolver for the carriers field.
+func (r *queryResolver) Carriers(ctx context.Context, filter *model.CarrierFilterInput, first *int, after *string, last *int, before *string) (*model.CarrierConnection, error) {
+	pagination, err := relayPagination(first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := mapper.MapCarriersFilter(filter)
+	filters.Pagination = pagination
+	filters.RequestedFields = ConvertSelectedPathsToMap(ctx)
+
+	results, hasMore, err := r.findCarriersProjectionResult(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := mapper.MapCarriers(results)
+	edges := make([]*model.CarrierEdge, len(nodes))
+	cursors := make([]string, len(nodes))
+	for i, node := range nodes {
+		cursors[i] = encodeCursor(results[i].ID)
+		edges[i] = &model.CarrierEdge{
+			Cursor: cursors[i],
+			Node:   node,
+		}
+	}
+
+	return &model.CarrierConnection{
+		Edges:    edges,
+		PageInfo: relayPageInfo(cursors, hasMore, last, before),
+	}, nil
+}
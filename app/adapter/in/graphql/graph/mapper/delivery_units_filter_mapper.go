@@ -179,6 +179,11 @@ func MapDeliveryUnitsFilter(filter *model.DeliveryUnitsReportFilterInput) *domai
 			}
 		}
 
+		originFilter.GeoFilter = MapGeoFilter(
+			filter.Origin.WithinPolygon,
+			filter.Origin.WithinRadius,
+			filter.Origin.BoundingBox)
+
 		deliveryUnitsFilter.Origin = originFilter
 	}
 
@@ -260,6 +265,11 @@ func MapDeliveryUnitsFilter(filter *model.DeliveryUnitsReportFilterInput) *domai
 			}
 		}
 
+		destinationFilter.GeoFilter = MapGeoFilter(
+			filter.Destination.WithinPolygon,
+			filter.Destination.WithinRadius,
+			filter.Destination.BoundingBox)
+
 		deliveryUnitsFilter.Destination = destinationFilter
 	}
 
@@ -308,5 +318,21 @@ func MapDeliveryUnitsFilter(filter *model.DeliveryUnitsReportFilterInput) *domai
 		deliveryUnitsFilter.CollectAvailability = collectAvailabilityFilter
 	}
 
+	// Filtros de Delivery
+	if filter.Delivery != nil {
+		deliveryFilter := &domain.DeliveryFilter{
+			SuspiciousLocation: filter.Delivery.SuspiciousLocation,
+		}
+
+		if filter.Delivery.DistanceToDestinationMeters != nil {
+			deliveryFilter.DistanceToDestinationMeters = &domain.DistanceRangeFilter{
+				Min: filter.Delivery.DistanceToDestinationMeters.Min,
+				Max: filter.Delivery.DistanceToDestinationMeters.Max,
+			}
+		}
+
+		deliveryUnitsFilter.Delivery = deliveryFilter
+	}
+
 	return deliveryUnitsFilter
 }
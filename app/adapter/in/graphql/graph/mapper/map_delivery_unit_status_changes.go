@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"time"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+)
+
+func MapDeliveryUnitStatusChangedFilter(filter *model.DeliveryUnitStatusChangedFilter) domain.DeliveryUnitStatusChangeFilter {
+	if filter == nil {
+		return domain.DeliveryUnitStatusChangeFilter{}
+	}
+	return domain.DeliveryUnitStatusChangeFilter{
+		OrderReferenceIDs: filter.OrderReferenceIds,
+		RouteReferenceIDs: filter.RouteReferenceIds,
+		Statuses:          filter.Statuses,
+	}
+}
+
+func MapDeliveryUnitStatusChange(change domain.DeliveryUnitStatusChange) *model.DeliveryUnitStatusChange {
+	return &model.DeliveryUnitStatusChange{
+		OrderReferenceID: change.OrderReferenceID,
+		Lpn:              optionalString(change.Lpn),
+		RouteReferenceID: optionalString(change.RouteReferenceID),
+		VehiclePlate:     optionalString(change.VehiclePlate),
+		Status:           change.Status,
+		OccurredAt:       change.OccurredAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func MapRouteProgress(progress domain.RouteProgress) *model.RouteProgress {
+	return &model.RouteProgress{
+		RouteReferenceID:   progress.RouteReferenceID,
+		Total:              progress.Total,
+		InTransit:          progress.InTransit,
+		Delivered:          progress.Delivered,
+		PartiallyDelivered: progress.PartiallyDelivered,
+		NotDelivered:       progress.NotDelivered,
+		Cancelled:          progress.Cancelled,
+	}
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 	"transport-app/app/adapter/in/graphql/graph/model"
 	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/adapter/out/tidbrepository/table"
 )
 
 // formatDate extrae la fecha en formato YYYY-MM-DD de un string en formato RFC3339
@@ -70,6 +71,8 @@ func MapDeliveryUnits(ctx context.Context, deliveryUnits []projectionresult.Deli
 					}
 					return photos
 				}(),
+				DistanceToDestinationMeters: du.DistanceToDestinationMeters,
+				SuspiciousLocation:          &du.SuspiciousLocation,
 			},
 			ManualChange: &model.ManualChange{
 				PerformedBy: &du.ManualChangePerformedBy,
@@ -232,6 +235,10 @@ func MapDeliveryUnits(ctx context.Context, deliveryUnits []projectionresult.Deli
 						du.JSONItems[0].Quantity == 0 {
 						return []*model.Item{}
 					}
+					confirmations := make(map[string]table.ItemConfirmation, len(du.ItemConfirmations))
+					for _, confirmation := range du.ItemConfirmations {
+						confirmations[confirmation.Sku] = confirmation
+					}
 					items := make([]*model.Item, len(du.JSONItems))
 					for i, item := range du.JSONItems {
 						items[i] = &model.Item{
@@ -247,6 +254,12 @@ func MapDeliveryUnits(ctx context.Context, deliveryUnits []projectionresult.Deli
 							Price:  &item.Price,
 							Weight: &item.Weight,
 						}
+						// Cantidades informadas en la confirmación de una entrega parcial
+						if confirmation, ok := confirmations[item.Sku]; ok {
+							items[i].DeliveredQuantity = &confirmation.DeliveredQuantity
+							items[i].RejectedQuantity = &confirmation.RejectedQuantity
+							items[i].RejectionReason = &confirmation.ReasonCode
+						}
 					}
 					return items
 				}(),
@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"strings"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+)
+
+// MapDeliveryUnitsExportFormat convierte el enum de GraphQL (CSV, XLSX, GEOJSON) al formato del dominio
+func MapDeliveryUnitsExportFormat(format model.DeliveryUnitsExportFormat) domain.DeliveryUnitsExportFormat {
+	return domain.DeliveryUnitsExportFormat(strings.ToLower(string(format)))
+}
+
+func MapDeliveryUnitsExportJob(job domain.DeliveryUnitsExportJob) *model.DeliveryUnitsExportJob {
+	return &model.DeliveryUnitsExportJob{
+		ID:          job.ID,
+		Status:      job.Status,
+		Format:      model.DeliveryUnitsExportFormat(strings.ToUpper(string(job.Format))),
+		QueuedAt:    optionalTime(job.QueuedAt),
+		StartedAt:   optionalTime(job.StartedAt),
+		FinishedAt:  optionalTime(job.FinishedAt),
+		Error:       optionalString(job.Error),
+		Rows:        job.Rows,
+		DownloadURL: optionalString(job.DownloadURL),
+		ExpiresAt:   optionalTime(job.ExpiresAt),
+	}
+}
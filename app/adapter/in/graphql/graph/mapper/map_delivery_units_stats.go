@@ -0,0 +1,56 @@
+package mapper
+
+import (
+	"math"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+)
+
+var deliveryUnitsStatsGroupBy = map[model.DeliveryUnitsStatsGroupBy]domain.DeliveryUnitsStatsGroupBy{
+	model.DeliveryUnitsStatsGroupByStatus:          domain.DeliveryUnitsStatsGroupByStatus,
+	model.DeliveryUnitsStatsGroupByAdminAreaLevel1: domain.DeliveryUnitsStatsGroupByAdminAreaLevel1,
+	model.DeliveryUnitsStatsGroupByAdminAreaLevel2: domain.DeliveryUnitsStatsGroupByAdminAreaLevel2,
+	model.DeliveryUnitsStatsGroupByAdminAreaLevel3: domain.DeliveryUnitsStatsGroupByAdminAreaLevel3,
+	model.DeliveryUnitsStatsGroupByAdminAreaLevel4: domain.DeliveryUnitsStatsGroupByAdminAreaLevel4,
+	model.DeliveryUnitsStatsGroupByPromisedDate:    domain.DeliveryUnitsStatsGroupByPromisedDate,
+	model.DeliveryUnitsStatsGroupByOrderType:       domain.DeliveryUnitsStatsGroupByOrderType,
+}
+
+// MapDeliveryUnitsStatsGrouping convierte el enum de GraphQL; los valores desconocidos se
+// conservan para que la validación del dominio los rechace
+func MapDeliveryUnitsStatsGrouping(groupBy []model.DeliveryUnitsStatsGroupBy) domain.DeliveryUnitsStatsGrouping {
+	grouping := make(domain.DeliveryUnitsStatsGrouping, 0, len(groupBy))
+	for _, g := range groupBy {
+		mapped, ok := deliveryUnitsStatsGroupBy[g]
+		if !ok {
+			mapped = domain.DeliveryUnitsStatsGroupBy(g)
+		}
+		grouping = append(grouping, mapped)
+	}
+	return grouping
+}
+
+func MapDeliveryUnitsStats(results []projectionresult.DeliveryUnitsStatsResult) []*model.DeliveryUnitsStatsGroup {
+	groups := make([]*model.DeliveryUnitsStatsGroup, 0, len(results))
+	for _, result := range results {
+		group := &model.DeliveryUnitsStatsGroup{
+			Status:          result.Status,
+			AdminAreaLevel1: result.AdminAreaLevel1,
+			AdminAreaLevel2: result.AdminAreaLevel2,
+			AdminAreaLevel3: result.AdminAreaLevel3,
+			AdminAreaLevel4: result.AdminAreaLevel4,
+			OrderType:       result.OrderType,
+			Count:           int(result.Count),
+			TotalWeight:     int64(math.Round(result.TotalWeight)),
+			TotalVolume:     int64(math.Round(result.TotalVolume)),
+			TotalPrice:      int64(math.Round(result.TotalPrice)),
+		}
+		if result.PromisedDate != nil {
+			promisedDate := result.PromisedDate.Format("2006-01-02")
+			group.PromisedDate = &promisedDate
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
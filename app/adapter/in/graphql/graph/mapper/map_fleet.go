@@ -0,0 +1,86 @@
+package mapper
+
+import (
+	"strconv"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+)
+
+func MapVehiclesFilter(filter *model.VehicleFilterInput) domain.VehicleSearchFilters {
+	if filter == nil {
+		return domain.VehicleSearchFilters{}
+	}
+	vehiclesFilter := domain.VehicleSearchFilters{
+		Plates: filter.Plates,
+	}
+	if filter.CarrierNationalID != nil {
+		vehiclesFilter.CarrierReferenceID = *filter.CarrierNationalID
+	}
+	return vehiclesFilter
+}
+
+func MapDriversFilter(filter *model.DriverFilterInput) domain.DriverSearchFilters {
+	if filter == nil {
+		return domain.DriverSearchFilters{}
+	}
+	return domain.DriverSearchFilters{
+		NationalIDs: filter.NationalIds,
+		Emails:      filter.Emails,
+		Name:        filter.Name,
+	}
+}
+
+func MapCarriersFilter(filter *model.CarrierFilterInput) domain.CarrierSearchFilters {
+	if filter == nil {
+		return domain.CarrierSearchFilters{}
+	}
+	return domain.CarrierSearchFilters{
+		NationalIDs: filter.NationalIds,
+		Name:        filter.Name,
+	}
+}
+
+func MapVehicles(vehicles projectionresult.VehiclesProjectionResults) []*model.VehicleDetail {
+	mapped := make([]*model.VehicleDetail, len(vehicles))
+	for i, v := range vehicles {
+		maxPackagesQuantity := v.CategoryMaxPackagesQuantity
+		mapped[i] = &model.VehicleDetail{
+			ID:              strconv.FormatInt(v.ID, 10),
+			Plate:           optionalString(v.Plate),
+			CertificateDate: optionalString(v.CertificateDate),
+			Category: &model.VehicleCategory{
+				Type:                optionalString(v.CategoryType),
+				MaxPackagesQuantity: &maxPackagesQuantity,
+			},
+			Carrier: &model.Carrier{
+				Name:       optionalString(v.CarrierName),
+				NationalID: optionalString(v.CarrierNationalID),
+			},
+		}
+	}
+	return mapped
+}
+
+func MapDrivers(drivers projectionresult.DriversProjectionResults) []*model.Driver {
+	mapped := make([]*model.Driver, len(drivers))
+	for i, d := range drivers {
+		mapped[i] = &model.Driver{
+			Name:       optionalString(d.Name),
+			NationalID: optionalString(d.NationalID),
+			Email:      optionalString(d.Email),
+		}
+	}
+	return mapped
+}
+
+func MapCarriers(carriers projectionresult.CarriersProjectionResults) []*model.Carrier {
+	mapped := make([]*model.Carrier, len(carriers))
+	for i, c := range carriers {
+		mapped[i] = &model.Carrier{
+			Name:       optionalString(c.Name),
+			NationalID: optionalString(c.NationalID),
+		}
+	}
+	return mapped
+}
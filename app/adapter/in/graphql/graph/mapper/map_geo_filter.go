@@ -0,0 +1,61 @@
+package mapper
+
+import (
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+
+	"github.com/paulmach/orb"
+)
+
+// MapGeoFilter convierte los filtros geográficos de GraphQL; GeoJSON usa el orden [lng, lat] igual que orb
+func MapGeoFilter(
+	withinPolygon *model.GeoJSONPolygon,
+	withinRadius *model.WithinRadiusFilter,
+	boundingBox *model.BoundingBoxFilter) domain.GeoFilter {
+	var geoFilter domain.GeoFilter
+	if withinPolygon != nil {
+		geoFilter.WithinPolygon = withinPolygon.Polygon
+	}
+	if withinRadius != nil && withinRadius.Center != nil {
+		geoFilter.WithinRadius = &domain.RadiusFilter{
+			Center: mapGeoPoint(withinRadius.Center),
+			Meters: withinRadius.Meters,
+		}
+	}
+	if boundingBox != nil && boundingBox.SouthWest != nil && boundingBox.NorthEast != nil {
+		geoFilter.BoundingBox = &orb.Bound{
+			Min: mapGeoPoint(boundingBox.SouthWest),
+			Max: mapGeoPoint(boundingBox.NorthEast),
+		}
+	}
+	return geoFilter
+}
+
+func mapGeoPoint(point *model.GeoPointInput) orb.Point {
+	return orb.Point{point.Longitude, point.Latitude}
+}
+
+// MapNodesFilter convierte el filtro de nodos de GraphQL al filtro del dominio
+func MapNodesFilter(filter *model.NodeFilterInput) domain.NodesFilter {
+	if filter == nil {
+		return domain.NodesFilter{}
+	}
+	nodesFilter := domain.NodesFilter{
+		Name:      filter.Name,
+		GeoFilter: MapGeoFilter(filter.WithinPolygon, filter.WithinRadius, filter.BoundingBox),
+	}
+	for _, ref := range filter.ReferenceIds {
+		if ref != nil {
+			nodesFilter.ReferenceIds = append(nodesFilter.ReferenceIds, *ref)
+		}
+	}
+	for _, ref := range filter.References {
+		if ref != nil {
+			nodesFilter.References = append(nodesFilter.References, domain.ReferenceFilter{
+				Type:  ref.Type,
+				Value: ref.Value,
+			})
+		}
+	}
+	return nodesFilter
+}
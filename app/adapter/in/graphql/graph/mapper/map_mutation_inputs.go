@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"encoding/json"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/graphql/graph/model"
+)
+
+// Los inputs de las mutaciones usan los mismos nombres de campo que los bodies REST,
+// por lo que se traducen a los requests de fuegoapi pasando por su representación JSON
+
+func MapUpsertOrderInput(input model.UpsertOrderInput) (request.UpsertOrderRequest, error) {
+	return mapInputToRequest[request.UpsertOrderRequest](input)
+}
+
+func MapCancelOrdersInput(input model.CancelOrdersInput) (request.CancelOrdersRequest, error) {
+	return mapInputToRequest[request.CancelOrdersRequest](input)
+}
+
+func MapConfirmDeliveriesInput(input model.ConfirmDeliveriesInput) (request.ConfirmDeliveriesRequest, error) {
+	return mapInputToRequest[request.ConfirmDeliveriesRequest](input)
+}
+
+func MapStartRouteInput(input model.StartRouteInput) (request.RouteStartedRequest, error) {
+	return mapInputToRequest[request.RouteStartedRequest](input)
+}
+
+func MapUpsertNodeInput(input model.UpsertNodeInput) (request.UpsertNodeRequest, error) {
+	return mapInputToRequest[request.UpsertNodeRequest](input)
+}
+
+func mapInputToRequest[T any](input any) (T, error) {
+	var req T
+	body, err := json.Marshal(input)
+	if err != nil {
+		return req, err
+	}
+	err = json.Unmarshal(body, &req)
+	return req, err
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"strconv"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+)
+
+func MapPlansFilter(filter *model.PlanFilterInput) domain.PlansFilter {
+	if filter == nil {
+		return domain.PlansFilter{}
+	}
+	plansFilter := domain.PlansFilter{
+		ReferenceIds: filter.ReferenceIds,
+	}
+	if filter.PlannedDate != nil {
+		plansFilter.PlannedDate = &domain.DateRangeFilter{
+			StartDate: filter.PlannedDate.StartDate,
+			EndDate:   filter.PlannedDate.EndDate,
+		}
+	}
+	return plansFilter
+}
+
+func MapPlans(plans projectionresult.PlansProjectionResults) []*model.Plan {
+	mapped := make([]*model.Plan, len(plans))
+	for i, p := range plans {
+		var plannedDate *string
+		if !p.PlannedDate.IsZero() {
+			formatted := p.PlannedDate.Format("2006-01-02")
+			plannedDate = &formatted
+		}
+		mapped[i] = &model.Plan{
+			ID:          strconv.FormatInt(p.ID, 10),
+			ReferenceID: optionalString(p.ReferenceID),
+			PlannedDate: plannedDate,
+			CreatedAt:   optionalTime(p.CreatedAt),
+			Commerce:    optionalString(p.Commerce),
+			Consumer:    optionalString(p.Consumer),
+			Channel:     optionalString(p.Channel),
+		}
+	}
+	return mapped
+}
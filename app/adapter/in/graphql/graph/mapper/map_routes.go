@@ -0,0 +1,179 @@
+package mapper
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+)
+
+func MapRoutesFilter(filter *model.RouteFilterInput) domain.RoutesFilter {
+	if filter == nil {
+		return domain.RoutesFilter{}
+	}
+	return domain.RoutesFilter{
+		ReferenceIds:     filter.ReferenceIds,
+		PlanReferenceIds: filter.PlanReferenceIds,
+		VehiclePlates:    filter.VehiclePlates,
+	}
+}
+
+func MapRoutes(routes projectionresult.RoutesProjectionResults) []*model.RouteDetail {
+	mapped := make([]*model.RouteDetail, len(routes))
+	for i, r := range routes {
+		mapped[i] = &model.RouteDetail{
+			ID:                  strconv.FormatInt(r.ID, 10),
+			ReferenceID:         optionalString(r.ReferenceID),
+			CreatedAt:           optionalTime(r.CreatedAt),
+			PlanReferenceID:     optionalString(r.PlanReferenceID),
+			NeedsReoptimization: &r.NeedsReoptimization,
+			Vehicle: &model.Vehicle{
+				Plate: optionalString(r.VehiclePlate),
+			},
+			Driver: &model.Driver{
+				Name:       optionalString(r.DriverName),
+				NationalID: optionalString(r.DriverNationalID),
+				Email:      optionalString(r.DriverEmail),
+			},
+			Carrier: &model.Carrier{
+				Name:       optionalString(r.CarrierName),
+				NationalID: optionalString(r.CarrierNationalID),
+			},
+			Visits: mapRouteVisits(r.Contract, r.DeliveryUnitStatuses),
+		}
+	}
+	return mapped
+}
+
+// mapRouteVisits lee el contrato de la ruta y devuelve sus visitas por secuencia, con el
+// estado vigente de cada unidad; las visitas no asignadas quedan al final
+func mapRouteVisits(
+	contract projectionresult.RouteContract,
+	statuses projectionresult.RouteDeliveryUnitStatuses) []*model.RouteVisit {
+	if len(contract) == 0 {
+		return nil
+	}
+	var route request.UpsertRouteRequest
+	if err := json.Unmarshal(contract, &route); err != nil {
+		// Hay rutas cuyo contrato no describe visitas (por ejemplo un string vacío)
+		return nil
+	}
+
+	visits := slices.Clone(route.Visits)
+	slices.SortStableFunc(visits, func(a, b request.UpsertRouteVisit) int {
+		if c := cmp.Compare(unassignedRank(a), unassignedRank(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+
+	mapped := make([]*model.RouteVisit, len(visits))
+	for i, v := range visits {
+		sequenceNumber := v.SequenceNumber
+		serviceTime := v.ServiceTime
+		mapped[i] = &model.RouteVisit{
+			SequenceNumber: &sequenceNumber,
+			Type:           optionalString(v.Type),
+			PlannedTime:    optionalString(v.PlannedTime),
+			ServiceTime:    &serviceTime,
+			TimeWindow: &model.TimeRange{
+				StartTime: optionalString(v.TimeWindow.Start),
+				EndTime:   optionalString(v.TimeWindow.End),
+			},
+			AddressInfo:      mapRouteAddressInfo(v.AddressInfo),
+			NodeInfo:         &model.NodeInfo{ReferenceID: optionalString(v.NodeInfo.ReferenceID)},
+			UnassignedReason: optionalString(v.UnassignedReason),
+			Orders:           mapRouteVisitOrders(v.Orders, statuses),
+		}
+	}
+	return mapped
+}
+
+func unassignedRank(v request.UpsertRouteVisit) int {
+	if v.UnassignedReason != "" {
+		return 1
+	}
+	return 0
+}
+
+func mapRouteAddressInfo(a request.UpsertRouteAddressInfo) *model.AddressInfo {
+	latitude := a.Coordinates.Latitude
+	longitude := a.Coordinates.Longitude
+	return &model.AddressInfo{
+		AddressLine1: optionalString(a.AddressLine1),
+		AddressLine2: optionalString(a.AddressLine2),
+		ZipCode:      optionalString(a.ZipCode),
+		Coordinates: &model.Coordinates{
+			Latitude:  &latitude,
+			Longitude: &longitude,
+		},
+		PoliticalArea: &model.PoliticalArea{
+			Code:            optionalString(a.PoliticalArea.Code),
+			AdminAreaLevel1: optionalString(a.PoliticalArea.AdminAreaLevel1),
+			AdminAreaLevel2: optionalString(a.PoliticalArea.AdminAreaLevel2),
+			AdminAreaLevel3: optionalString(a.PoliticalArea.AdminAreaLevel3),
+			AdminAreaLevel4: optionalString(a.PoliticalArea.AdminAreaLevel4),
+		},
+	}
+}
+
+func mapRouteVisitOrders(
+	orders []request.UpsertRouteOrder,
+	statuses projectionresult.RouteDeliveryUnitStatuses) []*model.RouteVisitOrder {
+	mapped := make([]*model.RouteVisitOrder, len(orders))
+	for i, o := range orders {
+		deliveryUnits := make([]*model.RouteDeliveryUnit, len(o.DeliveryUnits))
+		for j, du := range o.DeliveryUnits {
+			deliveryUnits[j] = mapRouteDeliveryUnit(du, statuses.Status(o.ReferenceID, du.Lpn))
+		}
+		mapped[i] = &model.RouteVisitOrder{
+			ReferenceID:   optionalString(o.ReferenceID),
+			Instructions:  optionalString(o.DeliveryInstructions),
+			DeliveryUnits: deliveryUnits,
+		}
+	}
+	return mapped
+}
+
+func mapRouteDeliveryUnit(du request.UpsertRouteDeliveryUnit, status string) *model.RouteDeliveryUnit {
+	volume, weight, price := du.Volume, du.Weight, du.Price
+
+	skills := make([]*string, len(du.Skills))
+	for i := range du.Skills {
+		skills[i] = &du.Skills[i]
+	}
+
+	items := make([]*model.Item, len(du.Items))
+	for i, item := range du.Items {
+		quantity := item.Quantity
+		items[i] = &model.Item{
+			Sku:         optionalString(item.Sku),
+			Description: optionalString(item.Description),
+			Quantity:    &quantity,
+		}
+	}
+
+	return &model.RouteDeliveryUnit{
+		Lpn:    optionalString(du.Lpn),
+		Status: optionalString(status),
+		Volume: &volume,
+		Weight: &weight,
+		Price:  &price,
+		Skills: skills,
+		Items:  items,
+	}
+}
+
+// optionalTime expone el instante en RFC3339 y omite los valores no seleccionados
+func optionalTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
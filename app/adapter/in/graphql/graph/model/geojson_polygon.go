@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+// GeoJSONPolygon es el scalar de los filtros geográficos. Acepta una geometría GeoJSON de tipo
+// Polygon como objeto o como string JSON.
+type GeoJSONPolygon struct {
+	orb.Polygon
+}
+
+func (p *GeoJSONPolygon) UnmarshalGQL(v any) error {
+	var data []byte
+	switch value := v.(type) {
+	case string:
+		data = []byte(value)
+	case map[string]any:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		data = encoded
+	default:
+		return fmt.Errorf("GeoJSONPolygon must be a GeoJSON object or string, got %T", v)
+	}
+
+	geometry, err := geojson.UnmarshalGeometry(data)
+	if err != nil {
+		return fmt.Errorf("invalid GeoJSONPolygon: %w", err)
+	}
+	polygon, ok := geometry.Geometry().(orb.Polygon)
+	if !ok {
+		return fmt.Errorf("invalid GeoJSONPolygon: expected type Polygon, got %s", geometry.Type)
+	}
+	p.Polygon = polygon
+	return nil
+}
+
+func (p GeoJSONPolygon) MarshalGQL(w io.Writer) {
+	data, err := geojson.NewGeometry(p.Polygon).MarshalJSON()
+	if err != nil {
+		io.WriteString(w, "null")
+		return
+	}
+	w.Write(data)
+}
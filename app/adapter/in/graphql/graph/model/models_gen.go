@@ -2,6 +2,13 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
 type AddressInfo struct {
 	AddressLine1  *string        `json:"addressLine1,omitempty"`
 	AddressLine2  *string        `json:"addressLine2,omitempty"`
@@ -11,16 +18,77 @@ type AddressInfo struct {
 	PoliticalArea *PoliticalArea `json:"politicalArea,omitempty"`
 }
 
+type AddressInfoInput struct {
+	AddressLine1  *string             `json:"addressLine1,omitempty"`
+	AddressLine2  *string             `json:"addressLine2,omitempty"`
+	Contact       *ContactInput       `json:"contact,omitempty"`
+	Coordinates   *CoordinatesInput   `json:"coordinates,omitempty"`
+	PoliticalArea *PoliticalAreaInput `json:"politicalArea,omitempty"`
+	ZipCode       *string             `json:"zipCode,omitempty"`
+}
+
+type BoundingBoxFilter struct {
+	SouthWest *GeoPointInput `json:"southWest"`
+	NorthEast *GeoPointInput `json:"northEast"`
+}
+
+type BusinessIdentifiersInput struct {
+	Commerce *string `json:"commerce,omitempty"`
+	Consumer *string `json:"consumer,omitempty"`
+}
+
+type CancelDeliveryUnitInput struct {
+	Lpn   *string     `json:"lpn,omitempty"`
+	Items []*SkuInput `json:"items,omitempty"`
+}
+
+type CancelOrderInput struct {
+	ReferenceID         string                     `json:"referenceID"`
+	BusinessIdentifiers *BusinessIdentifiersInput  `json:"businessIdentifiers,omitempty"`
+	DeliveryUnits       []*CancelDeliveryUnitInput `json:"deliveryUnits,omitempty"`
+}
+
+type CancelOrdersInput struct {
+	ManualChange       *ManualChangeInput      `json:"manualChange,omitempty"`
+	Orders             []*CancelOrderInput     `json:"orders"`
+	CancellationReason *NonDeliveryReasonInput `json:"cancellationReason,omitempty"`
+}
+
 type Carrier struct {
 	NationalID *string `json:"nationalID,omitempty"`
 	Name       *string `json:"name,omitempty"`
 }
 
+type CarrierConnection struct {
+	Edges    []*CarrierEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type CarrierEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Carrier `json:"node"`
+}
+
+type CarrierFilterInput struct {
+	NationalIds []string `json:"nationalIds,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+}
+
+type CarrierInput struct {
+	Name       *string `json:"name,omitempty"`
+	NationalID *string `json:"nationalID,omitempty"`
+}
+
 type CollectAvailabilityDate struct {
 	Date      *string    `json:"date,omitempty"`
 	TimeRange *TimeRange `json:"timeRange,omitempty"`
 }
 
+type CollectAvailabilityDateInput struct {
+	Date      *string         `json:"date,omitempty"`
+	TimeRange *TimeRangeInput `json:"timeRange,omitempty"`
+}
+
 type CollectAvailabilityFilter struct {
 	Dates     []*string        `json:"dates,omitempty"`
 	TimeRange *TimeRangeFilter `json:"timeRange,omitempty"`
@@ -32,6 +100,31 @@ type Confidence struct {
 	Reason  *string  `json:"reason,omitempty"`
 }
 
+type ConfidenceInput struct {
+	Level   *float64 `json:"level,omitempty"`
+	Message *string  `json:"message,omitempty"`
+	Reason  *string  `json:"reason,omitempty"`
+}
+
+type ConfirmDeliveriesInput struct {
+	ManualChange  *ManualChangeInput          `json:"manualChange,omitempty"`
+	Carrier       *CarrierInput               `json:"carrier,omitempty"`
+	Driver        *DriverInput                `json:"driver,omitempty"`
+	Vehicle       *VehicleInput               `json:"vehicle,omitempty"`
+	Route         *DeliveryRouteInput         `json:"route,omitempty"`
+	DeliveryUnits []*ConfirmDeliveryUnitInput `json:"deliveryUnits"`
+}
+
+type ConfirmDeliveryUnitInput struct {
+	OrderReferenceID    string                     `json:"orderReferenceID"`
+	BusinessIdentifiers *BusinessIdentifiersInput  `json:"businessIdentifiers,omitempty"`
+	Recipient           *RecipientInput            `json:"recipient,omitempty"`
+	Delivery            *DeliveryConfirmationInput `json:"delivery,omitempty"`
+	EvidencePhotos      []*EvidencePhotoInput      `json:"evidencePhotos,omitempty"`
+	Lpn                 *string                    `json:"lpn,omitempty"`
+	Items               []*DeliveredItemInput      `json:"items,omitempty"`
+}
+
 type Contact struct {
 	AdditionalContactMethods []*ContactMethod `json:"additionalContactMethods,omitempty"`
 	Documents                []*Document      `json:"documents,omitempty"`
@@ -41,6 +134,15 @@ type Contact struct {
 	Phone                    *string          `json:"phone,omitempty"`
 }
 
+type ContactInput struct {
+	AdditionalContactMethods []*TypeValueInput `json:"additionalContactMethods,omitempty"`
+	Documents                []*TypeValueInput `json:"documents,omitempty"`
+	Email                    *string           `json:"email,omitempty"`
+	FullName                 *string           `json:"fullName,omitempty"`
+	NationalID               *string           `json:"nationalID,omitempty"`
+	Phone                    *string           `json:"phone,omitempty"`
+}
+
 type ContactMethod struct {
 	Type  *string `json:"type,omitempty"`
 	Value *string `json:"value,omitempty"`
@@ -58,6 +160,13 @@ type CoordinatesConfidenceLevelFilter struct {
 	Max *float64 `json:"max,omitempty"`
 }
 
+type CoordinatesInput struct {
+	Latitude   *float64         `json:"latitude,omitempty"`
+	Longitude  *float64         `json:"longitude,omitempty"`
+	Source     *string          `json:"source,omitempty"`
+	Confidence *ConfidenceInput `json:"confidence,omitempty"`
+}
+
 type DateRange struct {
 	StartDate *string `json:"startDate,omitempty"`
 	EndDate   *string `json:"endDate,omitempty"`
@@ -68,12 +177,36 @@ type DateRangeFilter struct {
 	EndDate   *string `json:"endDate,omitempty"`
 }
 
+type DateRangeInput struct {
+	StartDate *string `json:"startDate,omitempty"`
+	EndDate   *string `json:"endDate,omitempty"`
+}
+
+type DeliveredItemInput struct {
+	Sku               *string `json:"sku,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	Quantity          *int    `json:"quantity,omitempty"`
+	DeliveredQuantity *int    `json:"deliveredQuantity,omitempty"`
+	RejectedQuantity  *int    `json:"rejectedQuantity,omitempty"`
+	ReasonCode        *string `json:"reasonCode,omitempty"`
+}
+
 type Delivery struct {
-	Recipient      *DeliveryRecipient `json:"recipient,omitempty"`
-	HandledAt      *string            `json:"handledAt,omitempty"`
-	Failure        *DeliveryFailure   `json:"failure,omitempty"`
-	Location       *DeliveryLocation  `json:"location,omitempty"`
-	EvidencePhotos []*EvidencePhoto   `json:"evidencePhotos,omitempty"`
+	Recipient                   *DeliveryRecipient `json:"recipient,omitempty"`
+	HandledAt                   *string            `json:"handledAt,omitempty"`
+	Failure                     *DeliveryFailure   `json:"failure,omitempty"`
+	Location                    *DeliveryLocation  `json:"location,omitempty"`
+	EvidencePhotos              []*EvidencePhoto   `json:"evidencePhotos,omitempty"`
+	DistanceToDestinationMeters *float64           `json:"distanceToDestinationMeters,omitempty"`
+	SuspiciousLocation          *bool              `json:"suspiciousLocation,omitempty"`
+}
+
+type DeliveryConfirmationInput struct {
+	HandledAt *string                 `json:"handledAt,omitempty"`
+	Status    *string                 `json:"status,omitempty"`
+	Location  *DeliveryLocationInput  `json:"location,omitempty"`
+	Pin       *string                 `json:"pin,omitempty"`
+	Failure   *NonDeliveryReasonInput `json:"failure,omitempty"`
 }
 
 type DeliveryFailure struct {
@@ -82,16 +215,31 @@ type DeliveryFailure struct {
 	ReferenceID *string `json:"referenceID,omitempty"`
 }
 
+type DeliveryFilter struct {
+	SuspiciousLocation          *bool                `json:"suspiciousLocation,omitempty"`
+	DistanceToDestinationMeters *DistanceRangeFilter `json:"distanceToDestinationMeters,omitempty"`
+}
+
 type DeliveryLocation struct {
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
 }
 
+type DeliveryLocationInput struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
 type DeliveryRecipient struct {
 	FullName   *string `json:"fullName,omitempty"`
 	NationalID *string `json:"nationalID,omitempty"`
 }
 
+type DeliveryRouteInput struct {
+	ReferenceID    *string `json:"referenceID,omitempty"`
+	SequenceNumber *int    `json:"sequenceNumber,omitempty"`
+}
+
 type DeliveryUnit struct {
 	SizeCategory *string   `json:"sizeCategory,omitempty"`
 	Volume       *int64    `json:"volume,omitempty"`
@@ -109,6 +257,34 @@ type DeliveryUnitFilter struct {
 	Labels         []*LabelFilterInput `json:"labels,omitempty"`
 }
 
+type DeliveryUnitStatusChange struct {
+	OrderReferenceID string  `json:"orderReferenceId"`
+	Lpn              *string `json:"lpn,omitempty"`
+	RouteReferenceID *string `json:"routeReferenceId,omitempty"`
+	VehiclePlate     *string `json:"vehiclePlate,omitempty"`
+	Status           string  `json:"status"`
+	OccurredAt       string  `json:"occurredAt"`
+}
+
+type DeliveryUnitStatusChangedFilter struct {
+	OrderReferenceIds []string `json:"orderReferenceIds,omitempty"`
+	RouteReferenceIds []string `json:"routeReferenceIds,omitempty"`
+	Statuses          []string `json:"statuses,omitempty"`
+}
+
+type DeliveryUnitsExportJob struct {
+	ID          string                    `json:"id"`
+	Status      string                    `json:"status"`
+	Format      DeliveryUnitsExportFormat `json:"format"`
+	QueuedAt    *string                   `json:"queuedAt,omitempty"`
+	StartedAt   *string                   `json:"startedAt,omitempty"`
+	FinishedAt  *string                   `json:"finishedAt,omitempty"`
+	Error       *string                   `json:"error,omitempty"`
+	Rows        int                       `json:"rows"`
+	DownloadURL *string                   `json:"downloadUrl,omitempty"`
+	ExpiresAt   *string                   `json:"expiresAt,omitempty"`
+}
+
 type DeliveryUnitsReport struct {
 	ID                      string                   `json:"id"`
 	Commerce                *string                  `json:"commerce,omitempty"`
@@ -151,9 +327,24 @@ type DeliveryUnitsReportFilterInput struct {
 	Destination         *LocationFilter            `json:"destination,omitempty"`
 	PromisedDate        *PromisedDateFilter        `json:"promisedDate,omitempty"`
 	CollectAvailability *CollectAvailabilityFilter `json:"collectAvailability,omitempty"`
+	Delivery            *DeliveryFilter            `json:"delivery,omitempty"`
 	OnlyLatestStatus    *bool                      `json:"onlyLatestStatus,omitempty"`
 }
 
+type DeliveryUnitsStatsGroup struct {
+	Status          *string `json:"status,omitempty"`
+	AdminAreaLevel1 *string `json:"adminAreaLevel1,omitempty"`
+	AdminAreaLevel2 *string `json:"adminAreaLevel2,omitempty"`
+	AdminAreaLevel3 *string `json:"adminAreaLevel3,omitempty"`
+	AdminAreaLevel4 *string `json:"adminAreaLevel4,omitempty"`
+	PromisedDate    *string `json:"promisedDate,omitempty"`
+	OrderType       *string `json:"orderType,omitempty"`
+	Count           int     `json:"count"`
+	TotalWeight     int64   `json:"totalWeight"`
+	TotalVolume     int64   `json:"totalVolume"`
+	TotalPrice      int64   `json:"totalPrice"`
+}
+
 type Dimension struct {
 	Length *int64  `json:"length,omitempty"`
 	Height *int64  `json:"height,omitempty"`
@@ -161,6 +352,17 @@ type Dimension struct {
 	Unit   *string `json:"unit,omitempty"`
 }
 
+type DimensionsInput struct {
+	Length *int64 `json:"length,omitempty"`
+	Height *int64 `json:"height,omitempty"`
+	Width  *int64 `json:"width,omitempty"`
+}
+
+type DistanceRangeFilter struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
 type Document struct {
 	Type  *string `json:"type,omitempty"`
 	Value *string `json:"value,omitempty"`
@@ -172,12 +374,44 @@ type Driver struct {
 	Email      *string `json:"email,omitempty"`
 }
 
+type DriverConnection struct {
+	Edges    []*DriverEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type DriverEdge struct {
+	Cursor string  `json:"cursor"`
+	Node   *Driver `json:"node"`
+}
+
+type DriverFilterInput struct {
+	NationalIds []string `json:"nationalIds,omitempty"`
+	Emails      []string `json:"emails,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+}
+
+type DriverInput struct {
+	Email      *string `json:"email,omitempty"`
+	NationalID *string `json:"nationalID,omitempty"`
+}
+
 type EvidencePhoto struct {
 	TakenAt *string `json:"takenAt,omitempty"`
 	Type    *string `json:"type,omitempty"`
 	URL     *string `json:"url,omitempty"`
 }
 
+type EvidencePhotoInput struct {
+	TakenAt *string `json:"takenAt,omitempty"`
+	Type    *string `json:"type,omitempty"`
+	URL     *string `json:"url,omitempty"`
+}
+
+type GeoPointInput struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
 type GroupBy struct {
 	Type  *string `json:"type,omitempty"`
 	Value *string `json:"value,omitempty"`
@@ -189,12 +423,15 @@ type GroupByFilter struct {
 }
 
 type Item struct {
-	Sku         *string    `json:"sku,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	Dimensions  *Dimension `json:"dimensions,omitempty"`
-	Price       *int64     `json:"price,omitempty"`
-	Quantity    *int       `json:"quantity,omitempty"`
-	Weight      *int64     `json:"weight,omitempty"`
+	Sku               *string    `json:"sku,omitempty"`
+	Description       *string    `json:"description,omitempty"`
+	Dimensions        *Dimension `json:"dimensions,omitempty"`
+	Price             *int64     `json:"price,omitempty"`
+	Quantity          *int       `json:"quantity,omitempty"`
+	Weight            *int64     `json:"weight,omitempty"`
+	DeliveredQuantity *int       `json:"deliveredQuantity,omitempty"`
+	RejectedQuantity  *int       `json:"rejectedQuantity,omitempty"`
+	RejectionReason   *string    `json:"rejectionReason,omitempty"`
 }
 
 type KeyValuePair struct {
@@ -226,6 +463,9 @@ type LocationFilter struct {
 	AdminAreaLevel4       []*string                         `json:"adminAreaLevel4,omitempty"`
 	ZipCodes              []*string                         `json:"zipCodes,omitempty"`
 	CoordinatesConfidence *CoordinatesConfidenceLevelFilter `json:"coordinatesConfidence,omitempty"`
+	WithinPolygon         *GeoJSONPolygon                   `json:"withinPolygon,omitempty"`
+	WithinRadius          *WithinRadiusFilter               `json:"withinRadius,omitempty"`
+	BoundingBox           *BoundingBoxFilter                `json:"boundingBox,omitempty"`
 }
 
 type ManualChange struct {
@@ -233,20 +473,48 @@ type ManualChange struct {
 	Reason      *string `json:"reason,omitempty"`
 }
 
+type ManualChangeInput struct {
+	PerformedBy *string `json:"performedBy,omitempty"`
+	Reason      *string `json:"reason,omitempty"`
+	Override    *bool   `json:"override,omitempty"`
+}
+
+type Mutation struct {
+}
+
+type NodeAddressInput struct {
+	AddressLine1  *string                 `json:"addressLine1,omitempty"`
+	AddressLine2  *string                 `json:"addressLine2,omitempty"`
+	Coordinates   *CoordinatesInput       `json:"coordinates,omitempty"`
+	PoliticalArea *NodePoliticalAreaInput `json:"politicalArea,omitempty"`
+	ZipCode       *string                 `json:"zipCode,omitempty"`
+}
+
 type NodeConnection struct {
 	Edges    []*NodeEdge `json:"edges"`
 	PageInfo *PageInfo   `json:"pageInfo"`
 }
 
+type NodeContactInput struct {
+	Documents  []*TypeValueInput `json:"documents,omitempty"`
+	Email      *string           `json:"email,omitempty"`
+	FullName   *string           `json:"fullName,omitempty"`
+	NationalID *string           `json:"nationalID,omitempty"`
+	Phone      *string           `json:"phone,omitempty"`
+}
+
 type NodeEdge struct {
 	Cursor string    `json:"cursor"`
 	Node   *Location `json:"node"`
 }
 
 type NodeFilterInput struct {
-	ReferenceIds []*string               `json:"referenceIds,omitempty"`
-	Name         *string                 `json:"name,omitempty"`
-	References   []*ReferenceFilterInput `json:"references,omitempty"`
+	ReferenceIds  []*string               `json:"referenceIds,omitempty"`
+	Name          *string                 `json:"name,omitempty"`
+	References    []*ReferenceFilterInput `json:"references,omitempty"`
+	WithinPolygon *GeoJSONPolygon         `json:"withinPolygon,omitempty"`
+	WithinRadius  *WithinRadiusFilter     `json:"withinRadius,omitempty"`
+	BoundingBox   *BoundingBoxFilter      `json:"boundingBox,omitempty"`
 }
 
 type NodeInfo struct {
@@ -256,6 +524,39 @@ type NodeInfo struct {
 	References  []*Reference `json:"references,omitempty"`
 }
 
+type NodePoliticalAreaInput struct {
+	ID              *string          `json:"id,omitempty"`
+	AdminAreaLevel1 *string          `json:"adminAreaLevel1,omitempty"`
+	AdminAreaLevel2 *string          `json:"adminAreaLevel2,omitempty"`
+	AdminAreaLevel3 *string          `json:"adminAreaLevel3,omitempty"`
+	AdminAreaLevel4 *string          `json:"adminAreaLevel4,omitempty"`
+	TimeZone        *string          `json:"timeZone,omitempty"`
+	Confidence      *ConfidenceInput `json:"confidence,omitempty"`
+}
+
+type NonDeliveryReasonInput struct {
+	Detail      *string `json:"detail,omitempty"`
+	Reason      *string `json:"reason,omitempty"`
+	ReferenceID *string `json:"referenceID,omitempty"`
+}
+
+type OrderDeliveryUnitInput struct {
+	Lpn          *string           `json:"lpn,omitempty"`
+	SizeCategory *string           `json:"sizeCategory,omitempty"`
+	Volume       *int64            `json:"volume,omitempty"`
+	Weight       *int64            `json:"weight,omitempty"`
+	Price        *int64            `json:"price,omitempty"`
+	Skills       []string          `json:"skills,omitempty"`
+	Labels       []*TypeValueInput `json:"labels,omitempty"`
+	Items        []*OrderItemInput `json:"items,omitempty"`
+}
+
+type OrderDestinationInput struct {
+	AddressInfo          *AddressInfoInput `json:"addressInfo,omitempty"`
+	DeliveryInstructions *string           `json:"deliveryInstructions,omitempty"`
+	NodeInfo             *ReferenceIDInput `json:"nodeInfo,omitempty"`
+}
+
 type OrderFilter struct {
 	ReferenceIds []*string               `json:"referenceIds,omitempty"`
 	References   []*ReferenceFilterInput `json:"references,omitempty"`
@@ -263,6 +564,20 @@ type OrderFilter struct {
 	GroupBy      *GroupByFilter          `json:"groupBy,omitempty"`
 }
 
+type OrderItemInput struct {
+	Sku         *string          `json:"sku,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Dimensions  *DimensionsInput `json:"dimensions,omitempty"`
+	Weight      *int64           `json:"weight,omitempty"`
+	Quantity    *int             `json:"quantity,omitempty"`
+	Price       *int64           `json:"price,omitempty"`
+}
+
+type OrderOriginInput struct {
+	AddressInfo *AddressInfoInput `json:"addressInfo,omitempty"`
+	NodeInfo    *ReferenceIDInput `json:"nodeInfo,omitempty"`
+}
+
 type OrderType struct {
 	Type        *string `json:"type,omitempty"`
 	Description *string `json:"description,omitempty"`
@@ -273,6 +588,12 @@ type OrderTypeFilter struct {
 	Description *string `json:"description,omitempty"`
 }
 
+type OrderTypeInput struct {
+	Type              *string `json:"type,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	DeliveryPinPolicy *string `json:"deliveryPinPolicy,omitempty"`
+}
+
 type PageInfo struct {
 	HasNextPage     bool    `json:"hasNextPage"`
 	HasPreviousPage bool    `json:"hasPreviousPage"`
@@ -280,6 +601,31 @@ type PageInfo struct {
 	EndCursor       string  `json:"endCursor"`
 }
 
+type Plan struct {
+	ID          string  `json:"id"`
+	ReferenceID *string `json:"referenceId,omitempty"`
+	PlannedDate *string `json:"plannedDate,omitempty"`
+	CreatedAt   *string `json:"createdAt,omitempty"`
+	Commerce    *string `json:"commerce,omitempty"`
+	Consumer    *string `json:"consumer,omitempty"`
+	Channel     *string `json:"channel,omitempty"`
+}
+
+type PlanConnection struct {
+	Edges    []*PlanEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type PlanEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Plan  `json:"node"`
+}
+
+type PlanFilterInput struct {
+	ReferenceIds []string         `json:"referenceIds,omitempty"`
+	PlannedDate  *DateRangeFilter `json:"plannedDate,omitempty"`
+}
+
 type PoliticalArea struct {
 	Code            *string     `json:"code,omitempty"`
 	AdminAreaLevel1 *string     `json:"adminAreaLevel1,omitempty"`
@@ -290,6 +636,16 @@ type PoliticalArea struct {
 	Confidence      *Confidence `json:"confidence,omitempty"`
 }
 
+type PoliticalAreaInput struct {
+	Code            *string          `json:"code,omitempty"`
+	AdminAreaLevel1 *string          `json:"adminAreaLevel1,omitempty"`
+	AdminAreaLevel2 *string          `json:"adminAreaLevel2,omitempty"`
+	AdminAreaLevel3 *string          `json:"adminAreaLevel3,omitempty"`
+	AdminAreaLevel4 *string          `json:"adminAreaLevel4,omitempty"`
+	TimeZone        *string          `json:"timeZone,omitempty"`
+	Confidence      *ConfidenceInput `json:"confidence,omitempty"`
+}
+
 type PromisedDate struct {
 	DateRange       *DateRange `json:"dateRange,omitempty"`
 	ServiceCategory *string    `json:"serviceCategory,omitempty"`
@@ -301,9 +657,20 @@ type PromisedDateFilter struct {
 	TimeRange *TimeRangeFilter `json:"timeRange,omitempty"`
 }
 
+type PromisedDateInput struct {
+	DateRange       *DateRangeInput `json:"dateRange,omitempty"`
+	ServiceCategory *string         `json:"serviceCategory,omitempty"`
+	TimeRange       *TimeRangeInput `json:"timeRange,omitempty"`
+}
+
 type Query struct {
 }
 
+type RecipientInput struct {
+	FullName   *string `json:"fullName,omitempty"`
+	NationalID *string `json:"nationalID,omitempty"`
+}
+
 type Reference struct {
 	Type  *string `json:"type,omitempty"`
 	Value *string `json:"value,omitempty"`
@@ -314,12 +681,103 @@ type ReferenceFilterInput struct {
 	Value string `json:"value"`
 }
 
+type ReferenceIDInput struct {
+	ReferenceID *string `json:"referenceID,omitempty"`
+}
+
 type Route struct {
 	RouteID       *string `json:"routeID,omitempty"`
 	LpnContainer  *string `json:"lpnContainer,omitempty"`
 	RoutePosition *int    `json:"routePosition,omitempty"`
 }
 
+type RouteConnection struct {
+	Edges    []*RouteEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+}
+
+type RouteDeliveryUnit struct {
+	Lpn    *string   `json:"lpn,omitempty"`
+	Status *string   `json:"status,omitempty"`
+	Volume *int64    `json:"volume,omitempty"`
+	Weight *int64    `json:"weight,omitempty"`
+	Price  *int64    `json:"price,omitempty"`
+	Skills []*string `json:"skills,omitempty"`
+	Items  []*Item   `json:"items,omitempty"`
+}
+
+type RouteDetail struct {
+	ID                  string        `json:"id"`
+	ReferenceID         *string       `json:"referenceId,omitempty"`
+	CreatedAt           *string       `json:"createdAt,omitempty"`
+	PlanReferenceID     *string       `json:"planReferenceId,omitempty"`
+	NeedsReoptimization *bool         `json:"needsReoptimization,omitempty"`
+	Vehicle             *Vehicle      `json:"vehicle,omitempty"`
+	Driver              *Driver       `json:"driver,omitempty"`
+	Carrier             *Carrier      `json:"carrier,omitempty"`
+	Visits              []*RouteVisit `json:"visits,omitempty"`
+}
+
+type RouteEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   *RouteDetail `json:"node"`
+}
+
+type RouteFilterInput struct {
+	ReferenceIds     []string `json:"referenceIds,omitempty"`
+	PlanReferenceIds []string `json:"planReferenceIds,omitempty"`
+	VehiclePlates    []string `json:"vehiclePlates,omitempty"`
+}
+
+type RouteProgress struct {
+	RouteReferenceID   string `json:"routeReferenceId"`
+	Total              int    `json:"total"`
+	InTransit          int    `json:"inTransit"`
+	Delivered          int    `json:"delivered"`
+	PartiallyDelivered int    `json:"partiallyDelivered"`
+	NotDelivered       int    `json:"notDelivered"`
+	Cancelled          int    `json:"cancelled"`
+}
+
+type RouteVisit struct {
+	SequenceNumber   *int               `json:"sequenceNumber,omitempty"`
+	Type             *string            `json:"type,omitempty"`
+	PlannedTime      *string            `json:"plannedTime,omitempty"`
+	ServiceTime      *int64             `json:"serviceTime,omitempty"`
+	TimeWindow       *TimeRange         `json:"timeWindow,omitempty"`
+	AddressInfo      *AddressInfo       `json:"addressInfo,omitempty"`
+	NodeInfo         *NodeInfo          `json:"nodeInfo,omitempty"`
+	UnassignedReason *string            `json:"unassignedReason,omitempty"`
+	Orders           []*RouteVisitOrder `json:"orders,omitempty"`
+}
+
+type RouteVisitOrder struct {
+	ReferenceID   *string              `json:"referenceId,omitempty"`
+	Instructions  *string              `json:"instructions,omitempty"`
+	DeliveryUnits []*RouteDeliveryUnit `json:"deliveryUnits,omitempty"`
+}
+
+type SkuInput struct {
+	Sku *string `json:"sku,omitempty"`
+}
+
+type StartRouteInput struct {
+	StartedAt *string           `json:"startedAt,omitempty"`
+	Carrier   *CarrierInput     `json:"carrier,omitempty"`
+	Driver    *DriverInput      `json:"driver,omitempty"`
+	Vehicle   *VehicleInput     `json:"vehicle,omitempty"`
+	Route     *ReferenceIDInput `json:"route"`
+}
+
+type SubmissionResult struct {
+	TraceID        string `json:"traceId"`
+	IdempotencyKey string `json:"idempotencyKey"`
+	Message        string `json:"message"`
+}
+
+type Subscription struct {
+}
+
 type TimeRange struct {
 	StartTime *string `json:"startTime,omitempty"`
 	EndTime   *string `json:"endTime,omitempty"`
@@ -330,6 +788,197 @@ type TimeRangeFilter struct {
 	EndTime   *string `json:"endTime,omitempty"`
 }
 
+type TimeRangeInput struct {
+	StartTime *string `json:"startTime,omitempty"`
+	EndTime   *string `json:"endTime,omitempty"`
+}
+
+type TypeValueInput struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type UpsertNodeInput struct {
+	ReferenceID string            `json:"referenceID"`
+	Name        *string           `json:"name,omitempty"`
+	Type        *string           `json:"type,omitempty"`
+	NodeAddress *NodeAddressInput `json:"nodeAddress,omitempty"`
+	Contact     *NodeContactInput `json:"contact,omitempty"`
+	References  []*TypeValueInput `json:"references,omitempty"`
+}
+
+type UpsertOrderInput struct {
+	ReferenceID             string                        `json:"referenceID"`
+	ExtraFields             map[string]any                `json:"extraFields,omitempty"`
+	GroupBy                 *TypeValueInput               `json:"groupBy,omitempty"`
+	CollectAvailabilityDate *CollectAvailabilityDateInput `json:"collectAvailabilityDate,omitempty"`
+	Destination             *OrderDestinationInput        `json:"destination,omitempty"`
+	OrderType               *OrderTypeInput               `json:"orderType,omitempty"`
+	Origin                  *OrderOriginInput             `json:"origin,omitempty"`
+	DeliveryUnits           []*OrderDeliveryUnitInput     `json:"deliveryUnits,omitempty"`
+	PromisedDate            *PromisedDateInput            `json:"promisedDate,omitempty"`
+	References              []*TypeValueInput             `json:"references,omitempty"`
+}
+
 type Vehicle struct {
 	Plate *string `json:"plate,omitempty"`
 }
+
+type VehicleCategory struct {
+	Type                *string `json:"type,omitempty"`
+	MaxPackagesQuantity *int    `json:"maxPackagesQuantity,omitempty"`
+}
+
+type VehicleConnection struct {
+	Edges    []*VehicleEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type VehicleDetail struct {
+	ID              string           `json:"id"`
+	Plate           *string          `json:"plate,omitempty"`
+	CertificateDate *string          `json:"certificateDate,omitempty"`
+	Category        *VehicleCategory `json:"category,omitempty"`
+	Carrier         *Carrier         `json:"carrier,omitempty"`
+}
+
+type VehicleEdge struct {
+	Cursor string         `json:"cursor"`
+	Node   *VehicleDetail `json:"node"`
+}
+
+type VehicleFilterInput struct {
+	Plates            []string `json:"plates,omitempty"`
+	CarrierNationalID *string  `json:"carrierNationalId,omitempty"`
+}
+
+type VehicleInput struct {
+	Plate *string `json:"plate,omitempty"`
+}
+
+type WithinRadiusFilter struct {
+	Center *GeoPointInput `json:"center"`
+	Meters float64        `json:"meters"`
+}
+
+type DeliveryUnitsExportFormat string
+
+const (
+	DeliveryUnitsExportFormatCSV     DeliveryUnitsExportFormat = "CSV"
+	DeliveryUnitsExportFormatXlsx    DeliveryUnitsExportFormat = "XLSX"
+	DeliveryUnitsExportFormatGeojson DeliveryUnitsExportFormat = "GEOJSON"
+)
+
+var AllDeliveryUnitsExportFormat = []DeliveryUnitsExportFormat{
+	DeliveryUnitsExportFormatCSV,
+	DeliveryUnitsExportFormatXlsx,
+	DeliveryUnitsExportFormatGeojson,
+}
+
+func (e DeliveryUnitsExportFormat) IsValid() bool {
+	switch e {
+	case DeliveryUnitsExportFormatCSV, DeliveryUnitsExportFormatXlsx, DeliveryUnitsExportFormatGeojson:
+		return true
+	}
+	return false
+}
+
+func (e DeliveryUnitsExportFormat) String() string {
+	return string(e)
+}
+
+func (e *DeliveryUnitsExportFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DeliveryUnitsExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DeliveryUnitsExportFormat", str)
+	}
+	return nil
+}
+
+func (e DeliveryUnitsExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DeliveryUnitsExportFormat) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DeliveryUnitsExportFormat) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DeliveryUnitsStatsGroupBy string
+
+const (
+	DeliveryUnitsStatsGroupByStatus          DeliveryUnitsStatsGroupBy = "STATUS"
+	DeliveryUnitsStatsGroupByAdminAreaLevel1 DeliveryUnitsStatsGroupBy = "ADMIN_AREA_LEVEL_1"
+	DeliveryUnitsStatsGroupByAdminAreaLevel2 DeliveryUnitsStatsGroupBy = "ADMIN_AREA_LEVEL_2"
+	DeliveryUnitsStatsGroupByAdminAreaLevel3 DeliveryUnitsStatsGroupBy = "ADMIN_AREA_LEVEL_3"
+	DeliveryUnitsStatsGroupByAdminAreaLevel4 DeliveryUnitsStatsGroupBy = "ADMIN_AREA_LEVEL_4"
+	DeliveryUnitsStatsGroupByPromisedDate    DeliveryUnitsStatsGroupBy = "PROMISED_DATE"
+	DeliveryUnitsStatsGroupByOrderType       DeliveryUnitsStatsGroupBy = "ORDER_TYPE"
+)
+
+var AllDeliveryUnitsStatsGroupBy = []DeliveryUnitsStatsGroupBy{
+	DeliveryUnitsStatsGroupByStatus,
+	DeliveryUnitsStatsGroupByAdminAreaLevel1,
+	DeliveryUnitsStatsGroupByAdminAreaLevel2,
+	DeliveryUnitsStatsGroupByAdminAreaLevel3,
+	DeliveryUnitsStatsGroupByAdminAreaLevel4,
+	DeliveryUnitsStatsGroupByPromisedDate,
+	DeliveryUnitsStatsGroupByOrderType,
+}
+
+func (e DeliveryUnitsStatsGroupBy) IsValid() bool {
+	switch e {
+	case DeliveryUnitsStatsGroupByStatus, DeliveryUnitsStatsGroupByAdminAreaLevel1, DeliveryUnitsStatsGroupByAdminAreaLevel2, DeliveryUnitsStatsGroupByAdminAreaLevel3, DeliveryUnitsStatsGroupByAdminAreaLevel4, DeliveryUnitsStatsGroupByPromisedDate, DeliveryUnitsStatsGroupByOrderType:
+		return true
+	}
+	return false
+}
+
+func (e DeliveryUnitsStatsGroupBy) String() string {
+	return string(e)
+}
+
+func (e *DeliveryUnitsStatsGroupBy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DeliveryUnitsStatsGroupBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DeliveryUnitsStatsGroupBy", str)
+	}
+	return nil
+}
+
+func (e DeliveryUnitsStatsGroupBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DeliveryUnitsStatsGroupBy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DeliveryUnitsStatsGroupBy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+	canonicaljson "transport-app/app/shared/caonincaljson"
+	"transport-app/app/shared/sharedcontext"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Códigos expuestos en extensions.code de los errores de las mutaciones
+const (
+	MutationErrorValidation = "VALIDATION_ERROR"
+	MutationErrorInternal   = "INTERNAL_ERROR"
+)
+
+// mutationError construye un error tipado que incluye el trace para correlacionarlo con los logs
+func mutationError(ctx context.Context, span trace.Span, code string, err error) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: err.Error(),
+		Path:    graphql.GetPath(ctx),
+		Extensions: map[string]any{
+			"code":    code,
+			"traceId": span.SpanContext().TraceID().String(),
+		},
+	}
+}
+
+// submitEvent publica el request con el mismo evento que su endpoint REST; la idempotency key
+// identifica el payload dentro del tenant, igual que en los consumers
+func (r *mutationResolver) submitEvent(
+	ctx context.Context,
+	span trace.Span,
+	eventContext sharedcontext.EventContext,
+	payload any,
+	message string) (*model.SubmissionResult, error) {
+	idempotencyKey, err := canonicaljson.HashKey(ctx, eventContext.EventType, payload)
+	if err != nil {
+		return nil, mutationError(ctx, span, MutationErrorInternal, err)
+	}
+
+	eventPayload, _ := json.Marshal(payload)
+
+	eventCtx := sharedcontext.AddEventContextToBaggage(ctx, eventContext)
+	if err := r.publish(eventCtx, domain.Outbox{
+		Payload: eventPayload,
+	}); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorInternal, err)
+	}
+
+	r.obs.Logger.InfoContext(ctx,
+		"GRAPHQL_MUTATION_SUBMITTED",
+		slog.String("eventType", eventContext.EventType),
+		slog.String("idempotencyKey", idempotencyKey),
+		slog.Any("payload", payload))
+
+	return &model.SubmissionResult{
+		TraceID:        span.SpanContext().TraceID().String(),
+		IdempotencyKey: idempotencyKey,
+		Message:        message,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	_ "embed"
+	"reflect"
+	"strings"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed mutations.graphqls
+var mutationsSchema string
+
+var _ = Describe("Mutations GraphQL Schema", func() {
+	var schema *ast.Schema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = gqlparser.LoadSchema(
+			&ast.Source{
+				Name:  "deliveryunits.graphqls",
+				Input: deliveryunitsSchema,
+			},
+			&ast.Source{
+				Name:  "mutations.graphqls",
+				Input: mutationsSchema,
+			},
+		)
+		Expect(err).NotTo(HaveOccurred(), "failed to parse schema")
+	})
+
+	DescribeTable("should mirror the REST request body of each mutation input",
+		func(inputName string, requestType reflect.Type) {
+			definition := schema.Types[inputName]
+			Expect(definition).NotTo(BeNil(), "input %s not found in schema", inputName)
+
+			var missing []string
+			collectMissingRequestFields(schema, definition, requestType, "", &missing)
+			Expect(missing).To(BeEmpty(),
+				"input fields of %s without a matching json field in %s", inputName, requestType.Name())
+		},
+		Entry("upsertOrder", "UpsertOrderInput", reflect.TypeOf(request.UpsertOrderRequest{})),
+		Entry("cancelOrders", "CancelOrdersInput", reflect.TypeOf(request.CancelOrdersRequest{})),
+		Entry("confirmDeliveries", "ConfirmDeliveriesInput", reflect.TypeOf(request.ConfirmDeliveriesRequest{})),
+		Entry("startRoute", "StartRouteInput", reflect.TypeOf(request.RouteStartedRequest{})),
+		Entry("upsertNode", "UpsertNodeInput", reflect.TypeOf(request.UpsertNodeRequest{})),
+	)
+
+	Describe("Input mapping", func() {
+		It("should map an order input into the REST request", func() {
+			lpn := "LPN456"
+			weight := int64(1500)
+			input := model.UpsertOrderInput{
+				ReferenceID: "ORD-1",
+				ExtraFields: map[string]any{"channel": "web"},
+				DeliveryUnits: []*model.OrderDeliveryUnitInput{
+					{Lpn: &lpn, Weight: &weight},
+				},
+			}
+
+			req, err := mapper.MapUpsertOrderInput(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(req.ReferenceID).To(Equal("ORD-1"))
+			Expect(req.ExtraFields).To(HaveKeyWithValue("channel", "web"))
+			Expect(req.DeliveryUnits).To(HaveLen(1))
+			Expect(req.DeliveryUnits[0].Lpn).To(Equal("LPN456"))
+			Expect(*req.DeliveryUnits[0].Weight).To(Equal(int64(1500)))
+			Expect(req.DeliveryUnits[0].Volume).To(BeNil())
+		})
+
+		It("should reject extra fields that are not strings", func() {
+			input := model.UpsertOrderInput{
+				ReferenceID: "ORD-1",
+				ExtraFields: map[string]any{"priority": 1},
+			}
+
+			_, err := mapper.MapUpsertOrderInput(input)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should keep the delivery failure empty when it is not sent", func() {
+			status := "DELIVERED"
+			input := model.ConfirmDeliveriesInput{
+				DeliveryUnits: []*model.ConfirmDeliveryUnitInput{
+					{
+						OrderReferenceID: "ORD-1",
+						Delivery:         &model.DeliveryConfirmationInput{Status: &status},
+					},
+				},
+			}
+
+			req, err := mapper.MapConfirmDeliveriesInput(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(req.DeliveryUnits).To(HaveLen(1))
+			Expect(req.DeliveryUnits[0].OrderReferenceID).To(Equal("ORD-1"))
+			Expect(req.DeliveryUnits[0].Delivery.Status).To(Equal("DELIVERED"))
+			Expect(req.DeliveryUnits[0].Delivery.Failure).To(BeNil())
+		})
+	})
+})
+
+// collectMissingRequestFields walks an input definition and reports every field
+// that has no json counterpart in the request struct
+func collectMissingRequestFields(schema *ast.Schema, definition *ast.Definition, requestType reflect.Type, prefix string, missing *[]string) {
+	requestType = unwrapRequestType(requestType)
+	for _, field := range definition.Fields {
+		path := prefix + field.Name
+		structField, ok := findJSONField(requestType, field.Name)
+		if !ok {
+			*missing = append(*missing, path)
+			continue
+		}
+		if nested := schema.Types[unwrapNodesType(field.Type)]; nested != nil && nested.Kind == ast.InputObject {
+			collectMissingRequestFields(schema, nested, structField.Type, path+".", missing)
+		}
+	}
+}
+
+func findJSONField(structType reflect.Type, name string) (reflect.StructField, bool) {
+	if structType.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if strings.Split(field.Tag.Get("json"), ",")[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func unwrapRequestType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
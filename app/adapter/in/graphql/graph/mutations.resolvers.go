@@ -0,0 +1,110 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+)
+
+// UpsertOrder is the resolver for the upsertOrder field.
+func (r *mutationResolver) UpsertOrder(ctx context.Context, input model.UpsertOrderInput) (*model.SubmissionResult, error) {
+	spanCtx, span := r.obs.Tracer.Start(ctx, "graphqlUpsertOrder")
+	defer span.End()
+
+	requestBody, err := mapper.MapUpsertOrderInput(input)
+	if err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+	if err := requestBody.Map(spanCtx).Validate(); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+
+	return r.submitEvent(spanCtx, span, sharedcontext.EventContext{
+		EntityType: "order",
+		EventType:  "orderSubmitted",
+	}, requestBody, "Order submitted successfully")
+}
+
+// CancelOrders is the resolver for the cancelOrders field.
+func (r *mutationResolver) CancelOrders(ctx context.Context, input model.CancelOrdersInput) (*model.SubmissionResult, error) {
+	spanCtx, span := r.obs.Tracer.Start(ctx, "graphqlCancelOrders")
+	defer span.End()
+
+	requestBody, err := mapper.MapCancelOrdersInput(input)
+	if err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+	if err := requestBody.Validate(); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+
+	return r.submitEvent(spanCtx, span, sharedcontext.EventContext{
+		EntityType: "order",
+		EventType:  "ordersCancellationSubmitted",
+	}, requestBody, "Orders cancellation submitted successfully")
+}
+
+// ConfirmDeliveries is the resolver for the confirmDeliveries field.
+func (r *mutationResolver) ConfirmDeliveries(ctx context.Context, input model.ConfirmDeliveriesInput) (*model.SubmissionResult, error) {
+	spanCtx, span := r.obs.Tracer.Start(ctx, "graphqlConfirmDeliveries")
+	defer span.End()
+
+	requestBody, err := mapper.MapConfirmDeliveriesInput(input)
+	if err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+	if err := requestBody.Validate(); err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+
+	return r.submitEvent(spanCtx, span, sharedcontext.EventContext{
+		EntityType: "delivery",
+		EventType:  "deliveriesSubmitted",
+	}, requestBody, "Deliveries submitted successfully")
+}
+
+// StartRoute is the resolver for the startRoute field.
+func (r *mutationResolver) StartRoute(ctx context.Context, input model.StartRouteInput) (*model.SubmissionResult, error) {
+	spanCtx, span := r.obs.Tracer.Start(ctx, "graphqlStartRoute")
+	defer span.End()
+
+	requestBody, err := mapper.MapStartRouteInput(input)
+	if err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+	if requestBody.Route.ReferenceID == "" {
+		return nil, mutationError(ctx, span, MutationErrorValidation, domain.ErrInvalidReferenceID)
+	}
+
+	return r.submitEvent(spanCtx, span, sharedcontext.EventContext{
+		EntityType: "route",
+		EventType:  "routeStarted",
+	}, requestBody, "Route started successfully")
+}
+
+// UpsertNode is the resolver for the upsertNode field.
+func (r *mutationResolver) UpsertNode(ctx context.Context, input model.UpsertNodeInput) (*model.SubmissionResult, error) {
+	spanCtx, span := r.obs.Tracer.Start(ctx, "graphqlUpsertNode")
+	defer span.End()
+
+	requestBody, err := mapper.MapUpsertNodeInput(input)
+	if err != nil {
+		return nil, mutationError(ctx, span, MutationErrorValidation, err)
+	}
+
+	return r.submitEvent(spanCtx, span, sharedcontext.EventContext{
+		EntityType: "node",
+		EventType:  "nodeSubmitted",
+	}, requestBody, "Node submitted successfully")
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+type mutationResolver struct{ *Resolver }
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+)
+
+// maxPageSize limita first/last en las conexiones Relay
+const maxPageSize = 100
+
+// relayPagination valida first/last y decodifica los cursores base64 al id numérico del registro.
+// Sin first ni last se pide una página de domain.DefaultPageSize, hacia atrás si viene before
+func relayPagination(first *int, after *string, last *int, before *string) (domain.Pagination, error) {
+	if first != nil && *first < 0 {
+		return domain.Pagination{}, fmt.Errorf("requested limit %d must not be negative", *first)
+	}
+	if last != nil && *last < 0 {
+		return domain.Pagination{}, fmt.Errorf("requested limit %d must not be negative", *last)
+	}
+	if first != nil && *first > maxPageSize {
+		return domain.Pagination{}, fmt.Errorf("requested limit %d exceeds maximum allowed limit of %d", *first, maxPageSize)
+	}
+	if last != nil && *last > maxPageSize {
+		return domain.Pagination{}, fmt.Errorf("requested limit %d exceeds maximum allowed limit of %d", *last, maxPageSize)
+	}
+
+	afterID, err := decodeCursor("after", after)
+	if err != nil {
+		return domain.Pagination{}, err
+	}
+	beforeID, err := decodeCursor("before", before)
+	if err != nil {
+		return domain.Pagination{}, err
+	}
+
+	if first == nil && last == nil {
+		pageSize := domain.DefaultPageSize
+		if beforeID != nil {
+			last = &pageSize
+		} else {
+			first = &pageSize
+		}
+	}
+
+	pagination := domain.Pagination{
+		First:  first,
+		After:  afterID,
+		Last:   last,
+		Before: beforeID,
+	}
+	if err := pagination.IsValid(); err != nil {
+		return domain.Pagination{}, err
+	}
+	return pagination, nil
+}
+
+func decodeCursor(name string, cursor *string) (*string, error) {
+	if cursor == nil || *cursor == "" {
+		return nil, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(*cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' cursor: %v", name, err)
+	}
+	id, err := strconv.ParseInt(string(decoded), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' cursor format: %v", name, err)
+	}
+	s := strconv.FormatInt(id, 10)
+	return &s, nil
+}
+
+func encodeCursor(id int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// relayPageInfo sigue el mismo criterio que deliveryUnitsReports para hasNextPage/hasPreviousPage
+func relayPageInfo(cursors []string, hasMore bool, last *int, before *string) *model.PageInfo {
+	var startCursor, endCursor string
+	if len(cursors) > 0 {
+		startCursor = cursors[0]
+		endCursor = cursors[len(cursors)-1]
+	}
+
+	hasPreviousPage := before != nil
+	if last != nil {
+		hasPreviousPage = hasMore
+	}
+
+	return &model.PageInfo{
+		HasNextPage:     hasMore,
+		HasPreviousPage: hasPreviousPage,
+		StartCursor:     &startCursor,
+		EndCursor:       endCursor,
+	}
+}
@@ -1,7 +1,11 @@
 package graph
 
 import (
+	"transport-app/app/adapter/in/natslistener"
+	"transport-app/app/adapter/out/natspublisher"
 	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/usecase"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 )
@@ -13,16 +17,64 @@ import (
 func init() {
 	ioc.Registry(
 		NewResolver,
-		tidbrepository.NewFindDeliveryUnitsProjectionResult)
+		tidbrepository.NewFindDeliveryUnitsProjectionResult,
+		tidbrepository.NewFindDeliveryUnitsStats,
+		tidbrepository.NewFindRoutesProjectionResult,
+		tidbrepository.NewFindPlansProjectionResult,
+		tidbrepository.NewFindVehiclesProjectionResult,
+		tidbrepository.NewFindDriversProjectionResult,
+		tidbrepository.NewFindCarriersProjectionResult,
+		natslistener.NewDeliveryUnitStatusChanges,
+		usecase.NewFindRouteProgress,
+		natspublisher.NewApplicationEvents,
+		usecase.NewSaveDeliveryUnitsExportJob,
+		usecase.NewFindDeliveryUnitsExportJob,
+		observability.NewObservability)
 }
 
 type Resolver struct {
 	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult
+	findDeliveryUnitsStats            tidbrepository.FindDeliveryUnitsStats
+	findRoutesProjectionResult        tidbrepository.FindRoutesProjectionResult
+	findPlansProjectionResult         tidbrepository.FindPlansProjectionResult
+	findVehiclesProjectionResult      tidbrepository.FindVehiclesProjectionResult
+	findDriversProjectionResult       tidbrepository.FindDriversProjectionResult
+	findCarriersProjectionResult      tidbrepository.FindCarriersProjectionResult
+	deliveryUnitStatusChanges         *natslistener.DeliveryUnitStatusChanges
+	findRouteProgress                 usecase.FindRouteProgress
+	publish                           natspublisher.ApplicationEvents
+	saveDeliveryUnitsExportJob        usecase.SaveDeliveryUnitsExportJob
+	findDeliveryUnitsExportJob        usecase.FindDeliveryUnitsExportJob
+	obs                               observability.Observability
 }
 
 func NewResolver(
-	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult) *Resolver {
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
+	findDeliveryUnitsStats tidbrepository.FindDeliveryUnitsStats,
+	findRoutesProjectionResult tidbrepository.FindRoutesProjectionResult,
+	findPlansProjectionResult tidbrepository.FindPlansProjectionResult,
+	findVehiclesProjectionResult tidbrepository.FindVehiclesProjectionResult,
+	findDriversProjectionResult tidbrepository.FindDriversProjectionResult,
+	findCarriersProjectionResult tidbrepository.FindCarriersProjectionResult,
+	deliveryUnitStatusChanges *natslistener.DeliveryUnitStatusChanges,
+	findRouteProgress usecase.FindRouteProgress,
+	publish natspublisher.ApplicationEvents,
+	saveDeliveryUnitsExportJob usecase.SaveDeliveryUnitsExportJob,
+	findDeliveryUnitsExportJob usecase.FindDeliveryUnitsExportJob,
+	obs observability.Observability) *Resolver {
 	return &Resolver{
 		findDeliveryUnitsProjectionResult: findDeliveryUnitsProjectionResult,
+		findDeliveryUnitsStats:            findDeliveryUnitsStats,
+		findRoutesProjectionResult:        findRoutesProjectionResult,
+		findPlansProjectionResult:         findPlansProjectionResult,
+		findVehiclesProjectionResult:      findVehiclesProjectionResult,
+		findDriversProjectionResult:       findDriversProjectionResult,
+		findCarriersProjectionResult:      findCarriersProjectionResult,
+		deliveryUnitStatusChanges:         deliveryUnitStatusChanges,
+		findRouteProgress:                 findRouteProgress,
+		publish:                           publish,
+		saveDeliveryUnitsExportJob:        saveDeliveryUnitsExportJob,
+		findDeliveryUnitsExportJob:        findDeliveryUnitsExportJob,
+		obs:                               obs,
 	}
 }
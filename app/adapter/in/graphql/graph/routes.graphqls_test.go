@@ -0,0 +1,153 @@
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/projection/carriers"
+	"transport-app/app/shared/projection/drivers"
+	"transport-app/app/shared/projection/plans"
+	"transport-app/app/shared/projection/routes"
+	"transport-app/app/shared/projection/vehicles"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed routes.graphqls
+var routesSchema string
+
+//go:embed plans.graphqls
+var plansSchema string
+
+//go:embed fleet.graphqls
+var fleetSchema string
+
+var _ = Describe("Routes, Plans and Fleet GraphQL Schema", func() {
+	var schema *ast.Schema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = gqlparser.LoadSchema(
+			&ast.Source{Name: "deliveryunits.graphqls", Input: deliveryunitsSchema},
+			&ast.Source{Name: "routes.graphqls", Input: routesSchema},
+			&ast.Source{Name: "plans.graphqls", Input: plansSchema},
+			&ast.Source{Name: "fleet.graphqls", Input: fleetSchema},
+		)
+		Expect(err).NotTo(HaveOccurred(), "failed to parse schema")
+	})
+
+	DescribeTable("should ensure all projections exist in the GraphQL schema",
+		func(nodeType string, projectionPaths []string) {
+			definition := schema.Types[nodeType]
+			Expect(definition).NotTo(BeNil(), "type %s not found in schema", nodeType)
+
+			fields := make(map[string]struct{})
+			collectNodesFields(fields, "", definition, schema)
+
+			for _, path := range projectionPaths {
+				_, exists := fields[strings.ToLower(path)]
+				Expect(exists).To(BeTrue(),
+					"Projection path '%s' does not exist in GraphQL type %s", path, nodeType)
+			}
+		},
+		Entry("routes", "RouteDetail", routes.GetAllProjectionPaths()),
+		Entry("plans", "Plan", plans.GetAllProjectionPaths()),
+		Entry("vehicles", "VehicleDetail", vehicles.GetAllProjectionPaths()),
+		Entry("drivers", "Driver", drivers.GetAllProjectionPaths()),
+		Entry("carriers", "Carrier", carriers.GetAllProjectionPaths()),
+	)
+
+	Describe("Route visits mapping", func() {
+		It("should sort visits by sequence and leave unassigned visits last", func() {
+			contract, err := json.Marshal(request.UpsertRouteRequest{
+				ReferenceID: "ROUTE-1",
+				Visits: []request.UpsertRouteVisit{
+					{SequenceNumber: 0, UnassignedReason: "capacity exceeded"},
+					{SequenceNumber: 2, Orders: []request.UpsertRouteOrder{{ReferenceID: "ORD-2"}}},
+					{SequenceNumber: 1, Orders: []request.UpsertRouteOrder{{ReferenceID: "ORD-1"}}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			mapped := mapper.MapRoutes(projectionresult.RoutesProjectionResults{
+				{ID: 7, Contract: contract},
+			})
+
+			Expect(mapped).To(HaveLen(1))
+			Expect(mapped[0].ID).To(Equal("7"))
+			visits := mapped[0].Visits
+			Expect(visits).To(HaveLen(3))
+			Expect(*visits[0].Orders[0].ReferenceID).To(Equal("ORD-1"))
+			Expect(*visits[1].Orders[0].ReferenceID).To(Equal("ORD-2"))
+			Expect(*visits[2].UnassignedReason).To(Equal("capacity exceeded"))
+		})
+
+		It("should expose the current status of each delivery unit", func() {
+			contract, err := json.Marshal(request.UpsertRouteRequest{
+				ReferenceID: "ROUTE-1",
+				Visits: []request.UpsertRouteVisit{
+					{SequenceNumber: 1, Orders: []request.UpsertRouteOrder{{
+						ReferenceID: "ORD-1",
+						DeliveryUnits: []request.UpsertRouteDeliveryUnit{
+							{Lpn: "LPN-1"},
+							{Lpn: "LPN-2"},
+						},
+					}}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			statuses := projectionresult.RouteDeliveryUnitStatuses{}
+			statuses.Set("ORD-1", "LPN-1", domain.StatusFinished)
+
+			mapped := mapper.MapRoutes(projectionresult.RoutesProjectionResults{
+				{ID: 7, Contract: contract, DeliveryUnitStatuses: statuses},
+			})
+
+			deliveryUnits := mapped[0].Visits[0].Orders[0].DeliveryUnits
+			Expect(deliveryUnits).To(HaveLen(2))
+			Expect(*deliveryUnits[0].Status).To(Equal(domain.StatusFinished))
+			Expect(deliveryUnits[1].Status).To(BeNil())
+		})
+
+		It("should not fail when the route has no stored contract", func() {
+			mapped := mapper.MapRoutes(projectionresult.RoutesProjectionResults{{ID: 1}})
+			Expect(mapped).To(HaveLen(1))
+			Expect(mapped[0].Visits).To(BeNil())
+		})
+	})
+
+	Describe("Relay pagination", func() {
+		It("should reject negative page sizes", func() {
+			negative := -1
+			_, err := relayPagination(&negative, nil, nil, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = relayPagination(nil, nil, &negative, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should default to the first page when neither first nor last is given", func() {
+			pagination, err := relayPagination(nil, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pagination.First).NotTo(BeNil())
+			Expect(*pagination.First).To(Equal(domain.DefaultPageSize))
+			Expect(pagination.Last).To(BeNil())
+		})
+
+		It("should page backwards by default when only before is given", func() {
+			before := encodeCursor(40)
+			pagination, err := relayPagination(nil, nil, nil, &before)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pagination.Last).NotTo(BeNil())
+			Expect(*pagination.Last).To(Equal(domain.DefaultPageSize))
+			Expect(*pagination.Before).To(Equal("40"))
+		})
+	})
+})
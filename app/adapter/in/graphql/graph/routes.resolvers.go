@@ -0,0 +1,44 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+)
+
+// Routes is the resolver for the routes field.
+func (r *queryResolver) Routes(ctx context.Context, filter *model.RouteFilterInput, first *int, after *string, last *int, before *string) (*model.RouteConnection, error) {
+	pagination, err := relayPagination(first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := mapper.MapRoutesFilter(filter)
+	filters.Pagination = pagination
+	filters.RequestedFields = ConvertSelectedPathsToMap(ctx)
+
+	results, hasMore, err := r.findRoutesProjectionResult(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := mapper.MapRoutes(results)
+	edges := make([]*model.RouteEdge, len(nodes))
+	cursors := make([]string, len(nodes))
+	for i, node := range nodes {
+		cursors[i] = encodeCursor(results[i].ID)
+		edges[i] = &model.RouteEdge{
+			Cursor: cursors[i],
+			Node:   node,
+		}
+	}
+
+	return &model.RouteConnection{
+		Edges:    edges,
+		PageInfo: relayPageInfo(cursors, hasMore, last, before),
+	}, nil
+}
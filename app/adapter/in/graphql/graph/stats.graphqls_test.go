@@ -0,0 +1,54 @@
+package graph
+
+import (
+	_ "embed"
+	"time"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed stats.graphqls
+var statsSchema string
+
+var _ = Describe("Delivery Units Stats GraphQL Schema", func() {
+	It("should parse together with the delivery units schema", func() {
+		_, err := gqlparser.LoadSchema(
+			&ast.Source{Name: "deliveryunits.graphqls", Input: deliveryunitsSchema},
+			&ast.Source{Name: "stats.graphqls", Input: statsSchema},
+		)
+		Expect(err).NotTo(HaveOccurred(), "failed to parse schema")
+	})
+
+	It("should map every groupBy value to a valid domain dimension", func() {
+		grouping, err := mapper.MapDeliveryUnitsStatsGrouping(model.AllDeliveryUnitsStatsGroupBy).Normalize()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grouping).To(HaveLen(len(model.AllDeliveryUnitsStatsGroupBy)))
+	})
+
+	It("should format the promised date and round the sums", func() {
+		promisedDate := time.Date(2025, 5, 26, 0, 0, 0, 0, time.UTC)
+		orderType := "EXPRESS"
+
+		groups := mapper.MapDeliveryUnitsStats([]projectionresult.DeliveryUnitsStatsResult{{
+			PromisedDate: &promisedDate,
+			OrderType:    &orderType,
+			Count:        2,
+			TotalWeight:  30,
+			TotalVolume:  2.9999999,
+			TotalPrice:   300,
+		}})
+
+		Expect(groups).To(HaveLen(1))
+		Expect(*groups[0].PromisedDate).To(Equal("2025-05-26"))
+		Expect(*groups[0].OrderType).To(Equal("EXPRESS"))
+		Expect(groups[0].Status).To(BeNil())
+		Expect(groups[0].Count).To(Equal(2))
+		Expect(groups[0].TotalVolume).To(Equal(int64(3)))
+	})
+})
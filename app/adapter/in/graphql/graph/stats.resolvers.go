@@ -0,0 +1,26 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+)
+
+// DeliveryUnitsStats is the resolver for the deliveryUnitsStats field.
+func (r *queryResolver) DeliveryUnitsStats(ctx context.Context, filter *model.DeliveryUnitsReportFilterInput, groupBy []model.DeliveryUnitsStatsGroupBy) ([]*model.DeliveryUnitsStatsGroup, error) {
+	var deliveryUnitsFilter domain.DeliveryUnitsFilter
+	if mappedFilter := mapper.MapDeliveryUnitsFilter(filter); mappedFilter != nil {
+		deliveryUnitsFilter = *mappedFilter
+	}
+
+	results, err := r.findDeliveryUnitsStats(ctx, deliveryUnitsFilter, mapper.MapDeliveryUnitsStatsGrouping(groupBy))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapDeliveryUnitsStats(results), nil
+}
@@ -0,0 +1,29 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+
+	"github.com/google/uuid"
+)
+
+var ErrSubscriptionUnauthenticated = errors.New("subscriptions require a valid access token with a tenant")
+
+// requireSubscriptionTenant exige el tenant que la conexión WebSocket obtuvo del access token
+func requireSubscriptionTenant(ctx context.Context) error {
+	if sharedcontext.TenantIDFromContext(ctx) == uuid.Nil || sharedcontext.TenantCountryFromContext(ctx) == "" {
+		return ErrSubscriptionUnauthenticated
+	}
+	return nil
+}
+
+func containsMatchingChange(changes []domain.DeliveryUnitStatusChange, filter domain.DeliveryUnitStatusChangeFilter) bool {
+	for _, change := range changes {
+		if filter.Matches(change) {
+			return true
+		}
+	}
+	return false
+}
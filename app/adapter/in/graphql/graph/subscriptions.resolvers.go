@@ -0,0 +1,80 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"transport-app/app/adapter/in/graphql/graph/mapper"
+	"transport-app/app/adapter/in/graphql/graph/model"
+	"transport-app/app/domain"
+)
+
+// DeliveryUnitStatusChanged is the resolver for the deliveryUnitStatusChanged field.
+func (r *subscriptionResolver) DeliveryUnitStatusChanged(ctx context.Context, filter *model.DeliveryUnitStatusChangedFilter) (<-chan *model.DeliveryUnitStatusChange, error) {
+	if err := requireSubscriptionTenant(ctx); err != nil {
+		return nil, err
+	}
+	statusFilter := mapper.MapDeliveryUnitStatusChangedFilter(filter)
+	batches := r.deliveryUnitStatusChanges.Subscribe(ctx)
+
+	out := make(chan *model.DeliveryUnitStatusChange)
+	go func() {
+		defer close(out)
+		for batch := range batches {
+			for _, change := range batch {
+				if !statusFilter.Matches(change) {
+					continue
+				}
+				select {
+				case out <- mapper.MapDeliveryUnitStatusChange(change):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// RouteProgress is the resolver for the routeProgress field.
+func (r *subscriptionResolver) RouteProgress(ctx context.Context, routeReferenceID string) (<-chan *model.RouteProgress, error) {
+	if err := requireSubscriptionTenant(ctx); err != nil {
+		return nil, err
+	}
+	routeFilter := domain.DeliveryUnitStatusChangeFilter{RouteReferenceIDs: []string{routeReferenceID}}
+	batches := r.deliveryUnitStatusChanges.Subscribe(ctx)
+
+	// El primer resumen se envía al suscribirse; luego se recalcula con cada lote de la ruta
+	progress, err := r.findRouteProgress(ctx, routeReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.RouteProgress, 1)
+	out <- mapper.MapRouteProgress(progress)
+	go func() {
+		defer close(out)
+		for batch := range batches {
+			if !containsMatchingChange(batch, routeFilter) {
+				continue
+			}
+			progress, err := r.findRouteProgress(ctx, routeReferenceID)
+			if err != nil {
+				continue
+			}
+			select {
+			case out <- mapper.MapRouteProgress(progress):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -9,6 +9,7 @@ import (
 	"transport-app/app/adapter/in/natsconsumer/mapper"
 	"transport-app/app/adapter/out/natspublisher"
 	"transport-app/app/domain"
+	canonicaljson "transport-app/app/shared/caonincaljson"
 	"transport-app/app/shared/chunker"
 	"transport-app/app/shared/configuration"
 	"transport-app/app/shared/infrastructure/natsconn"
@@ -32,6 +33,7 @@ func init() {
 		usecase.NewGetDataFromRedisWorkflow,
 		natspublisher.NewApplicationEvents,
 		usecase.NewKeyNormalizationWorkflow,
+		usecase.NewSaveOptimizationJob,
 	)
 }
 
@@ -42,6 +44,7 @@ func newAgentOptimizationRequested(
 	getDataFromRedisWorkflow usecase.GetDataFromRedisWorkflow,
 	publish natspublisher.ApplicationEvents,
 	keyNormalizationWorkflow usecase.KeyNormalizationWorkflow,
+	saveOptimizationJob usecase.SaveOptimizationJob,
 ) (jetstream.ConsumeContext, error) {
 	// Validación para verificar si el nombre de la suscripción está vacío
 	if conf.AGENT_OPTIMIZATION_REQUESTED_SUBSCRIPTION == "" {
@@ -174,6 +177,31 @@ func newAgentOptimizationRequested(
 		// Publicar el evento de optimización de flota
 		eventPayload, _ := json.Marshal(optimizeFleetRequest)
 
+		// Reenviar el job del agente al de la optimización, cuya llave se calcula sobre el
+		// payload tal como lo deserializa el consumidor de optimizationRequested
+		agentJobID, err := canonicaljson.HashKey(ctx, "agent_optimize_fleet", request)
+		var published optimizeFleetRequestPayload
+		if err == nil {
+			err = json.Unmarshal(eventPayload, &published)
+		}
+		var jobID string
+		if err == nil {
+			jobID, err = canonicaljson.HashKey(ctx, "optimize_fleet", published)
+		}
+		if err != nil {
+			obs.Logger.ErrorContext(ctx, "Error calculando job de optimización", "error", err)
+		} else {
+			now := time.Now()
+			agentJob := domain.NewOptimizationJob(agentJobID, now)
+			agentJob.ForwardedTo = jobID
+			if err := saveOptimizationJob(ctx, domain.NewOptimizationJob(jobID, now)); err != nil {
+				obs.Logger.ErrorContext(ctx, "Error guardando job de optimización", "error", err)
+			}
+			if err := saveOptimizationJob(ctx, agentJob); err != nil {
+				obs.Logger.ErrorContext(ctx, "Error guardando job de optimización", "error", err)
+			}
+		}
+
 		eventCtx := sharedcontext.AddEventContextToBaggage(ctx,
 			sharedcontext.EventContext{
 				EntityType: "optimization",
@@ -197,3 +225,7 @@ func newAgentOptimizationRequested(
 		msg.Ack()
 	})
 }
+
+// optimizeFleetRequestPayload es el payload publicado en optimizationRequested; el alias
+// evita la colisión con la variable request dentro del consumidor
+type optimizeFleetRequestPayload = request.OptimizeFleetRequest
@@ -0,0 +1,110 @@
+package natsconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"transport-app/app/domain"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/natsconn"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/usecase"
+
+	"cloud.google.com/go/pubsub"
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func init() {
+	ioc.Registry(
+		newDeliveryUnitsExportRequestedConsumer,
+		natsconn.NewJetStream,
+		usecase.NewExportDeliveryUnits,
+		usecase.NewFindDeliveryUnitsExportJob,
+		usecase.NewSaveDeliveryUnitsExportJob,
+		observability.NewObservability,
+		configuration.NewConf,
+	)
+}
+
+func newDeliveryUnitsExportRequestedConsumer(
+	js jetstream.JetStream,
+	exportDeliveryUnits usecase.ExportDeliveryUnits,
+	findExportJob usecase.FindDeliveryUnitsExportJob,
+	saveExportJob usecase.SaveDeliveryUnitsExportJob,
+	obs observability.Observability,
+	conf configuration.Conf,
+) (jetstream.ConsumeContext, error) {
+	// Validación para verificar si el nombre de la suscripción está vacío
+	if conf.DELIVERY_UNITS_EXPORT_REQUESTED_SUBSCRIPTION == "" {
+		obs.Logger.Warn("Delivery units export requested subscription name is empty, skipping consumer initialization")
+		// Retornar nil para indicar que no hay consumidor activo
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	consumer, err := js.CreateOrUpdateConsumer(ctx, conf.TRANSPORT_APP_TOPIC, jetstream.ConsumerConfig{
+		Name:          fmt.Sprintf("%s-%s", conf.ENVIRONMENT, conf.DELIVERY_UNITS_EXPORT_REQUESTED_SUBSCRIPTION),
+		Durable:       fmt.Sprintf("%s-%s", conf.ENVIRONMENT, conf.DELIVERY_UNITS_EXPORT_REQUESTED_SUBSCRIPTION),
+		FilterSubject: conf.TRANSPORT_APP_TOPIC + "." + conf.ENVIRONMENT + ".*.*.deliveryUnitsExportRequested",
+		MaxAckPending: 5,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return consumer.Consume(func(msg jetstream.Msg) {
+		// Deserializar el mensaje como pubsub.Message
+		var pubsubMsg pubsub.Message
+		if err := json.Unmarshal(msg.Data(), &pubsubMsg); err != nil {
+			obs.Logger.Error("Error deserializando mensaje NATS", "error", err)
+			msg.Ack()
+			return
+		}
+
+		// Extraer contexto de OpenTelemetry
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(pubsubMsg.Attributes))
+
+		var input domain.DeliveryUnitsExportRequest
+		if err := json.Unmarshal(pubsubMsg.Data, &input); err != nil {
+			obs.Logger.ErrorContext(ctx, "Error deserializando payload de exportación", "error", err)
+			msg.Ack()
+			return
+		}
+
+		job, err := findExportJob(ctx, input.JobID)
+		if err != nil {
+			if !errors.Is(err, usecase.ErrDeliveryUnitsExportJobNotFound) {
+				obs.Logger.ErrorContext(ctx, "Error obteniendo job de exportación", "error", err)
+			}
+			job = domain.NewDeliveryUnitsExportJob(input.JobID, input.Format, time.Now())
+		}
+		saveJob := func(job domain.DeliveryUnitsExportJob) {
+			if err := saveExportJob(ctx, job); err != nil {
+				obs.Logger.ErrorContext(ctx, "Error guardando job de exportación", "jobID", job.ID, "error", err)
+			}
+		}
+		job = job.Start(time.Now())
+		saveJob(job)
+
+		job, err = exportDeliveryUnits(ctx, job, input.Filter)
+		if err != nil {
+			obs.Logger.ErrorContext(ctx, "Error exportando unidades de entrega", "jobID", job.ID, "error", err)
+			saveJob(job.Fail(time.Now(), err))
+			msg.Ack()
+			return
+		}
+		saveJob(job)
+
+		obs.Logger.InfoContext(ctx, "Exportación de unidades procesada exitosamente desde NATS",
+			"eventType", "deliveryUnitsExportRequested",
+			"jobID", job.ID,
+			"rows", job.Rows)
+		msg.Ack()
+	})
+}
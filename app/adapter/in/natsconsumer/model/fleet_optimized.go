@@ -1,6 +1,7 @@
 package model
 
 type FleetOptimizedWebhookBody struct {
-	Plan   string   `json:"plan"`
-	Routes []string `json:"routes"`
+	Plan      string   `json:"plan"`
+	Routes    []string `json:"routes"`
+	TotalCost int64    `json:"totalCost,omitempty"`
 }
@@ -3,9 +3,12 @@ package natsconsumer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 	"transport-app/app/adapter/in/fuegoapi/request"
 	"transport-app/app/adapter/out/fuegoapiclient"
+	"transport-app/app/domain"
 	canonicaljson "transport-app/app/shared/caonincaljson"
 	"transport-app/app/shared/configuration"
 	"transport-app/app/shared/infrastructure/natsconn"
@@ -31,11 +34,12 @@ func init() {
 		natsconn.NewKeyValue,
 		usecase.NewGetDataFromRedisWorkflow,
 		workers.NewFleetOptimizer,
-		usecase.NewOptimizeFleetWorkflow,
 		observability.NewObservability,
 		configuration.NewConf,
 		usecase.NewStoreDataInRedisWorkflow,
 		fuegoapiclient.NewPostWebhook,
+		usecase.NewFindOptimizationJob,
+		usecase.NewSaveOptimizationJob,
 	)
 }
 
@@ -44,11 +48,12 @@ func newOptimizationRequestedConsumer(
 	kv jetstream.KeyValue,
 	getDataFromRedisWorkflow usecase.GetDataFromRedisWorkflow,
 	optimize workers.FleetOptimizer,
-	optimizeFleetWorkflow usecase.OptimizeFleetWorkflow,
 	obs observability.Observability,
 	conf configuration.Conf,
 	storeDataInBucketWorkflow usecase.StoreDataInRedisWorkflow,
 	postWebhook fuegoapiclient.PostWebhook,
+	findOptimizationJob usecase.FindOptimizationJob,
+	saveOptimizationJob usecase.SaveOptimizationJob,
 ) (jetstream.ConsumeContext, error) {
 	// Validación para verificar si el nombre de la suscripción está vacío
 	if conf.OPTIMIZATION_REQUESTED_SUBSCRIPTION == "" {
@@ -130,11 +135,28 @@ func newOptimizationRequestedConsumer(
 				msg.Ack()
 				return
 			}
+			// El job se identifica con la misma llave de idempotencia de la optimización
+			job, err := findOptimizationJob(ctx, key)
+			if err != nil {
+				if !errors.Is(err, usecase.ErrOptimizationJobNotFound) {
+					obs.Logger.ErrorContext(ctx, "Error obteniendo job de optimización", "error", err)
+				}
+				job = domain.NewOptimizationJob(key, time.Now())
+			}
+			saveJob := func(job domain.OptimizationJob) {
+				if err := saveOptimizationJob(ctx, job); err != nil {
+					obs.Logger.ErrorContext(ctx, "Error guardando job de optimización", "jobID", job.ID, "error", err)
+				}
+			}
+			job = job.Start(time.Now())
+			saveJob(job)
+
 			optimizeFleetWorkflowCtx := sharedcontext.WithIdempotencyKey(ctx, key)
 			optimizeFleetWorkflowCtx = sharedcontext.WithAccessToken(optimizeFleetWorkflowCtx, msg.Headers().Get("X-Access-Token"))
-			routeRequests, err := optimizeFleetWorkflow(optimizeFleetWorkflowCtx, input.Map())
+			routeRequests, err := optimize(optimizeFleetWorkflowCtx, input.Map())
 			if err != nil {
 				obs.Logger.ErrorContext(ctx, "Error procesando optimización", "error", err)
+				saveJob(job.Fail(time.Now(), err))
 				msg.Ack()
 				return
 			}
@@ -143,6 +165,7 @@ func newOptimizationRequestedConsumer(
 				routeRequestBytes, err := json.Marshal(routeRequest)
 				if err != nil {
 					obs.Logger.ErrorContext(ctx, "Error serializando ruta de optimización", "error", err)
+					saveJob(job.Fail(time.Now(), err))
 					msg.Ack()
 					return
 				}
@@ -152,23 +175,34 @@ func newOptimizationRequestedConsumer(
 				err = storeDataInBucketWorkflow(storeDataInBucketWorkflowCtx, routeRequest.ReferenceID, routeRequestBytes)
 				if err != nil {
 					obs.Logger.ErrorContext(ctx, "Error almacenando ruta de optimización en bucket", "error", err)
+					saveJob(job.Fail(time.Now(), err))
 					msg.Ack()
 					return
 				}
 			}
 
+			var routeReferences []string
+			var planReferenceID string
+			for _, routeRequest := range routeRequests {
+				routeReferences = append(routeReferences, routeRequest.ReferenceID)
+				planReferenceID = routeRequest.PlanReferenceID
+			}
+			saveJob(job.Succeed(time.Now(), planReferenceID, routeReferences))
+
 			webhookKey, err := canonicaljson.HashKey(ctx, "publish_webhook", input)
 			publishWebhookWorkflowCtx := sharedcontext.WithAccessToken(ctx, msg.Headers().Get("X-Access-Token"))
 			publishWebhookWorkflowCtx = sharedcontext.WithBucketToken(publishWebhookWorkflowCtx, msg.Headers().Get("X-Bucket-Token"))
 			publishWebhookWorkflowCtx = sharedcontext.WithIdempotencyKey(publishWebhookWorkflowCtx, webhookKey)
 			type fleetOptimizedWebhook struct {
-				Plan   string   `json:"plan"`
-				Routes []string `json:"routes"`
+				Plan      string   `json:"plan"`
+				Routes    []string `json:"routes"`
+				TotalCost int64    `json:"totalCost,omitempty"`
 			}
 			var webhookBody fleetOptimizedWebhook
 
 			for _, routeRequest := range routeRequests {
 				webhookBody.Routes = append(webhookBody.Routes, routeRequest.ReferenceID)
+				webhookBody.TotalCost += routeRequest.Cost
 			}
 			if len(routeRequests) > 0 {
 				webhookBody.Plan = routeRequests[0].PlanReferenceID
@@ -41,7 +41,9 @@ func init() {
 		usecase.NewUpsertDeliveryUnitsSkillsWorkflow,
 		usecase.NewUpsertSizeCategoryWorkflow,
 		usecase.NewUpsertDeliveryUnitsHistoryWorkflow,
+		usecase.NewRegisterNewDeliveryUnits,
 		usecase.NewUpsertSkillWorkflow,
+		usecase.NewAssignDeliveryPinWorkflow,
 	)
 }
 
@@ -62,7 +64,9 @@ func newNatsConsumer(
 	upsertDeliveryUnitsSkillsWorkflow usecase.UpsertDeliveryUnitsSkillsWorkflow,
 	upsertSizeCategoryWorkflow usecase.UpsertSizeCategoryWorkflow,
 	upsertDeliveryUnitsHistoryWorkflow usecase.UpsertDeliveryUnitsHistoryWorkflow,
+	registerNewDeliveryUnits usecase.RegisterNewDeliveryUnits,
 	upsertSkillWorkflow usecase.UpsertSkillWorkflow,
+	assignDeliveryPinWorkflow usecase.AssignDeliveryPinWorkflow,
 ) (jetstream.ConsumeContext, error) {
 	// Validación para verificar si el nombre de la suscripción está vacío
 	if conf.ORDER_SUBMITTED_SUBSCRIPTION == "" {
@@ -248,6 +252,22 @@ func newNatsConsumer(
 			return
 		}
 
+		// PIN de entrega, solo para tipos de orden que lo exigen
+		deliveryPinKey, err := canonicaljson.HashKey(ctx, "delivery_pin", order.ReferenceID)
+		if err != nil {
+			obs.Logger.ErrorContext(ctx, "Error generando key para PIN de entrega", "error", err)
+			msg.Nak()
+			return
+		}
+		deliveryPinCtx := sharedcontext.WithIdempotencyKey(
+			sharedcontext.WithAccessToken(ctx, msg.Headers().Get("X-Access-Token")),
+			deliveryPinKey)
+		if err := assignDeliveryPinWorkflow(deliveryPinCtx, order); err != nil {
+			obs.Logger.ErrorContext(ctx, "Error asignando PIN de entrega", "error", err)
+			msg.Nak()
+			return
+		}
+
 		// Delivery Units Labels
 		deliveryUnitsLabelsKey, err := canonicaljson.HashKey(ctx, "delivery_units_labels", order)
 		if err != nil {
@@ -306,8 +326,15 @@ func newNatsConsumer(
 			}
 		}
 
-		// Delivery Units History - iterar por cada delivery unit
-		for _, du := range order.DeliveryUnits {
+		// Delivery Units History - solo las unidades nuevas quedan disponibles; reenviar la
+		// orden no altera el estado de las que ya están en operación
+		newOrder, err := registerNewDeliveryUnits(ctx, order)
+		if err != nil {
+			obs.Logger.ErrorContext(ctx, "Error obteniendo estado de unidades de entrega", "error", err)
+			msg.Nak()
+			return
+		}
+		for _, du := range newOrder.DeliveryUnits {
 			// Generar hash key específico por delivery unit usando LPN + referenceId
 			deliveryUnitHistoryKey, err := canonicaljson.HashKey(ctx, "delivery_units_history", map[string]string{
 				"lpn":         du.Lpn,
@@ -0,0 +1,95 @@
+package natsconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/fuegoapiclient"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/natsconn"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+	"transport-app/app/usecase"
+
+	"cloud.google.com/go/pubsub"
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func init() {
+	ioc.Registry(
+		newSellerPickupConfirmedConsumer,
+		natsconn.NewJetStream,
+		usecase.NewConfirmPickup,
+		fuegoapiclient.NewPostWebhook,
+		observability.NewObservability,
+		configuration.NewConf,
+	)
+}
+
+func newSellerPickupConfirmedConsumer(
+	js jetstream.JetStream,
+	confirmPickup usecase.ConfirmPickup,
+	postWebhook fuegoapiclient.PostWebhook,
+	obs observability.Observability,
+	conf configuration.Conf,
+) (jetstream.ConsumeContext, error) {
+	// Validación para verificar si el nombre de la suscripción está vacío
+	if conf.SELLER_PICKUP_CONFIRMED_SUBSCRIPTION == "" {
+		obs.Logger.Warn("Seller pickup confirmed subscription name is empty, skipping consumer initialization")
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	consumer, err := js.CreateOrUpdateConsumer(ctx, conf.TRANSPORT_APP_TOPIC, jetstream.ConsumerConfig{
+		Name:          fmt.Sprintf("%s-%s", conf.ENVIRONMENT, conf.SELLER_PICKUP_CONFIRMED_SUBSCRIPTION),
+		Durable:       fmt.Sprintf("%s-%s", conf.ENVIRONMENT, conf.SELLER_PICKUP_CONFIRMED_SUBSCRIPTION),
+		FilterSubject: conf.TRANSPORT_APP_TOPIC + "." + conf.ENVIRONMENT + ".*.*.sellerPickupConfirmed",
+		MaxAckPending: 5,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return consumer.Consume(func(msg jetstream.Msg) {
+		// Deserializar el mensaje como pubsub.Message
+		var pubsubMsg pubsub.Message
+		if err := json.Unmarshal(msg.Data(), &pubsubMsg); err != nil {
+			obs.Logger.Error("Error deserializando mensaje NATS", "error", err)
+			msg.Ack()
+			return
+		}
+
+		// Extraer contexto de OpenTelemetry
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(pubsubMsg.Attributes))
+
+		var input request.PickingVisitConfirmedRequest
+		if err := json.Unmarshal(pubsubMsg.Data, &input); err != nil {
+			obs.Logger.Error("Error deserializando payload de retiro en seller", "error", err)
+			msg.Ack()
+			return
+		}
+
+		// Registrar el retiro de las unidades
+		picked, err := confirmPickup(ctx, input.Map(ctx))
+		if err != nil {
+			obs.Logger.ErrorContext(ctx, "Error procesando retiro en seller", "error", err)
+			msg.Ack()
+			return
+		}
+
+		// Avisar a la consolidación que las unidades van en camino al hub
+		webhookCtx := sharedcontext.WithAccessToken(ctx, msg.Headers().Get("X-Access-Token"))
+		if err := postWebhook(webhookCtx, request.MapSellerPickupConfirmedWebhookBody(picked), "seller-pickup-confirmed"); err != nil {
+			obs.Logger.ErrorContext(ctx, "Error publicando webhook", "error", err)
+		}
+
+		obs.Logger.InfoContext(ctx, "Retiro en seller procesado exitosamente desde NATS",
+			"eventType", "sellerPickupConfirmed")
+		msg.Ack()
+	})
+}
@@ -0,0 +1,104 @@
+package natslistener
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"transport-app/app/adapter/out/natsbroadcaster"
+	"transport-app/app/domain"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/natsconn"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/nats-io/nats.go"
+)
+
+// subscriberBuffer es la cantidad de lotes que un suscriptor lento puede acumular antes de que
+// se descarten sus notificaciones
+const subscriberBuffer = 32
+
+// DeliveryUnitStatusChanges reparte entre los suscriptores en vivo de esta instancia los
+// cambios de estado difundidos por NATS. Cada suscriptor solo recibe los de su tenant
+type DeliveryUnitStatusChanges struct {
+	obs         observability.Observability
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []domain.DeliveryUnitStatusChange]struct{}
+}
+
+func init() {
+	ioc.Registry(
+		NewDeliveryUnitStatusChanges,
+		natsconn.NewConn,
+		configuration.NewConf,
+		observability.NewObservability)
+}
+
+func NewDeliveryUnitStatusChanges(
+	nc *nats.Conn,
+	conf configuration.Conf,
+	obs observability.Observability) (*DeliveryUnitStatusChanges, error) {
+	listener := &DeliveryUnitStatusChanges{
+		obs:         obs,
+		subscribers: make(map[string]map[chan []domain.DeliveryUnitStatusChange]struct{}),
+	}
+	// Sin grupo de cola: todas las instancias reciben todos los cambios
+	if _, err := nc.Subscribe(natsbroadcaster.DeliveryUnitStatusChangedSubject(conf, "*", "*"), listener.dispatch); err != nil {
+		return nil, err
+	}
+	return listener, nil
+}
+
+// Subscribe entrega los lotes de cambios del tenant del contexto hasta que el contexto termina
+func (l *DeliveryUnitStatusChanges) Subscribe(ctx context.Context) <-chan []domain.DeliveryUnitStatusChange {
+	tenant := tenantKey(sharedcontext.TenantIDFromContext(ctx).String(), sharedcontext.TenantCountryFromContext(ctx))
+	ch := make(chan []domain.DeliveryUnitStatusChange, subscriberBuffer)
+
+	l.mu.Lock()
+	if l.subscribers[tenant] == nil {
+		l.subscribers[tenant] = make(map[chan []domain.DeliveryUnitStatusChange]struct{})
+	}
+	l.subscribers[tenant][ch] = struct{}{}
+	l.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		l.mu.Lock()
+		delete(l.subscribers[tenant], ch)
+		if len(l.subscribers[tenant]) == 0 {
+			delete(l.subscribers, tenant)
+		}
+		l.mu.Unlock()
+		close(ch)
+	}()
+	return ch
+}
+
+func (l *DeliveryUnitStatusChanges) dispatch(msg *nats.Msg) {
+	var message natsbroadcaster.DeliveryUnitStatusChangesMessage
+	if err := json.Unmarshal(msg.Data, &message); err != nil {
+		l.obs.Logger.Warn("Error deserializando cambios de estado", "error", err)
+		return
+	}
+	changes := make([]domain.DeliveryUnitStatusChange, 0, len(message.Changes))
+	for _, change := range message.Changes {
+		changes = append(changes, change.Map())
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for ch := range l.subscribers[tenantKey(message.TenantID, message.TenantCountry)] {
+		select {
+		case ch <- changes:
+		default:
+			l.obs.Logger.Warn("Suscriptor lento, se descartan cambios de estado",
+				"tenantID", message.TenantID,
+				"changes", len(changes))
+		}
+	}
+}
+
+func tenantKey(tenantID, country string) string {
+	return tenantID + "-" + country
+}
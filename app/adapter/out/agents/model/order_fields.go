@@ -0,0 +1,116 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"transport-app/app/adapter/in/fuegoapi/request"
+
+	"google.golang.org/genai"
+)
+
+// OrderFieldMappingSchema propone qué cabecera de un archivo de órdenes alimenta cada
+// clave canónica de request.OrderImportKeys
+type OrderFieldMappingSchema struct {
+	schema      *genai.Schema
+	synonyms    map[string][]string
+	orderedKeys []string
+}
+
+func NewOrderFieldMappingSchema() *OrderFieldMappingSchema {
+	props := make(map[string]*genai.Schema, len(request.OrderImportKeys))
+	for _, key := range request.OrderImportKeys {
+		props[key] = &genai.Schema{Type: genai.TypeString}
+	}
+
+	schema := &genai.Schema{
+		Type:       genai.TypeObject,
+		Properties: props,
+		Required:   append([]string(nil), request.OrderImportKeys...),
+	}
+
+	synonyms := map[string][]string{
+		request.OrderImportKeyReferenceID:          {"id", "order_id", "reference_id", "reference", "ref", "folio", "numero", "nro", "num", "id_pedido", "id_orden", "pedido", "orden"},
+		request.OrderImportKeyOrderType:            {"order_type", "tipo", "tipo_orden", "tipo pedido", "type"},
+		request.OrderImportKeyOriginNodeReference:  {"origin", "origen", "bodega", "warehouse", "tienda", "store", "cd", "centro distribucion"},
+		request.OrderImportKeyDeliveryInstructions: {"instrucciones", "instructions", "observaciones", "notes", "notas", "comentario", "comments"},
+		request.OrderImportKeyAddressLine1:         {"direccion", "dirección", "address", "street", "calle", "domicilio", "address_line", "address1", "address_1"},
+		request.OrderImportKeyAddressLine2:         {"address2", "address_2", "depto", "departamento", "dpto", "oficina", "block", "complemento"},
+		request.OrderImportKeyZipCode:              {"zip", "zipcode", "zip_code", "postal", "codigo postal", "código postal", "cp"},
+		request.OrderImportKeyContactFullName:      {"nombre cliente", "nombre_cliente", "cliente", "nombre", "name", "full_name", "fullname", "contact_name", "customer_name", "destinatario"},
+		request.OrderImportKeyContactPhone:         {"telf", "teléfono", "telefono", "fono", "celular", "móvil", "movil", "whatsapp", "phone", "phone_number", "contact_phone"},
+		request.OrderImportKeyContactEmail:         {"email", "correo", "mail", "e-mail", "customer_email"},
+		request.OrderImportKeyContactNationalID:    {"rut", "dni", "national_id", "documento", "cedula", "cédula", "run"},
+		request.OrderImportKeyLatitude:             {"lat", "latitude", "latitud"},
+		request.OrderImportKeyLongitude:            {"lon", "lng", "long", "longitude", "longitud"},
+		request.OrderImportKeyAdminAreaLevel1:      {"region", "región", "state", "estado", "provincia"},
+		request.OrderImportKeyAdminAreaLevel2:      {"comuna", "city", "ciudad", "municipio", "district"},
+		request.OrderImportKeyAdminAreaLevel3:      {"barrio", "localidad", "sector", "neighborhood"},
+		request.OrderImportKeyPromisedStartDate:    {"fecha", "fecha_entrega", "fecha compromiso", "delivery_date", "promised_date", "start_date", "fecha_inicio"},
+		request.OrderImportKeyPromisedEndDate:      {"fecha_fin", "end_date", "fecha_limite", "fecha límite", "deadline"},
+		request.OrderImportKeyPromisedStartTime:    {"hora_inicio", "start_time", "desde", "from", "ventana_inicio"},
+		request.OrderImportKeyPromisedEndTime:      {"hora_fin", "end_time", "hasta", "to", "ventana_fin"},
+		request.OrderImportKeyServiceCategory:      {"servicio", "service", "service_category", "tipo_servicio", "categoria servicio"},
+		request.OrderImportKeyLpn:                  {"lpn", "bulto", "package", "package_id", "etiqueta", "label", "tracking", "codigo bulto"},
+		request.OrderImportKeyVolume:               {"volume (cm3)", "volume_cm3", "volumen_cm3", "volumen", "volume", "cm3"},
+		request.OrderImportKeyWeight:               {"weight (grams)", "weight_g", "weight_grams", "peso_gramos", "gramos", "weight", "peso"},
+		request.OrderImportKeyPrice:                {"price", "precio", "amount", "monto", "valor", "cost", "costo"},
+		request.OrderImportKeyItemSku:              {"sku", "codigo", "código", "product_code", "codigo producto", "item_code"},
+		request.OrderImportKeyItemDescription:      {"producto", "product", "descripcion", "descripción", "description", "item", "detalle"},
+		request.OrderImportKeyItemQuantity:         {"cantidad", "quantity", "qty", "unidades", "units"},
+	}
+
+	return &OrderFieldMappingSchema{
+		schema:      schema,
+		synonyms:    synonyms,
+		orderedKeys: request.OrderImportKeys,
+	}
+}
+
+func (o *OrderFieldMappingSchema) Schema() *genai.Schema { return o.schema }
+
+// Prompt recibe las cabeceras del archivo con un valor de ejemplo cada una
+func (o *OrderFieldMappingSchema) Prompt(input interface{}) string {
+	in, err := json.Marshal(input)
+	if err != nil {
+		in = []byte("{}")
+	}
+
+	return fmt.Sprintf(`
+Eres un asistente de normalización. La entrada es un objeto JSON cuyas claves son las cabeceras de un archivo de pedidos y cuyos valores son un ejemplo de la primera fila.
+Devuelve EXCLUSIVAMENTE UN SOLO OBJETO JSON cuyas claves sean EXACTAMENTE estas claves canónicas:
+
+%s
+
+Entrada:
+%s
+
+Reglas:
+- La salida DEBE ser SOLO UN OBJETO JSON válido (NO un array).
+- Para cada clave canónica, devuelve el NOMBRE EXACTO DE LA CABECERA de entrada que corresponde a ese campo.
+- Coincidencia case-insensitive; trata espacios, guiones y guiones_bajos como equivalentes.
+- Usa los valores de ejemplo para desambiguar (por ejemplo, un email no es un teléfono).
+- Una misma cabecera no puede asignarse a más de una clave canónica.
+- Si no encuentras una cabecera para un campo, deja el valor vacío "".
+
+Diccionario de sinónimos:
+%s`,
+		strings.Join(o.orderedKeys, "\n"),
+		string(in),
+		o.renderSynonymsSection(),
+	)
+}
+
+func (o *OrderFieldMappingSchema) renderSynonymsSection() string {
+	var b strings.Builder
+	for _, k := range o.orderedKeys {
+		syns := append([]string(nil), o.synonyms[k]...)
+		sort.Strings(syns)
+		if len(syns) > 0 {
+			b.WriteString(fmt.Sprintf("- %s ← %s\n", k, strings.Join(syns, ", ")))
+		}
+	}
+	return b.String()
+}
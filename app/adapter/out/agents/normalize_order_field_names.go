@@ -0,0 +1,58 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"transport-app/app/adapter/out/agents/model"
+	"transport-app/app/shared/infrastructure/ai"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"google.golang.org/genai"
+)
+
+// OrderFieldNamesNormalizer devuelve el mapeo clave canónica -> cabecera del archivo de órdenes
+type OrderFieldNamesNormalizer func(ctx context.Context, input interface{}) (map[string]string, error)
+
+func init() {
+	ioc.Registry(NewOrderFieldNamesNormalizer, ai.NewClient)
+}
+
+func NewOrderFieldNamesNormalizer(client *genai.Client) OrderFieldNamesNormalizer {
+	ms := model.NewOrderFieldMappingSchema()
+
+	const modelName = "gemini-2.0-flash"
+
+	return func(ctx context.Context, input interface{}) (map[string]string, error) {
+		prompt := ms.Prompt(input)
+
+		resp, err := client.Models.GenerateContent(
+			ctx,
+			modelName,
+			genai.Text(prompt),
+			&genai.GenerateContentConfig{
+				ResponseMIMEType: "application/json",
+				ResponseSchema:   ms.Schema(),
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("genai error: %w", err)
+		}
+
+		var keyMapping map[string]string
+		if err := json.Unmarshal([]byte(resp.Text()), &keyMapping); err != nil {
+			return nil, fmt.Errorf("no pude parsear la respuesta LLM: %w", err)
+		}
+
+		// Descartar claves canónicas sin cabecera asignada
+		result := make(map[string]string)
+		for officialKey, header := range keyMapping {
+			if officialKey != "" && header != "" {
+				result[officialKey] = header
+			}
+		}
+
+		return result, nil
+	}
+}
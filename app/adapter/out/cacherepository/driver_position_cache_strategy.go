@@ -0,0 +1,158 @@
+package cacherepository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/cache"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/paulmach/orb"
+	"github.com/redis/go-redis/v9"
+	"github.com/valkey-io/valkey-go"
+)
+
+// DriverPositionCacheStrategy mantiene la última posición conocida de cada ruta del tenant en un
+// hash por tenant, indexado por la referencia de la ruta
+type DriverPositionCacheStrategy interface {
+	// Save guarda las posiciones recibidas, ignorando las que no son más recientes que la almacenada
+	Save(context.Context, []domain.DriverLocation) error
+	// List retorna las posiciones reportadas desde since; las anteriores quedan en el cache
+	// hasta que el hash expira o la ruta vuelve a reportar
+	List(ctx context.Context, since time.Time) ([]domain.DriverLocation, error)
+}
+
+// driverPositionsTTL expira el hash de un tenant que dejó de reportar posiciones
+const driverPositionsTTL = 24 * time.Hour
+
+// saveDriverPositionsScript compara y escribe cada posición dentro del servidor, de modo que dos
+// consumidores procesando pings de la misma ruta no puedan pisar una posición más reciente.
+// KEYS[1] es el hash del tenant; ARGV[1] el TTL en segundos, seguido de tríos con la referencia
+// de la ruta, el instante del ping en microsegundos y la posición serializada
+const saveDriverPositionsScript = `
+local updated = 0
+for i = 2, #ARGV, 3 do
+	local stored = -1
+	local current = redis.call('HGET', KEYS[1], ARGV[i])
+	if current then
+		local ok, decoded = pcall(cjson.decode, current)
+		if ok and type(decoded) == 'table' and tonumber(decoded.recordedAtUnixMicro) then
+			stored = tonumber(decoded.recordedAtUnixMicro)
+		end
+	end
+	if tonumber(ARGV[i + 1]) > stored then
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 2])
+		updated = updated + 1
+	end
+end
+if updated > 0 then
+	redis.call('EXPIRE', KEYS[1], ARGV[1])
+end
+return updated
+`
+
+// saveDriverPositionsArgs arma los argumentos de saveDriverPositionsScript con el ping más
+// reciente de cada ruta
+func saveDriverPositionsArgs(locations []domain.DriverLocation) ([]string, error) {
+	latest := domain.LatestDriverLocations(locations)
+	if len(latest) == 0 {
+		return nil, nil
+	}
+	args := make([]string, 0, 1+3*len(latest))
+	args = append(args, strconv.FormatInt(int64(driverPositionsTTL.Seconds()), 10))
+	for _, location := range latest {
+		position := newCachedDriverPosition(location)
+		data, err := json.Marshal(position)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args,
+			position.RouteReferenceID,
+			strconv.FormatInt(position.RecordedAtUnixMicro, 10),
+			string(data))
+	}
+	return args, nil
+}
+
+// listDriverPositions decodifica el hash del tenant y retorna, ordenadas por ruta, las posiciones
+// reportadas desde since
+func listDriverPositions(stored map[string]string, since time.Time) []domain.DriverLocation {
+	var positions []domain.DriverLocation
+	for _, val := range stored {
+		var position cachedDriverPosition
+		if err := json.Unmarshal([]byte(val), &position); err != nil || position.RecordedAt.Before(since) {
+			continue
+		}
+		positions = append(positions, position.Map())
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].RouteReferenceID < positions[j].RouteReferenceID
+	})
+	return positions
+}
+
+func init() {
+	ioc.Registry(NewDriverPositionCacheStrategy, cache.NewCacheClientFactory)
+}
+func NewDriverPositionCacheStrategy(factory any) DriverPositionCacheStrategy {
+	if client, ok := factory.(*redis.Client); ok {
+		return newRedisDriverPositionCacheStrategy(client)
+	}
+	if client, ok := factory.(valkey.Client); ok {
+		return newValkeyDriverPositionCacheStrategy(client)
+	}
+	panic("unsupported cache client type")
+}
+
+func driverPositionsKey(ctx context.Context) string {
+	return "driver_positions:" + sharedcontext.TenantIDFromContext(ctx).String() + "-" + sharedcontext.TenantCountryFromContext(ctx)
+}
+
+type cachedDriverPosition struct {
+	RouteReferenceID string    `json:"routeReferenceID"`
+	VehiclePlate     string    `json:"vehiclePlate"`
+	DriverEmail      string    `json:"driverEmail"`
+	DriverNationalID string    `json:"driverNationalID"`
+	Latitude         float64   `json:"latitude"`
+	Longitude        float64   `json:"longitude"`
+	RecordedAt       time.Time `json:"recordedAt"`
+	// RecordedAtUnixMicro permite comparar el instante dentro del script sin parsear fechas;
+	// en microsegundos el valor cabe sin pérdida en los números de Lua
+	RecordedAtUnixMicro int64   `json:"recordedAtUnixMicro"`
+	SpeedKmh            float64 `json:"speedKmh"`
+	AccuracyMeters      float64 `json:"accuracyMeters"`
+}
+
+func newCachedDriverPosition(location domain.DriverLocation) cachedDriverPosition {
+	return cachedDriverPosition{
+		RouteReferenceID:    location.RouteReferenceID,
+		VehiclePlate:        location.VehiclePlate,
+		DriverEmail:         location.Driver.Email,
+		DriverNationalID:    location.Driver.NationalID,
+		Latitude:            location.Point.Lat(),
+		Longitude:           location.Point.Lon(),
+		RecordedAt:          location.RecordedAt,
+		RecordedAtUnixMicro: location.RecordedAt.UnixMicro(),
+		SpeedKmh:            location.SpeedKmh,
+		AccuracyMeters:      location.AccuracyMeters,
+	}
+}
+
+func (p cachedDriverPosition) Map() domain.DriverLocation {
+	return domain.DriverLocation{
+		RouteReferenceID: p.RouteReferenceID,
+		VehiclePlate:     p.VehiclePlate,
+		Driver: domain.Driver{
+			Email:      p.DriverEmail,
+			NationalID: p.DriverNationalID,
+		},
+		Point:          orb.Point{p.Longitude, p.Latitude},
+		RecordedAt:     p.RecordedAt,
+		SpeedKmh:       p.SpeedKmh,
+		AccuracyMeters: p.AccuracyMeters,
+	}
+}
@@ -0,0 +1,39 @@
+package cacherepository
+
+import (
+	"context"
+	"time"
+	"transport-app/app/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var redisSaveDriverPositionsScript = redis.NewScript(saveDriverPositionsScript)
+
+type redisDriverPositionCacheStrategy struct {
+	c *redis.Client
+}
+
+func newRedisDriverPositionCacheStrategy(c *redis.Client) DriverPositionCacheStrategy {
+	return redisDriverPositionCacheStrategy{c}
+}
+
+func (r redisDriverPositionCacheStrategy) Save(ctx context.Context, locations []domain.DriverLocation) error {
+	args, err := saveDriverPositionsArgs(locations)
+	if err != nil || len(args) == 0 {
+		return err
+	}
+	scriptArgs := make([]any, len(args))
+	for i, arg := range args {
+		scriptArgs[i] = arg
+	}
+	return redisSaveDriverPositionsScript.Run(ctx, r.c, []string{driverPositionsKey(ctx)}, scriptArgs...).Err()
+}
+
+func (r redisDriverPositionCacheStrategy) List(ctx context.Context, since time.Time) ([]domain.DriverLocation, error) {
+	stored, err := r.c.HGetAll(ctx, driverPositionsKey(ctx)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return listDriverPositions(stored, since), nil
+}
@@ -0,0 +1,35 @@
+package cacherepository
+
+import (
+	"context"
+	"time"
+	"transport-app/app/domain"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+var valkeySaveDriverPositionsScript = valkey.NewLuaScript(saveDriverPositionsScript)
+
+type valkeyDriverPositionCacheStrategy struct {
+	c valkey.Client
+}
+
+func newValkeyDriverPositionCacheStrategy(c valkey.Client) DriverPositionCacheStrategy {
+	return valkeyDriverPositionCacheStrategy{c}
+}
+
+func (v valkeyDriverPositionCacheStrategy) Save(ctx context.Context, locations []domain.DriverLocation) error {
+	args, err := saveDriverPositionsArgs(locations)
+	if err != nil || len(args) == 0 {
+		return err
+	}
+	return valkeySaveDriverPositionsScript.Exec(ctx, v.c, []string{driverPositionsKey(ctx)}, args).Error()
+}
+
+func (v valkeyDriverPositionCacheStrategy) List(ctx context.Context, since time.Time) ([]domain.DriverLocation, error) {
+	stored, err := v.c.Do(ctx, v.c.B().Hgetall().Key(driverPositionsKey(ctx)).Build()).AsStrMap()
+	if err != nil {
+		return nil, err
+	}
+	return listDriverPositions(stored, since), nil
+}
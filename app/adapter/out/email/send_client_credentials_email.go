@@ -12,7 +12,7 @@ import (
 	"github.com/resend/resend-go/v2"
 )
 
-//go:embed client_credentials_email.html
+//go:embed client_credentials_email.html delivery_pin_email.html
 var emailTemplates embed.FS
 
 type SendClientCredentialsEmail func(ctx context.Context, email string, credentials domain.ClientCredentials) error
@@ -0,0 +1,57 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"transport-app/app/domain"
+	resendcli "transport-app/app/shared/infrastructure/resendcli"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/resend/resend-go/v2"
+)
+
+type SendDeliveryPinEmail func(ctx context.Context, order domain.Order, pin string) error
+
+func init() {
+	ioc.Registry(
+		NewSendDeliveryPinEmail,
+		resendcli.NewClient,
+	)
+}
+
+type DeliveryPinEmailData struct {
+	RecipientName    string
+	OrderReferenceID string
+	Pin              string
+}
+
+func NewSendDeliveryPinEmail(resendClient resendcli.ResendClient) SendDeliveryPinEmail {
+	return func(ctx context.Context, order domain.Order, pin string) error {
+		tmpl, err := template.ParseFS(emailTemplates, "delivery_pin_email.html")
+		if err != nil {
+			return err
+		}
+
+		contact := order.Destination.AddressInfo.Contact
+		var body bytes.Buffer
+		err = tmpl.Execute(&body, DeliveryPinEmailData{
+			RecipientName:    contact.FullName,
+			OrderReferenceID: order.ReferenceID.String(),
+			Pin:              pin,
+		})
+		if err != nil {
+			return err
+		}
+
+		params := &resend.SendEmailRequest{
+			From:    "Transport App <onboarding@transport-app.com>",
+			To:      []string{contact.PrimaryEmail},
+			Html:    body.String(),
+			Subject: "🚚 Transport App - PIN de entrega de tu pedido " + order.ReferenceID.String(),
+		}
+
+		_, err = resendClient.Emails.Send(params)
+		return err
+	}
+}
@@ -238,6 +238,7 @@ func mapPoliticalAreaToRequest(pa domain.PoliticalArea) request.UpsertRoutePolit
 		AdminAreaLevel2: pa.AdminAreaLevel2,
 		AdminAreaLevel3: pa.AdminAreaLevel3,
 		AdminAreaLevel4: pa.AdminAreaLevel4,
+		TimeZone:        pa.TimeZone,
 	}
 }
 
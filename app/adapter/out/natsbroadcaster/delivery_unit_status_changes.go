@@ -0,0 +1,98 @@
+package natsbroadcaster
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"transport-app/app/domain"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/natsconn"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/nats-io/nats.go"
+)
+
+// PublishDeliveryUnitStatusChanges difunde los cambios de estado a las instancias que atienden
+// suscripciones en vivo. Se publica sobre NATS core, fuera del stream de eventos, porque la
+// notificación no se persiste ni se reintenta: una falla se registra y no interrumpe el flujo
+type PublishDeliveryUnitStatusChanges func(ctx context.Context, changes []domain.DeliveryUnitStatusChange)
+
+// DeliveryUnitStatusChangesMessage es el mensaje difundido por cada evento procesado
+type DeliveryUnitStatusChangesMessage struct {
+	TenantID      string                          `json:"tenantID"`
+	TenantCountry string                          `json:"tenantCountry"`
+	Changes       []DeliveryUnitStatusChangeEntry `json:"changes"`
+}
+
+type DeliveryUnitStatusChangeEntry struct {
+	OrderReferenceID string    `json:"orderReferenceID"`
+	Lpn              string    `json:"lpn"`
+	RouteReferenceID string    `json:"routeReferenceID"`
+	VehiclePlate     string    `json:"vehiclePlate"`
+	Status           string    `json:"status"`
+	OccurredAt       time.Time `json:"occurredAt"`
+}
+
+func (e DeliveryUnitStatusChangeEntry) Map() domain.DeliveryUnitStatusChange {
+	return domain.DeliveryUnitStatusChange{
+		OrderReferenceID: e.OrderReferenceID,
+		Lpn:              e.Lpn,
+		RouteReferenceID: e.RouteReferenceID,
+		VehiclePlate:     e.VehiclePlate,
+		Status:           e.Status,
+		OccurredAt:       e.OccurredAt,
+	}
+}
+
+// DeliveryUnitStatusChangedSubject arma el subject de difusión. Usa el prefijo live para
+// quedar fuera de los subjects capturados por el stream de la aplicación
+func DeliveryUnitStatusChangedSubject(conf configuration.Conf, tenantID, country string) string {
+	return "live." + conf.TRANSPORT_APP_TOPIC + "." + conf.ENVIRONMENT + "." + tenantID + "." + country + ".deliveryUnitStatusChanged"
+}
+
+func init() {
+	ioc.Registry(
+		NewPublishDeliveryUnitStatusChanges,
+		natsconn.NewConn,
+		configuration.NewConf,
+		observability.NewObservability)
+}
+
+func NewPublishDeliveryUnitStatusChanges(
+	nc *nats.Conn,
+	conf configuration.Conf,
+	obs observability.Observability) PublishDeliveryUnitStatusChanges {
+	return func(ctx context.Context, changes []domain.DeliveryUnitStatusChange) {
+		if len(changes) == 0 {
+			return
+		}
+
+		msg := DeliveryUnitStatusChangesMessage{
+			TenantID:      sharedcontext.TenantIDFromContext(ctx).String(),
+			TenantCountry: sharedcontext.TenantCountryFromContext(ctx),
+			Changes:       make([]DeliveryUnitStatusChangeEntry, 0, len(changes)),
+		}
+		for _, change := range changes {
+			msg.Changes = append(msg.Changes, DeliveryUnitStatusChangeEntry{
+				OrderReferenceID: change.OrderReferenceID,
+				Lpn:              change.Lpn,
+				RouteReferenceID: change.RouteReferenceID,
+				VehiclePlate:     change.VehiclePlate,
+				Status:           change.Status,
+				OccurredAt:       change.OccurredAt,
+			})
+		}
+
+		data, err := json.Marshal(msg)
+		if err != nil {
+			obs.Logger.WarnContext(ctx, "Error serializando cambios de estado", "error", err)
+			return
+		}
+		subject := DeliveryUnitStatusChangedSubject(conf, msg.TenantID, msg.TenantCountry)
+		if err := nc.Publish(subject, data); err != nil {
+			obs.Logger.WarnContext(ctx, "Error difundiendo cambios de estado", "subject", subject, "error", err)
+		}
+	}
+}
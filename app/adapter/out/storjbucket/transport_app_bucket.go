@@ -1,8 +1,10 @@
 package storjbucket
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"time"
 	"transport-app/app/shared/configuration"
 	"transport-app/app/shared/infrastructure/storj"
@@ -12,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 	"github.com/google/uuid"
 )
@@ -19,6 +22,7 @@ import (
 type TransportAppBucket struct {
 	bucketName string
 	s3Client   *s3.S3 // Cliente S3 para pre-signed URLs rápidas
+	uploader   *s3manager.Uploader
 }
 
 func init() {
@@ -57,6 +61,7 @@ func NewTransportAppBucket(ul *storj.Uplink, config configuration.StorjConfigura
 	return &TransportAppBucket{
 		bucketName: bucketName,
 		s3Client:   s3Client,
+		uploader:   s3manager.NewUploaderWithClient(s3Client),
 	}, nil
 }
 
@@ -150,3 +155,51 @@ func (b TransportAppBucket) GeneratePublicDownloadURL(ctx context.Context, objec
 	return urlStr, nil
 }
 
+func (b TransportAppBucket) Upload(ctx context.Context, objectKey string, contentType string, data []byte) error {
+	// Crear prefijo específico por tenant
+	tenantID := sharedcontext.TenantIDFromContext(ctx)
+	tenantCountry := sharedcontext.TenantCountryFromContext(ctx)
+
+	var prefixedKey string
+	if tenantID != uuid.Nil && tenantCountry != "" {
+		prefixedKey = fmt.Sprintf("%s-%s/%s", tenantID.String(), tenantCountry, objectKey)
+	} else {
+		prefixedKey = fmt.Sprintf("default/%s", objectKey)
+	}
+
+	_, err := b.s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucketName),
+		Key:         aws.String(prefixedKey),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("could not upload object %s: %w", objectKey, err)
+	}
+	return nil
+}
+
+func (b TransportAppBucket) UploadStream(ctx context.Context, objectKey string, contentType string, body io.Reader) error {
+	// Crear prefijo específico por tenant
+	tenantID := sharedcontext.TenantIDFromContext(ctx)
+	tenantCountry := sharedcontext.TenantCountryFromContext(ctx)
+
+	var prefixedKey string
+	if tenantID != uuid.Nil && tenantCountry != "" {
+		prefixedKey = fmt.Sprintf("%s-%s/%s", tenantID.String(), tenantCountry, objectKey)
+	} else {
+		prefixedKey = fmt.Sprintf("default/%s", objectKey)
+	}
+
+	// El uploader lee el body por partes y usa multipart upload cuando supera una parte
+	_, err := b.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(b.bucketName),
+		Key:         aws.String(prefixedKey),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("could not upload object %s: %w", objectKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// DeletePriorityRule elimina la regla identificada por su categoría y días a la fecha prometida.
+// El borrado es definitivo para que la misma regla pueda volver a crearse
+type DeletePriorityRule func(context.Context, domain.PriorityRule) error
+
+func init() {
+	ioc.Registry(NewDeletePriorityRule, database.NewConnectionFactory)
+}
+
+func NewDeletePriorityRule(conn database.ConnectionFactory) DeletePriorityRule {
+	return func(ctx context.Context, rule domain.PriorityRule) error {
+		result := conn.DB.WithContext(ctx).
+			Unscoped().
+			Where("document_id = ?", rule.DocID(ctx).String()).
+			Delete(&table.PriorityRule{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPriorityRuleNotFound
+		}
+		return nil
+	}
+}
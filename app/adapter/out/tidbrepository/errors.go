@@ -8,4 +8,6 @@ var (
 	ErrClientCredentialsNotFound = errors.New("client credentials not found")
 	ErrClientCredentialsDatabase = errors.New("client credentials database error")
 	ErrWebhookNotFound           = errors.New("webhook not found")
+	ErrOrderNotFound             = errors.New("order not found")
+	ErrPriorityRuleNotFound      = errors.New("priority rule not found")
 )
@@ -0,0 +1,78 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/carriers"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+type FindCarriersProjectionResult func(
+	ctx context.Context,
+	filters domain.CarrierSearchFilters) (projectionresult.CarriersProjectionResults, bool, error)
+
+func init() {
+	ioc.Registry(
+		NewFindCarriersProjectionResult,
+		database.NewConnectionFactory,
+		carriers.NewProjection,
+	)
+}
+
+func NewFindCarriersProjectionResult(
+	conn database.ConnectionFactory,
+	projection carriers.Projection) FindCarriersProjectionResult {
+	const c = "c" // carriers
+
+	return func(ctx context.Context, filters domain.CarrierSearchFilters) (projectionresult.CarriersProjectionResults, bool, error) {
+		var results projectionresult.CarriersProjectionResults
+
+		ds := goqu.From(goqu.T("carriers").As(c)).
+			Select(goqu.I(c + ".id").As("id")).
+			Where(goqu.Ex{
+				c + ".tenant_id": sharedcontext.TenantIDFromContext(ctx),
+			})
+
+		if len(filters.NationalIDs) > 0 {
+			ds = ds.Where(goqu.I(c + ".national_id").In(filters.NationalIDs))
+		}
+
+		if filters.Name != nil {
+			ds = ds.Where(goqu.I(c + ".name").Like("%" + *filters.Name + "%"))
+		}
+
+		if projection.Name().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(c + ".name").As("name"))
+		}
+
+		if projection.NationalID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(c + ".national_id").As("national_id"))
+		}
+
+		ds, err := applyRelayPagination(ds, c+".id", filters.Pagination)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&results).Error; err != nil {
+			return nil, false, err
+		}
+
+		results, hasMoreResults := trimRelayPage(results, filters.Pagination)
+		if filters.Pagination.IsBackward() {
+			results = results.Reversed()
+		}
+
+		return results, hasMoreResults, nil
+	}
+}
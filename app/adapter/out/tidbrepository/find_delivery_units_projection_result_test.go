@@ -1887,6 +1887,152 @@ var _ = Describe("FindDeliveryUnitsProjectionResult", func() {
 		))
 	})
 
+	It("should filter delivery units by destination and origin geo filters", func() {
+		// Create a new tenant for this test
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Puntos en formato [lng, lat]: dos en Santiago y uno en Valparaíso
+		points := []orb.Point{
+			{-70.6506, -33.4372},
+			{-70.6109, -33.4263},
+			{-71.6197, -33.0472},
+		}
+
+		orders := make([]domain.Order, len(points))
+		for i, point := range points {
+			address := domain.AddressInfo{
+				PoliticalArea: domain.PoliticalArea{
+					AdminAreaLevel1: "Region Metropolitana",
+					AdminAreaLevel2: "Santiago",
+					AdminAreaLevel3: fmt.Sprintf("Comuna %d", i),
+					TimeZone:        "America/Santiago",
+				},
+				AddressLine1: fmt.Sprintf("Geo Address %d", i),
+				Coordinates: domain.Coordinates{
+					Point:  point,
+					Source: "geocoding",
+				},
+			}
+			err = NewUpsertAddressInfo(conn, nil)(ctx, address)
+			Expect(err).ToNot(HaveOccurred())
+
+			orders[i] = domain.Order{
+				ReferenceID: domain.ReferenceID(fmt.Sprintf("GEO-%d", i)),
+				Destination: domain.NodeInfo{
+					AddressInfo: address,
+				},
+				DeliveryUnits: []domain.DeliveryUnit{
+					{},
+				},
+			}
+		}
+		// Sólo la orden de Valparaíso tiene origen, ubicado en Santiago
+		orders[2].Origin = domain.NodeInfo{
+			AddressInfo: orders[0].Destination.AddressInfo,
+		}
+
+		for _, order := range orders {
+			err = NewUpsertOrder(conn, nil)(ctx, order)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		err = NewUpsertDeliveryUnitsHistory(conn, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{
+				{
+					Orders: orders,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		projection := deliveryunits.NewProjection()
+		findDeliveryUnits := NewFindDeliveryUnitsProjectionResult(
+			conn,
+			projection)
+
+		referenceIDs := func(results []projectionresult.DeliveryUnitsProjectionResult) []string {
+			ids := make([]string, 0, len(results))
+			for _, r := range results {
+				ids = append(ids, r.OrderReferenceID)
+			}
+			return ids
+		}
+		requestedFields := map[string]any{
+			projection.ReferenceID().String(): "",
+		}
+
+		// Polígono que cubre Santiago centro y Providencia
+		results, _, err := findDeliveryUnits(ctx, domain.DeliveryUnitsFilter{
+			RequestedFields: requestedFields,
+			Destination: &domain.LocationFilter{
+				GeoFilter: domain.GeoFilter{
+					WithinPolygon: orb.Polygon{{
+						{-70.70, -33.50}, {-70.55, -33.50}, {-70.55, -33.38}, {-70.70, -33.38}, {-70.70, -33.50},
+					}},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenceIDs(results)).To(ConsistOf("GEO-0", "GEO-1"))
+
+		// Radio de 2 km alrededor de la Plaza de Armas excluye Providencia (~3,8 km)
+		results, _, err = findDeliveryUnits(ctx, domain.DeliveryUnitsFilter{
+			RequestedFields: requestedFields,
+			Destination: &domain.LocationFilter{
+				GeoFilter: domain.GeoFilter{
+					WithinRadius: &domain.RadiusFilter{
+						Center: orb.Point{-70.6506, -33.4372},
+						Meters: 2000,
+					},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenceIDs(results)).To(ConsistOf("GEO-0"))
+
+		// Caja envolvente sobre Valparaíso
+		results, _, err = findDeliveryUnits(ctx, domain.DeliveryUnitsFilter{
+			RequestedFields: requestedFields,
+			Destination: &domain.LocationFilter{
+				GeoFilter: domain.GeoFilter{
+					BoundingBox: &orb.Bound{
+						Min: orb.Point{-71.70, -33.10},
+						Max: orb.Point{-71.50, -33.00},
+					},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenceIDs(results)).To(ConsistOf("GEO-2"))
+
+		// Filtro geográfico sobre el origen
+		results, _, err = findDeliveryUnits(ctx, domain.DeliveryUnitsFilter{
+			RequestedFields: requestedFields,
+			Origin: &domain.LocationFilter{
+				GeoFilter: domain.GeoFilter{
+					WithinRadius: &domain.RadiusFilter{
+						Center: orb.Point{-70.6506, -33.4372},
+						Meters: 500,
+					},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenceIDs(results)).To(ConsistOf("GEO-2"))
+
+		// Un polígono inválido se rechaza antes de consultar
+		_, _, err = findDeliveryUnits(ctx, domain.DeliveryUnitsFilter{
+			RequestedFields: requestedFields,
+			Destination: &domain.LocationFilter{
+				GeoFilter: domain.GeoFilter{
+					WithinPolygon: orb.Polygon{{{-70.70, -33.50}, {-70.55, -33.50}}},
+				},
+			},
+		})
+		Expect(err).To(MatchError(domain.ErrInvalidGeoFilter))
+	})
+
 	It("should filter delivery units by origin node references", func() {
 		// Create a new tenant for this test
 		_, ctx, err := CreateTestTenant(context.Background(), conn)
@@ -2054,6 +2200,39 @@ var _ = Describe("FindDeliveryUnitsProjectionResult", func() {
 			"El ID del último estado debería ser mayor")
 	})
 
+	It("should filter delivery units by route", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		onRoute := domain.Order{ReferenceID: "ORD-ON-ROUTE", DeliveryUnits: []domain.DeliveryUnit{{Lpn: "LPN-ON-ROUTE"}}}
+		otherRoute := domain.Order{ReferenceID: "ORD-OTHER-ROUTE", DeliveryUnits: []domain.DeliveryUnit{{Lpn: "LPN-OTHER-ROUTE"}}}
+		for _, order := range []domain.Order{onRoute, otherRoute} {
+			Expect(NewUpsertOrder(conn, nil)(ctx, order)).To(Succeed())
+		}
+
+		err = NewUpsertDeliveryUnitsHistory(conn, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{
+				{ReferenceID: "ROUTE-A", Orders: []domain.Order{onRoute}},
+				{ReferenceID: "ROUTE-B", Orders: []domain.Order{otherRoute}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		projection := deliveryunits.NewProjection()
+		findDeliveryUnits := NewFindDeliveryUnitsProjectionResult(conn, projection)
+
+		results, _, err := findDeliveryUnits(ctx, domain.DeliveryUnitsFilter{
+			OnlyLatestStatus: true,
+			Route:            &domain.RouteFilter{ReferenceIds: []string{"ROUTE-A"}},
+			RequestedFields: map[string]any{
+				projection.ReferenceID().String(): true,
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].OrderReferenceID).To(Equal("ORD-ON-ROUTE"))
+	})
+
 	It("should filter delivery units by promised date range", func() {
 		// Create a new tenant for this test
 		_, ctx, err := CreateTestTenant(context.Background(), conn)
@@ -0,0 +1,158 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+)
+
+type FindDeliveryUnitsStats func(
+	ctx context.Context,
+	filters domain.DeliveryUnitsFilter,
+	groupBy domain.DeliveryUnitsStatsGrouping) ([]projectionresult.DeliveryUnitsStatsResult, error)
+
+func init() {
+	ioc.Registry(
+		NewFindDeliveryUnitsStats,
+		database.NewConnectionFactory,
+		deliveryunits.NewProjection,
+	)
+}
+
+// NewFindDeliveryUnitsStats agrega conteos y sumas de peso, volumen y precio con GROUP BY. Los
+// filtros se resuelven con la misma consulta de la proyección de unidades de entrega, siempre
+// sobre el último estado de cada unidad para no contar dos veces su historial.
+func NewFindDeliveryUnitsStats(
+	conn database.ConnectionFactory,
+	projection deliveryunits.Projection) FindDeliveryUnitsStats {
+	const (
+		duh  = "duh"  // delivery_units_status_histories
+		o    = "o"    // orders
+		du   = "du"   // delivery_units
+		s    = "s"    // statuses
+		dadi = "dadi" // destination_address_infos
+		dpa  = "dpa"  // destination_political_areas
+		ot   = "ot"   // order_types
+	)
+
+	return func(
+		ctx context.Context,
+		filters domain.DeliveryUnitsFilter,
+		groupBy domain.DeliveryUnitsStatsGrouping) ([]projectionresult.DeliveryUnitsStatsResult, error) {
+		grouping, err := groupBy.Normalize()
+		if err != nil {
+			return nil, err
+		}
+
+		// La subconsulta sólo aplica filtros: sin campos solicitados, orden ni paginación
+		filters.RequestedFields = nil
+		filters.Pagination = domain.Pagination{}
+		filters.OnlyLatestStatus = true
+		filtered, err := deliveryUnitsProjectionDataset(ctx, projection, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		ds := goqu.From(filtered.ClearOrder().As("filtered")).
+			InnerJoin(
+				goqu.T("delivery_units_status_histories").As(duh),
+				goqu.On(goqu.I(duh+".id").Eq(goqu.I("filtered.id"))),
+			).
+			InnerJoin(
+				goqu.T("orders").As(o),
+				goqu.On(goqu.I(o+".document_id").Eq(goqu.I(duh+".order_doc"))),
+			).
+			LeftJoin(
+				goqu.T("delivery_units").As(du),
+				goqu.On(goqu.I(du+".document_id").Eq(goqu.I(duh+".delivery_unit_doc"))),
+			).
+			Select(
+				goqu.COUNT(goqu.Star()).As("count"),
+				goqu.COALESCE(goqu.SUM(goqu.I(du+".weight")), 0).As("total_weight"),
+				goqu.COALESCE(goqu.SUM(goqu.I(du+".volume")), 0).As("total_volume"),
+				goqu.COALESCE(goqu.SUM(goqu.I(du+".price")), 0).As("total_price"),
+			)
+
+		// Los joins de agrupación son LEFT para que las unidades sin el dato queden en un grupo nulo
+		joinedPoliticalArea := false
+		var columns []exp.IdentifierExpression
+		for _, dimension := range grouping {
+			var column exp.IdentifierExpression
+			var alias string
+			switch dimension {
+			case domain.DeliveryUnitsStatsGroupByStatus:
+				ds = ds.LeftJoin(
+					goqu.T("statuses").As(s),
+					goqu.On(goqu.I(s+".document_id").Eq(goqu.I(duh+".delivery_unit_status_doc"))),
+				)
+				column, alias = goqu.I(s+".status"), "status"
+			case domain.DeliveryUnitsStatsGroupByAdminAreaLevel1,
+				domain.DeliveryUnitsStatsGroupByAdminAreaLevel2,
+				domain.DeliveryUnitsStatsGroupByAdminAreaLevel3,
+				domain.DeliveryUnitsStatsGroupByAdminAreaLevel4:
+				if !joinedPoliticalArea {
+					ds = ds.LeftJoin(
+						goqu.T("address_infos").As(dadi),
+						goqu.On(goqu.I(dadi+".document_id").Eq(goqu.I(o+".destination_address_info_doc"))),
+					).LeftJoin(
+						goqu.T("political_areas").As(dpa),
+						goqu.On(goqu.I(dpa+".document_id").Eq(goqu.I(dadi+".political_area_doc"))),
+					)
+					joinedPoliticalArea = true
+				}
+				alias = adminAreaLevelColumn(dimension)
+				column = goqu.I(dpa + "." + alias)
+			case domain.DeliveryUnitsStatsGroupByPromisedDate:
+				column, alias = goqu.I(o+".promised_date_range_start"), "promised_date"
+			case domain.DeliveryUnitsStatsGroupByOrderType:
+				ds = ds.LeftJoin(
+					goqu.T("order_types").As(ot),
+					goqu.On(goqu.I(ot+".document_id").Eq(goqu.I(o+".order_type_doc"))),
+				)
+				column, alias = goqu.I(ot+".type"), "order_type"
+			}
+			ds = ds.SelectAppend(column.As(alias))
+			columns = append(columns, column)
+		}
+
+		if len(columns) > 0 {
+			groupColumns := make([]any, 0, len(columns))
+			orderColumns := make([]exp.OrderedExpression, 0, len(columns))
+			for _, column := range columns {
+				groupColumns = append(groupColumns, column)
+				orderColumns = append(orderColumns, column.Asc())
+			}
+			ds = ds.GroupBy(groupColumns...).Order(orderColumns...)
+		}
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, err
+		}
+
+		var results []projectionresult.DeliveryUnitsStatsResult
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&results).Error; err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+}
+
+// adminAreaLevelColumn retorna la columna de political_areas que corresponde al nivel solicitado
+func adminAreaLevelColumn(dimension domain.DeliveryUnitsStatsGroupBy) string {
+	switch dimension {
+	case domain.DeliveryUnitsStatsGroupByAdminAreaLevel2:
+		return "admin_area_level2"
+	case domain.DeliveryUnitsStatsGroupByAdminAreaLevel3:
+		return "admin_area_level3"
+	case domain.DeliveryUnitsStatsGroupByAdminAreaLevel4:
+		return "admin_area_level4"
+	}
+	return "admin_area_level1"
+}
@@ -0,0 +1,171 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/paulmach/orb"
+)
+
+var _ = Describe("FindDeliveryUnitsStats", func() {
+	var (
+		conn database.ConnectionFactory
+	)
+
+	BeforeEach(func() {
+		conn = connection
+	})
+
+	It("should aggregate counts and sums grouped by the requested dimensions", func() {
+		err := NewLoadStatuses(conn)()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		int64Ptr := func(v int64) *int64 { return &v }
+
+		destination := func(adminAreaLevel1, addressLine1 string, point orb.Point) domain.AddressInfo {
+			address := domain.AddressInfo{
+				PoliticalArea: domain.PoliticalArea{
+					AdminAreaLevel1: adminAreaLevel1,
+					AdminAreaLevel2: adminAreaLevel1,
+					AdminAreaLevel3: adminAreaLevel1,
+					TimeZone:        "America/Santiago",
+				},
+				AddressLine1: addressLine1,
+				Coordinates: domain.Coordinates{
+					Point:  point,
+					Source: "geocoding",
+				},
+			}
+			err := NewUpsertAddressInfo(conn, nil)(ctx, address)
+			Expect(err).ToNot(HaveOccurred())
+			err = NewUpsertPoliticalArea(conn, nil)(ctx, address.PoliticalArea)
+			Expect(err).ToNot(HaveOccurred())
+			return address
+		}
+
+		express := domain.OrderType{Type: "EXPRESS", Description: "Entrega express"}
+		standard := domain.OrderType{Type: "STANDARD", Description: "Entrega estándar"}
+		for _, orderType := range []domain.OrderType{express, standard} {
+			err = NewUpsertOrderType(conn, nil)(ctx, orderType)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		orders := []domain.Order{
+			{
+				ReferenceID: "STATS-1",
+				OrderType:   express,
+				Destination: domain.NodeInfo{
+					AddressInfo: destination("Metropolitana", "Stats Address 1", orb.Point{-70.65, -33.44}),
+				},
+				DeliveryUnits: []domain.DeliveryUnit{
+					{
+						Lpn:    "STATS-LPN-1",
+						Weight: int64Ptr(10),
+						Volume: int64Ptr(1),
+						Price:  int64Ptr(100),
+						Status: domain.Status{Status: domain.StatusPlanned},
+					},
+					{
+						Lpn:    "STATS-LPN-2",
+						Weight: int64Ptr(20),
+						Volume: int64Ptr(2),
+						Price:  int64Ptr(200),
+						Status: domain.Status{Status: domain.StatusPlanned},
+					},
+				},
+			},
+			{
+				ReferenceID: "STATS-2",
+				OrderType:   standard,
+				Destination: domain.NodeInfo{
+					AddressInfo: destination("Valparaiso", "Stats Address 2", orb.Point{-71.62, -33.05}),
+				},
+				DeliveryUnits: []domain.DeliveryUnit{
+					{
+						Lpn:    "STATS-LPN-3",
+						Weight: int64Ptr(5),
+						Volume: int64Ptr(3),
+						Price:  int64Ptr(50),
+						Status: domain.Status{Status: domain.StatusPlanned},
+					},
+				},
+			},
+		}
+		for _, order := range orders {
+			err = NewUpsertOrder(conn, nil)(ctx, order)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		err = NewUpsertDeliveryUnitsHistory(conn, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{
+				{
+					Orders: orders,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		findDeliveryUnitsStats := NewFindDeliveryUnitsStats(conn, deliveryunits.NewProjection())
+
+		// Sin agrupación se obtiene una sola fila con los totales
+		results, err := findDeliveryUnitsStats(ctx, domain.DeliveryUnitsFilter{}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Count).To(Equal(int64(3)))
+		Expect(results[0].TotalWeight).To(Equal(35.0))
+		Expect(results[0].TotalVolume).To(Equal(6.0))
+		Expect(results[0].TotalPrice).To(Equal(350.0))
+
+		// Agrupación por tipo de orden
+		results, err = findDeliveryUnitsStats(ctx, domain.DeliveryUnitsFilter{}, domain.DeliveryUnitsStatsGrouping{
+			domain.DeliveryUnitsStatsGroupByOrderType,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(*results[0].OrderType).To(Equal("EXPRESS"))
+		Expect(results[0].Count).To(Equal(int64(2)))
+		Expect(results[0].TotalWeight).To(Equal(30.0))
+		Expect(results[0].Status).To(BeNil())
+		Expect(*results[1].OrderType).To(Equal("STANDARD"))
+		Expect(results[1].Count).To(Equal(int64(1)))
+		Expect(results[1].TotalWeight).To(Equal(5.0))
+
+		// Combinación de estado y área administrativa del destino
+		results, err = findDeliveryUnitsStats(ctx, domain.DeliveryUnitsFilter{}, domain.DeliveryUnitsStatsGrouping{
+			domain.DeliveryUnitsStatsGroupByStatus,
+			domain.DeliveryUnitsStatsGroupByAdminAreaLevel1,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(ConsistOf(
+			WithTransform(func(r projectionresult.DeliveryUnitsStatsResult) []any {
+				return []any{*r.Status, *r.AdminAreaLevel1, r.Count, r.TotalPrice}
+			}, Equal([]any{domain.StatusPlanned, "Metropolitana", int64(2), 300.0})),
+			WithTransform(func(r projectionresult.DeliveryUnitsStatsResult) []any {
+				return []any{*r.Status, *r.AdminAreaLevel1, r.Count, r.TotalPrice}
+			}, Equal([]any{domain.StatusPlanned, "Valparaiso", int64(1), 50.0})),
+		))
+
+		// Los filtros de la proyección se aplican antes de agregar
+		results, err = findDeliveryUnitsStats(ctx, domain.DeliveryUnitsFilter{
+			Order: &domain.OrderFilter{
+				ReferenceIds: []string{"STATS-2"},
+			},
+		}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Count).To(Equal(int64(1)))
+		Expect(results[0].TotalVolume).To(Equal(3.0))
+
+		// Una dimensión desconocida se rechaza antes de consultar
+		_, err = findDeliveryUnitsStats(ctx, domain.DeliveryUnitsFilter{}, domain.DeliveryUnitsStatsGrouping{"carrier"})
+		Expect(err).To(MatchError(domain.ErrInvalidDeliveryUnitsStatsGroupBy))
+	})
+})
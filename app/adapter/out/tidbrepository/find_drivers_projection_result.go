@@ -0,0 +1,86 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/drivers"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+type FindDriversProjectionResult func(
+	ctx context.Context,
+	filters domain.DriverSearchFilters) (projectionresult.DriversProjectionResults, bool, error)
+
+func init() {
+	ioc.Registry(
+		NewFindDriversProjectionResult,
+		database.NewConnectionFactory,
+		drivers.NewProjection,
+	)
+}
+
+func NewFindDriversProjectionResult(
+	conn database.ConnectionFactory,
+	projection drivers.Projection) FindDriversProjectionResult {
+	const d = "d" // drivers
+
+	return func(ctx context.Context, filters domain.DriverSearchFilters) (projectionresult.DriversProjectionResults, bool, error) {
+		var results projectionresult.DriversProjectionResults
+
+		ds := goqu.From(goqu.T("drivers").As(d)).
+			Select(goqu.I(d + ".id").As("id")).
+			Where(goqu.Ex{
+				d + ".tenant_id": sharedcontext.TenantIDFromContext(ctx),
+			})
+
+		if len(filters.NationalIDs) > 0 {
+			ds = ds.Where(goqu.I(d + ".national_id").In(filters.NationalIDs))
+		}
+
+		if len(filters.Emails) > 0 {
+			ds = ds.Where(goqu.I(d + ".email").In(filters.Emails))
+		}
+
+		if filters.Name != nil {
+			ds = ds.Where(goqu.I(d + ".name").Like("%" + *filters.Name + "%"))
+		}
+
+		if projection.Name().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(d + ".name").As("name"))
+		}
+
+		if projection.NationalID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(d + ".national_id").As("national_id"))
+		}
+
+		if projection.Email().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(d + ".email").As("email"))
+		}
+
+		ds, err := applyRelayPagination(ds, d+".id", filters.Pagination)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&results).Error; err != nil {
+			return nil, false, err
+		}
+
+		results, hasMoreResults := trimRelayPage(results, filters.Pagination)
+		if filters.Pagination.IsBackward() {
+			results = results.Reversed()
+		}
+
+		return results, hasMoreResults, nil
+	}
+}
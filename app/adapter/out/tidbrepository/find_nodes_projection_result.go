@@ -129,14 +129,21 @@ func NewFindNodesProjectionResult(
 			}
 		}
 
-		// Join con address_infos si se requiere algún campo de addressInfo
-		if projection.AddressInfo().Has(filters.RequestedFields) {
+		// Join con address_infos si se requiere algún campo de addressInfo o un filtro geográfico
+		if projection.AddressInfo().Has(filters.RequestedFields) || !filters.GeoFilter.IsEmpty() {
 			ds = ds.InnerJoin(
 				goqu.T("address_infos").As(ai),
 				goqu.On(goqu.I(ai+".document_id").Eq(goqu.I(ni+".address_info_doc"))),
 			)
 		}
 
+		// Agregar filtros geográficos sobre las coordenadas del nodo
+		geoExpressions, err := geoFilterExpressions(ai, filters.GeoFilter)
+		if err != nil {
+			return nil, false, err
+		}
+		ds = ds.Where(geoExpressions...)
+
 		// Campos de node_infos
 		if projection.NodeInfo().Has(filters.RequestedFields) {
 			ds = ds.SelectAppend(
@@ -0,0 +1,83 @@
+package tidbrepository
+
+import (
+	"context"
+	"time"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// OrderDeliveryPin reúne el hash del PIN de una orden con su tipo de orden. La política del tipo
+// ya viene resuelta con el valor por defecto del tenant
+type OrderDeliveryPin struct {
+	PinHash   string
+	Attempts  domain.DeliveryPinAttempts
+	OrderType domain.OrderType
+}
+
+// FindOrderDeliveryPins retorna el PIN de entrega de las órdenes existentes, indexado por DocID de orden
+type FindOrderDeliveryPins func(ctx context.Context, orders []domain.Order) (map[domain.DocumentID]OrderDeliveryPin, error)
+
+func init() {
+	ioc.Registry(NewFindOrderDeliveryPins, database.NewConnectionFactory)
+}
+
+func NewFindOrderDeliveryPins(conn database.ConnectionFactory) FindOrderDeliveryPins {
+	return func(ctx context.Context, orders []domain.Order) (map[domain.DocumentID]OrderDeliveryPin, error) {
+		pins := make(map[domain.DocumentID]OrderDeliveryPin, len(orders))
+		if len(orders) == 0 {
+			return pins, nil
+		}
+
+		docIDs := make([]string, 0, len(orders))
+		for _, order := range orders {
+			docIDs = append(docIDs, order.DocID(ctx).String())
+		}
+
+		var rows []struct {
+			DocumentID                string
+			DeliveryPinHash           string
+			DeliveryPinFailedAttempts int
+			DeliveryPinLockedUntil    *time.Time
+			Type                      string
+			Description               string
+			DeliveryPinPolicy         string
+			TenantDeliveryPinPolicy   string
+		}
+		err := conn.DB.WithContext(ctx).
+			Table("orders o").
+			Select(`o.document_id,
+				COALESCE(o.delivery_pin_hash, '') AS delivery_pin_hash,
+				COALESCE(o.delivery_pin_failed_attempts, 0) AS delivery_pin_failed_attempts,
+				o.delivery_pin_locked_until,
+				COALESCE(ot.type, '') AS type,
+				COALESCE(ot.description, '') AS description,
+				COALESCE(ot.delivery_pin_policy, '') AS delivery_pin_policy,
+				COALESCE(t.delivery_pin_policy, '') AS tenant_delivery_pin_policy`).
+			Joins("LEFT JOIN order_types ot ON ot.document_id = o.order_type_doc").
+			Joins("LEFT JOIN tenants t ON t.id = o.tenant_id").
+			Where("o.document_id IN ?", docIDs).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			pins[domain.DocumentID(row.DocumentID)] = OrderDeliveryPin{
+				PinHash: row.DeliveryPinHash,
+				Attempts: domain.DeliveryPinAttempts{
+					Failed:      row.DeliveryPinFailedAttempts,
+					LockedUntil: row.DeliveryPinLockedUntil,
+				},
+				OrderType: domain.OrderType{
+					Type:              row.Type,
+					Description:       row.Description,
+					DeliveryPinPolicy: row.DeliveryPinPolicy,
+				}.WithDefaultDeliveryPinPolicy(row.TenantDeliveryPinPolicy),
+			}
+		}
+		return pins, nil
+	}
+}
@@ -0,0 +1,43 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindOrderDeliveryPins", func() {
+	var (
+		ctx  context.Context
+		find FindOrderDeliveryPins
+	)
+
+	BeforeEach(func() {
+		var err error
+		_, ctx, err = CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+		find = NewFindOrderDeliveryPins(connection)
+	})
+
+	It("should return the pin hash with the policy of the order type", func() {
+		orderType := domain.OrderType{Type: "PIN", DeliveryPinPolicy: domain.DeliveryPinPolicyReject}
+		Expect(NewUpsertOrderType(connection, nil)(ctx, orderType)).To(Succeed())
+
+		withPin := domain.Order{ReferenceID: "ORDER-PIN-FIND-1", OrderType: orderType}
+		withoutPin := domain.Order{ReferenceID: "ORDER-PIN-FIND-2"}
+		Expect(NewUpsertOrder(connection, nil)(ctx, withPin)).To(Succeed())
+		Expect(NewUpsertOrder(connection, nil)(ctx, withoutPin)).To(Succeed())
+		Expect(NewSaveOrderDeliveryPin(connection, nil)(ctx, withPin, "hash")).To(Succeed())
+
+		pins, err := find(ctx, []domain.Order{withPin, withoutPin, {ReferenceID: "ORDER-PIN-MISSING"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pins).To(HaveLen(2))
+		Expect(pins[withPin.DocID(ctx)].PinHash).To(Equal("hash"))
+		Expect(pins[withPin.DocID(ctx)].OrderType.DeliveryPinPolicy).To(Equal(domain.DeliveryPinPolicyReject))
+		Expect(pins[withoutPin.DocID(ctx)].PinHash).To(BeEmpty())
+		Expect(pins[withoutPin.DocID(ctx)].OrderType.RequiresDeliveryPin()).To(BeFalse())
+	})
+})
@@ -0,0 +1,76 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+// FindOrderRoutes retorna, por referencia de orden, la ruta del último registro del historial
+// de sus unidades que quedó asociado a una ruta existente
+type FindOrderRoutes func(ctx context.Context, orderReferenceIDs []string) (map[string]string, error)
+
+func init() {
+	ioc.Registry(NewFindOrderRoutes, database.NewConnectionFactory)
+}
+
+func NewFindOrderRoutes(conn database.ConnectionFactory) FindOrderRoutes {
+	const (
+		duh = "duh" // delivery_units_status_histories
+		r   = "r"   // routes
+	)
+
+	return func(ctx context.Context, orderReferenceIDs []string) (map[string]string, error) {
+		routes := make(map[string]string)
+		if len(orderReferenceIDs) == 0 {
+			return routes, nil
+		}
+
+		referenceByDoc := make(map[string]string, len(orderReferenceIDs))
+		orderDocs := make([]string, 0, len(orderReferenceIDs))
+		for _, ref := range orderReferenceIDs {
+			doc := domain.Order{ReferenceID: domain.ReferenceID(ref)}.DocID(ctx).String()
+			referenceByDoc[doc] = ref
+			orderDocs = append(orderDocs, doc)
+		}
+
+		latestRouteIDs := goqu.From(goqu.T("delivery_units_status_histories").As("h")).
+			Select(goqu.MAX(goqu.I("h.id")).As("id")).
+			Join(goqu.T("routes").As("rr"), goqu.On(goqu.I("rr.document_id").Eq(goqu.I("h.route_doc")))).
+			Where(
+				goqu.I("h.tenant_id").Eq(sharedcontext.TenantIDFromContext(ctx)),
+				goqu.I("h.order_doc").In(orderDocs),
+			).
+			GroupBy(goqu.I("h.order_doc")).
+			As("latest_route_ids")
+
+		ds := goqu.From(goqu.T("delivery_units_status_histories").As(duh)).
+			Select(
+				goqu.I(duh+".order_doc"),
+				goqu.I(r+".reference_id"),
+			).
+			Join(latestRouteIDs, goqu.On(goqu.I(duh+".id").Eq(goqu.I("latest_route_ids.id")))).
+			Join(goqu.T("routes").As(r), goqu.On(goqu.I(r+".document_id").Eq(goqu.I(duh+".route_doc"))))
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []struct {
+			OrderDoc    string
+			ReferenceID string
+		}
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			routes[referenceByDoc[row.OrderDoc]] = row.ReferenceID
+		}
+		return routes, nil
+	}
+}
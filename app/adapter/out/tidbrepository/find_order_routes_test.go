@@ -0,0 +1,41 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindOrderRoutes", func() {
+	It("should return the route of the latest history record linked to a route", func() {
+		Expect(NewLoadStatuses(connection)()).To(Succeed())
+
+		_, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+
+		order := domain.Order{
+			ReferenceID: "ORD-ROUTES-1",
+			DeliveryUnits: []domain.DeliveryUnit{
+				{Lpn: "LPN-ROUTES-1", Status: domain.Status{Status: domain.StatusInTransit}},
+			},
+		}
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+		route := domain.Route{ReferenceID: "ROUTE-ORDER-1", Orders: []domain.Order{order}}
+		Expect(NewUpsertRoute(connection, nil)(ctx, route, nil, "")).To(Succeed())
+		Expect(NewUpsertDeliveryUnitsHistory(connection, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{route},
+		})).To(Succeed())
+
+		// Un registro posterior sin ruta, como el de una cancelación, no pierde la ruta de la orden
+		order.DeliveryUnits[0].Status = domain.Status{Status: domain.StatusCancelled}
+		Expect(NewUpsertDeliveryUnitsHistory(connection, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{{Orders: []domain.Order{order}}},
+		})).To(Succeed())
+
+		routes, err := NewFindOrderRoutes(connection)(ctx, []string{"ORD-ROUTES-1", "ORD-ROUTES-2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(routes).To(Equal(map[string]string{"ORD-ROUTES-1": "ROUTE-ORDER-1"}))
+	})
+})
@@ -0,0 +1,112 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/plans"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+type FindPlansProjectionResult func(
+	ctx context.Context,
+	filters domain.PlansFilter) (projectionresult.PlansProjectionResults, bool, error)
+
+func init() {
+	ioc.Registry(
+		NewFindPlansProjectionResult,
+		database.NewConnectionFactory,
+		plans.NewProjection,
+	)
+}
+
+func NewFindPlansProjectionResult(
+	conn database.ConnectionFactory,
+	projection plans.Projection) FindPlansProjectionResult {
+	const (
+		p  = "p"  // plans
+		ph = "ph" // plan_headers
+	)
+
+	return func(ctx context.Context, filters domain.PlansFilter) (projectionresult.PlansProjectionResults, bool, error) {
+		var results projectionresult.PlansProjectionResults
+
+		ds := goqu.From(goqu.T("plans").As(p)).
+			Select(goqu.I(p + ".id").As("id")).
+			Where(goqu.Ex{
+				p + ".tenant_id": sharedcontext.TenantIDFromContext(ctx),
+			})
+
+		if len(filters.ReferenceIds) > 0 {
+			ds = ds.Where(goqu.I(p + ".reference_id").In(filters.ReferenceIds))
+		}
+
+		if filters.PlannedDate != nil {
+			if filters.PlannedDate.StartDate != nil {
+				ds = ds.Where(goqu.I(p + ".planned_date").Gte(*filters.PlannedDate.StartDate))
+			}
+			if filters.PlannedDate.EndDate != nil {
+				ds = ds.Where(goqu.I(p + ".planned_date").Lte(*filters.PlannedDate.EndDate))
+			}
+		}
+
+		if projection.ReferenceID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(p + ".reference_id").As("reference_id"))
+		}
+
+		if projection.PlannedDate().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(p + ".planned_date").As("planned_date"))
+		}
+
+		if projection.CreatedAt().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(p + ".created_at").As("created_at"))
+		}
+
+		// Join con plan_headers solo si se pide alguno de sus campos
+		if projection.Commerce().Has(filters.RequestedFields) ||
+			projection.Consumer().Has(filters.RequestedFields) ||
+			projection.Channel().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("plan_headers").As(ph),
+				goqu.On(goqu.I(ph+".document_id").Eq(goqu.I(p+".plan_headers_doc"))),
+			)
+		}
+
+		if projection.Commerce().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(ph + ".commerce").As("commerce"))
+		}
+
+		if projection.Consumer().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(ph + ".consumer").As("consumer"))
+		}
+
+		if projection.Channel().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(ph + ".channel").As("channel"))
+		}
+
+		ds, err := applyRelayPagination(ds, p+".id", filters.Pagination)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&results).Error; err != nil {
+			return nil, false, err
+		}
+
+		results, hasMoreResults := trimRelayPage(results, filters.Pagination)
+		if filters.Pagination.IsBackward() {
+			results = results.Reversed()
+		}
+
+		return results, hasMoreResults, nil
+	}
+}
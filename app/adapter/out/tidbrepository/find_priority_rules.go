@@ -0,0 +1,45 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+func init() {
+	ioc.Registry(
+		NewFindPriorityRules,
+		database.NewConnectionFactory,
+	)
+}
+
+// FindPriorityRules retorna las reglas de prioridad configuradas por el tenant del contexto
+type FindPriorityRules func(ctx context.Context) (domain.PriorityRules, error)
+
+func NewFindPriorityRules(conn database.ConnectionFactory) FindPriorityRules {
+	return func(ctx context.Context) (domain.PriorityRules, error) {
+		if conn.Strategy == "disabled" {
+			return nil, nil
+		}
+		var records []table.PriorityRule
+		err := conn.DB.WithContext(ctx).
+			Table("priority_rules").
+			Where("tenant_id = ?", sharedcontext.TenantIDFromContext(ctx)).
+			Order("priority DESC").
+			Find(&records).Error
+		if err != nil {
+			return nil, err
+		}
+
+		rules := make(domain.PriorityRules, len(records))
+		for i, r := range records {
+			rules[i] = r.Map()
+		}
+		return rules, nil
+	}
+}
@@ -0,0 +1,76 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+// FindRoutesInTransit retorna, de las rutas indicadas, las que todavía tienen alguna unidad
+// en tránsito según el último estado de cada unidad
+type FindRoutesInTransit func(ctx context.Context, routeReferenceIDs []string) (map[string]bool, error)
+
+func init() {
+	ioc.Registry(NewFindRoutesInTransit, database.NewConnectionFactory)
+}
+
+func NewFindRoutesInTransit(conn database.ConnectionFactory) FindRoutesInTransit {
+	const (
+		duh = "duh" // delivery_units_status_histories
+		s   = "s"   // statuses
+		r   = "r"   // routes
+	)
+
+	return func(ctx context.Context, routeReferenceIDs []string) (map[string]bool, error) {
+		inTransit := make(map[string]bool)
+		if len(routeReferenceIDs) == 0 {
+			return inTransit, nil
+		}
+
+		routeDocs := make([]string, 0, len(routeReferenceIDs))
+		for _, ref := range routeReferenceIDs {
+			routeDocs = append(routeDocs, domain.Route{ReferenceID: ref}.DocID(ctx).String())
+		}
+
+		ds := goqu.From(goqu.T("delivery_units_status_histories").As(duh)).
+			Select(goqu.I(r+".reference_id")).
+			Distinct().
+			Join(latestDeliveryUnitStatusIDs(ctx), goqu.On(goqu.I(duh+".id").Eq(goqu.I("latest_ids.id")))).
+			Join(goqu.T("statuses").As(s), goqu.On(goqu.I(s+".document_id").Eq(goqu.I(duh+".delivery_unit_status_doc")))).
+			Join(goqu.T("routes").As(r), goqu.On(goqu.I(r+".document_id").Eq(goqu.I(duh+".route_doc")))).
+			Where(
+				goqu.I(duh+".route_doc").In(routeDocs),
+				goqu.I(s+".status").Eq(domain.StatusInTransit),
+			)
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, err
+		}
+
+		var references []string
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&references).Error; err != nil {
+			return nil, err
+		}
+		for _, ref := range references {
+			inTransit[ref] = true
+		}
+		return inTransit, nil
+	}
+}
+
+// latestDeliveryUnitStatusIDs es la subconsulta latest_ids con el último registro del historial
+// de cada unidad del tenant
+func latestDeliveryUnitStatusIDs(ctx context.Context) *goqu.SelectDataset {
+	return goqu.From(goqu.T("delivery_units_status_histories")).
+		Select(goqu.MAX(goqu.I("id")).As("id")).
+		Where(goqu.Ex{
+			"tenant_id": sharedcontext.TenantIDFromContext(ctx),
+		}).
+		GroupBy(goqu.I("delivery_unit_doc"), goqu.I("order_doc")).
+		As("latest_ids")
+}
@@ -0,0 +1,43 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindRoutesInTransit", func() {
+	It("should return only the routes whose units are still in transit", func() {
+		Expect(NewLoadStatuses(connection)()).To(Succeed())
+
+		_, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+
+		route := func(referenceID, lpn, status string) domain.Route {
+			order := domain.Order{
+				ReferenceID: domain.ReferenceID("ORD-" + referenceID),
+				DeliveryUnits: []domain.DeliveryUnit{
+					{Lpn: lpn, Status: domain.Status{Status: status}},
+				},
+			}
+			Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+			return domain.Route{ReferenceID: referenceID, Orders: []domain.Order{order}}
+		}
+
+		err = NewUpsertDeliveryUnitsHistory(connection, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{
+				route("ROUTE-TRANSIT-1", "LPN-TRANSIT-1", domain.StatusInTransit),
+				route("ROUTE-TRANSIT-2", "LPN-TRANSIT-2", domain.StatusFinished),
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		inTransit, err := NewFindRoutesInTransit(connection)(ctx, []string{
+			"ROUTE-TRANSIT-1", "ROUTE-TRANSIT-2", "ROUTE-TRANSIT-3",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inTransit).To(Equal(map[string]bool{"ROUTE-TRANSIT-1": true}))
+	})
+})
@@ -0,0 +1,227 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/routes"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+type FindRoutesProjectionResult func(
+	ctx context.Context,
+	filters domain.RoutesFilter) (projectionresult.RoutesProjectionResults, bool, error)
+
+func init() {
+	ioc.Registry(
+		NewFindRoutesProjectionResult,
+		database.NewConnectionFactory,
+		routes.NewProjection,
+	)
+}
+
+func NewFindRoutesProjectionResult(
+	conn database.ConnectionFactory,
+	projection routes.Projection) FindRoutesProjectionResult {
+	const (
+		r = "r" // routes
+		p = "p" // plans
+		v = "v" // vehicles
+		d = "d" // drivers
+		c = "c" // carriers
+	)
+
+	return func(ctx context.Context, filters domain.RoutesFilter) (projectionresult.RoutesProjectionResults, bool, error) {
+		var results projectionresult.RoutesProjectionResults
+
+		ds := goqu.From(goqu.T("routes").As(r)).
+			Select(goqu.I(r + ".id").As("id")).
+			Where(goqu.Ex{
+				r + ".tenant_id": sharedcontext.TenantIDFromContext(ctx),
+			})
+
+		if len(filters.ReferenceIds) > 0 {
+			ds = ds.Where(goqu.I(r + ".reference_id").In(filters.ReferenceIds))
+		}
+
+		// Plan y vehículo se filtran por su document_id para no requerir joins
+		if len(filters.PlanReferenceIds) > 0 {
+			planDocs := make([]string, 0, len(filters.PlanReferenceIds))
+			for _, ref := range filters.PlanReferenceIds {
+				planDocs = append(planDocs, domain.Plan{ReferenceID: ref}.DocID(ctx).String())
+			}
+			ds = ds.Where(goqu.I(r + ".plan_doc").In(planDocs))
+		}
+
+		if len(filters.VehiclePlates) > 0 {
+			vehicleDocs := make([]string, 0, len(filters.VehiclePlates))
+			for _, plate := range filters.VehiclePlates {
+				vehicleDocs = append(vehicleDocs, domain.Vehicle{Plate: plate}.DocID(ctx).String())
+			}
+			ds = ds.Where(goqu.I(r + ".vehicle_doc").In(vehicleDocs))
+		}
+
+		if projection.ReferenceID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(r + ".reference_id").As("reference_id"))
+		}
+
+		if projection.CreatedAt().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(r + ".created_at").As("created_at"))
+		}
+
+		if projection.PlanReferenceID().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("plans").As(p),
+				goqu.On(goqu.I(p+".document_id").Eq(goqu.I(r+".plan_doc"))),
+			).SelectAppend(goqu.I(p + ".reference_id").As("plan_reference_id"))
+		}
+
+		if projection.NeedsReoptimization().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(r + ".needs_reoptimization").As("needs_reoptimization"))
+		}
+
+		if projection.Vehicle().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("vehicles").As(v),
+				goqu.On(goqu.I(v+".document_id").Eq(goqu.I(r+".vehicle_doc"))),
+			)
+		}
+
+		if projection.VehiclePlate().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(v + ".plate").As("vehicle_plate"))
+		}
+
+		if projection.Driver().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("drivers").As(d),
+				goqu.On(goqu.I(d+".document_id").Eq(goqu.I(r+".driver_doc"))),
+			)
+		}
+
+		if projection.DriverName().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(d + ".name").As("driver_name"))
+		}
+
+		if projection.DriverNationalID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(d + ".national_id").As("driver_national_id"))
+		}
+
+		if projection.DriverEmail().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(d + ".email").As("driver_email"))
+		}
+
+		if projection.Carrier().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("carriers").As(c),
+				goqu.On(goqu.I(c+".document_id").Eq(goqu.I(r+".carrier_doc"))),
+			)
+		}
+
+		if projection.CarrierName().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(c + ".name").As("carrier_name"))
+		}
+
+		if projection.CarrierNationalID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(c + ".national_id").As("carrier_national_id"))
+		}
+
+		// Las visitas ordenadas, sus órdenes y unidades viven en el contrato guardado con la ruta
+		if projection.Visits().HasAnyPrefix(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(r + ".raw").As("contract"))
+		}
+
+		ds, err := applyRelayPagination(ds, r+".id", filters.Pagination)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&results).Error; err != nil {
+			return nil, false, err
+		}
+
+		results, hasMoreResults := trimRelayPage(results, filters.Pagination)
+		if filters.Pagination.IsBackward() {
+			results = results.Reversed()
+		}
+
+		// El contrato no refleja el avance de la ruta: el estado vigente de cada unidad se lee
+		// del historial, considerando solo su último registro
+		if projection.VisitsDeliveryUnitStatus().Has(filters.RequestedFields) && len(results) > 0 {
+			if err := attachRouteDeliveryUnitStatuses(ctx, conn, results); err != nil {
+				return nil, false, err
+			}
+		}
+
+		return results, hasMoreResults, nil
+	}
+}
+
+// attachRouteDeliveryUnitStatuses completa en cada ruta el último estado de las unidades que
+// siguen asignadas a ella
+func attachRouteDeliveryUnitStatuses(
+	ctx context.Context,
+	conn database.ConnectionFactory,
+	results projectionresult.RoutesProjectionResults) error {
+	const (
+		duh = "duh" // delivery_units_status_histories
+		s   = "s"   // statuses
+		r   = "r"   // routes
+		o   = "o"   // orders
+		du  = "du"  // delivery_units
+	)
+
+	routeIDs := make([]int64, len(results))
+	for i, result := range results {
+		routeIDs[i] = result.ID
+	}
+
+	ds := goqu.From(goqu.T("delivery_units_status_histories").As(duh)).
+		Select(
+			goqu.I(r+".id").As("route_id"),
+			goqu.I(o+".reference_id").As("order_reference_id"),
+			goqu.I(du+".lpn").As("lpn"),
+			goqu.I(s+".status").As("status"),
+		).
+		Join(latestDeliveryUnitStatusIDs(ctx), goqu.On(goqu.I(duh+".id").Eq(goqu.I("latest_ids.id")))).
+		Join(goqu.T("statuses").As(s), goqu.On(goqu.I(s+".document_id").Eq(goqu.I(duh+".delivery_unit_status_doc")))).
+		Join(goqu.T("routes").As(r), goqu.On(goqu.I(r+".document_id").Eq(goqu.I(duh+".route_doc")))).
+		Join(goqu.T("orders").As(o), goqu.On(goqu.I(o+".document_id").Eq(goqu.I(duh+".order_doc")))).
+		Join(goqu.T("delivery_units").As(du), goqu.On(goqu.I(du+".document_id").Eq(goqu.I(duh+".delivery_unit_doc")))).
+		Where(goqu.I(r + ".id").In(routeIDs))
+
+	sql, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return err
+	}
+
+	var rows []struct {
+		RouteID          int64
+		OrderReferenceID string
+		LPN              string
+		Status           string
+	}
+	if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	byRoute := make(map[int64]projectionresult.RouteDeliveryUnitStatuses)
+	for _, row := range rows {
+		if byRoute[row.RouteID] == nil {
+			byRoute[row.RouteID] = make(projectionresult.RouteDeliveryUnitStatuses)
+		}
+		byRoute[row.RouteID].Set(row.OrderReferenceID, row.LPN, row.Status)
+	}
+	for i := range results {
+		results[i].DeliveryUnitStatuses = byRoute[results[i].ID]
+	}
+	return nil
+}
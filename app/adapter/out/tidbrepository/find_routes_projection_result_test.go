@@ -0,0 +1,159 @@
+package tidbrepository
+
+import (
+	"context"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/routes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindRoutesProjectionResult", func() {
+	var (
+		conn database.ConnectionFactory
+	)
+
+	BeforeEach(func() {
+		conn = connection
+	})
+
+	createRoute := func(ctx context.Context, referenceID, planReferenceID, plate string) {
+		plan := domain.Plan{ReferenceID: planReferenceID, PlannedDate: time.Now()}
+		Expect(NewUpsertPlan(conn, nil)(ctx, plan)).To(Succeed())
+
+		vehicle := domain.Vehicle{
+			Plate: plate,
+			Carrier: domain.Carrier{
+				Name:       "Transportes Test",
+				NationalID: "76.000.000-" + plate,
+				Driver: domain.Driver{
+					Name:       "Juan Pérez",
+					NationalID: "11.111.111-" + plate,
+					Email:      plate + "@driver.cl",
+				},
+			},
+		}
+		Expect(NewUpsertVehicle(conn, nil)(ctx, vehicle)).To(Succeed())
+		Expect(NewUpsertCarrier(conn, nil)(ctx, vehicle.Carrier)).To(Succeed())
+		Expect(NewUpsertDriver(conn, nil)(ctx, vehicle.Carrier.Driver)).To(Succeed())
+
+		contract := request.UpsertRouteRequest{
+			ReferenceID: referenceID,
+			Visits: []request.UpsertRouteVisit{
+				{SequenceNumber: 1, Orders: []request.UpsertRouteOrder{{ReferenceID: "ORD-" + referenceID}}},
+			},
+		}
+		route := domain.Route{ReferenceID: referenceID, Vehicle: vehicle}
+		Expect(NewUpsertRoute(conn, nil)(ctx, route, contract, plan.DocID(ctx).String())).To(Succeed())
+	}
+
+	It("should return empty list when no routes exist", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		findRoutes := NewFindRoutesProjectionResult(conn, routes.NewProjection())
+		results, hasMore, err := findRoutes(ctx, domain.RoutesFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(BeEmpty())
+		Expect(hasMore).To(BeFalse())
+	})
+
+	It("should filter routes by plan and vehicle and project joined fields", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		createRoute(ctx, "ROUTE-001", "PLAN-001", "ABC123")
+		createRoute(ctx, "ROUTE-002", "PLAN-002", "XYZ789")
+
+		projection := routes.NewProjection()
+		findRoutes := NewFindRoutesProjectionResult(conn, projection)
+		results, _, err := findRoutes(ctx, domain.RoutesFilter{
+			PlanReferenceIds: []string{"PLAN-001"},
+			VehiclePlates:    []string{"ABC123"},
+			RequestedFields: map[string]any{
+				projection.ReferenceID().String():       true,
+				projection.PlanReferenceID().String():   true,
+				projection.Vehicle().String():           true,
+				projection.VehiclePlate().String():      true,
+				projection.Driver().String():            true,
+				projection.DriverName().String():        true,
+				projection.Carrier().String():           true,
+				projection.CarrierNationalID().String(): true,
+				projection.Visits().String():            true,
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].ReferenceID).To(Equal("ROUTE-001"))
+		Expect(results[0].PlanReferenceID).To(Equal("PLAN-001"))
+		Expect(results[0].VehiclePlate).To(Equal("ABC123"))
+		Expect(results[0].DriverName).To(Equal("Juan Pérez"))
+		Expect(results[0].CarrierNationalID).To(Equal("76.000.000-ABC123"))
+		Expect(string(results[0].Contract)).To(ContainSubstring("ORD-ROUTE-001"))
+	})
+
+	It("should paginate routes forward", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		createRoute(ctx, "ROUTE-101", "PLAN-101", "AAA101")
+		createRoute(ctx, "ROUTE-102", "PLAN-101", "AAA102")
+
+		first := 1
+		findRoutes := NewFindRoutesProjectionResult(conn, routes.NewProjection())
+		results, hasMore, err := findRoutes(ctx, domain.RoutesFilter{
+			Pagination: domain.Pagination{First: &first},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(hasMore).To(BeTrue())
+	})
+
+	It("should return the first page when no page size is requested", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		createRoute(ctx, "ROUTE-201", "PLAN-201", "AAA201")
+		createRoute(ctx, "ROUTE-202", "PLAN-201", "AAA202")
+
+		findRoutes := NewFindRoutesProjectionResult(conn, routes.NewProjection())
+		results, hasMore, err := findRoutes(ctx, domain.RoutesFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(hasMore).To(BeFalse())
+	})
+
+	It("should attach the latest status of the delivery units of each route", func() {
+		Expect(NewLoadStatuses(conn)()).To(Succeed())
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		createRoute(ctx, "ROUTE-301", "PLAN-301", "AAA301")
+		order := domain.Order{
+			ReferenceID: "ORD-ROUTE-301",
+			DeliveryUnits: []domain.DeliveryUnit{
+				{Lpn: "LPN-ROUTE-301", Status: domain.Status{Status: domain.StatusInTransit}},
+			},
+		}
+		Expect(NewUpsertOrder(conn, nil)(ctx, order)).To(Succeed())
+		Expect(NewUpsertDeliveryUnitsHistory(conn, nil)(ctx, domain.Plan{
+			Routes: []domain.Route{{ReferenceID: "ROUTE-301", Orders: []domain.Order{order}}},
+		})).To(Succeed())
+
+		projection := routes.NewProjection()
+		findRoutes := NewFindRoutesProjectionResult(conn, projection)
+		results, _, err := findRoutes(ctx, domain.RoutesFilter{
+			RequestedFields: map[string]any{
+				projection.Visits().String():                   true,
+				projection.VisitsDeliveryUnitStatus().String(): true,
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].DeliveryUnitStatuses.Status("ORD-ROUTE-301", "LPN-ROUTE-301")).To(Equal(domain.StatusInTransit))
+	})
+})
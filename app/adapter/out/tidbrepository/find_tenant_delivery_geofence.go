@@ -0,0 +1,37 @@
+package tidbrepository
+
+import (
+	"context"
+	"errors"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm"
+)
+
+// FindTenantDeliveryGeofence retorna el geocerco de entrega configurado por el tenant del
+// contexto. Un radio cero indica que el tenant no lo ha configurado
+type FindTenantDeliveryGeofence func(context.Context) (domain.DeliveryGeofence, error)
+
+func init() {
+	ioc.Registry(NewFindTenantDeliveryGeofence, database.NewConnectionFactory)
+}
+
+func NewFindTenantDeliveryGeofence(conn database.ConnectionFactory) FindTenantDeliveryGeofence {
+	return func(ctx context.Context) (domain.DeliveryGeofence, error) {
+		var tenant table.Tenant
+		err := conn.DB.WithContext(ctx).
+			Where("id = ?", sharedcontext.TenantIDFromContext(ctx)).
+			First(&tenant).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.DeliveryGeofence{}, ErrTenantNotFound
+		}
+		if err != nil {
+			return domain.DeliveryGeofence{}, err
+		}
+		return domain.DeliveryGeofence{RadiusMeters: tenant.DeliveryGeofenceRadiusMeters}, nil
+	}
+}
@@ -0,0 +1,45 @@
+package tidbrepository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm"
+)
+
+// FindTenantOrderImportMapping retorna el mapeo de columnas guardado por el tenant del
+// contexto para importar órdenes. Un mapeo vacío indica que no se ha guardado
+type FindTenantOrderImportMapping func(context.Context) (domain.OrderImportMapping, error)
+
+func init() {
+	ioc.Registry(NewFindTenantOrderImportMapping, database.NewConnectionFactory)
+}
+
+func NewFindTenantOrderImportMapping(conn database.ConnectionFactory) FindTenantOrderImportMapping {
+	return func(ctx context.Context) (domain.OrderImportMapping, error) {
+		var tenant table.Tenant
+		err := conn.DB.WithContext(ctx).
+			Where("id = ?", sharedcontext.TenantIDFromContext(ctx)).
+			First(&tenant).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.OrderImportMapping{}, ErrTenantNotFound
+		}
+		if err != nil {
+			return domain.OrderImportMapping{}, err
+		}
+
+		var columns map[string]string
+		if len(tenant.OrderImportMapping) > 0 {
+			if err := json.Unmarshal(tenant.OrderImportMapping, &columns); err != nil {
+				return domain.OrderImportMapping{}, err
+			}
+		}
+		return domain.OrderImportMapping{Columns: columns}, nil
+	}
+}
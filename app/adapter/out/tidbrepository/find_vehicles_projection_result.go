@@ -0,0 +1,114 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/projection/vehicles"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/doug-martin/goqu/v9"
+)
+
+type FindVehiclesProjectionResult func(
+	ctx context.Context,
+	filters domain.VehicleSearchFilters) (projectionresult.VehiclesProjectionResults, bool, error)
+
+func init() {
+	ioc.Registry(
+		NewFindVehiclesProjectionResult,
+		database.NewConnectionFactory,
+		vehicles.NewProjection,
+	)
+}
+
+func NewFindVehiclesProjectionResult(
+	conn database.ConnectionFactory,
+	projection vehicles.Projection) FindVehiclesProjectionResult {
+	const (
+		v  = "v"  // vehicles
+		vc = "vc" // vehicle_categories
+		c  = "c"  // carriers
+	)
+
+	return func(ctx context.Context, filters domain.VehicleSearchFilters) (projectionresult.VehiclesProjectionResults, bool, error) {
+		var results projectionresult.VehiclesProjectionResults
+
+		ds := goqu.From(goqu.T("vehicles").As(v)).
+			Select(goqu.I(v + ".id").As("id")).
+			Where(goqu.Ex{
+				v + ".tenant_id": sharedcontext.TenantIDFromContext(ctx),
+			})
+
+		if len(filters.Plates) > 0 {
+			ds = ds.Where(goqu.I(v + ".plate").In(filters.Plates))
+		}
+
+		// El transportista se identifica por su national id, que es la base de su document_id
+		if filters.CarrierReferenceID != "" {
+			carrierDoc := domain.Carrier{NationalID: filters.CarrierReferenceID}.DocID(ctx).String()
+			ds = ds.Where(goqu.I(v + ".carrier_doc").Eq(carrierDoc))
+		}
+
+		if projection.Plate().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(v + ".plate").As("plate"))
+		}
+
+		if projection.CertificateDate().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(v + ".certificate_date").As("certificate_date"))
+		}
+
+		if projection.Category().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("vehicle_categories").As(vc),
+				goqu.On(goqu.I(vc+".document_id").Eq(goqu.I(v+".vehicle_category_doc"))),
+			)
+		}
+
+		if projection.CategoryType().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(vc + ".type").As("category_type"))
+		}
+
+		if projection.CategoryMaxPackagesQuantity().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(vc + ".max_packages_quantity").As("category_max_packages_quantity"))
+		}
+
+		if projection.Carrier().Has(filters.RequestedFields) {
+			ds = ds.LeftJoin(
+				goqu.T("carriers").As(c),
+				goqu.On(goqu.I(c+".document_id").Eq(goqu.I(v+".carrier_doc"))),
+			)
+		}
+
+		if projection.CarrierName().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(c + ".name").As("carrier_name"))
+		}
+
+		if projection.CarrierNationalID().Has(filters.RequestedFields) {
+			ds = ds.SelectAppend(goqu.I(c + ".national_id").As("carrier_national_id"))
+		}
+
+		ds, err := applyRelayPagination(ds, v+".id", filters.Pagination)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sql, args, err := ds.Prepared(true).ToSQL()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err := conn.WithContext(ctx).Raw(sql, args...).Scan(&results).Error; err != nil {
+			return nil, false, err
+		}
+
+		results, hasMoreResults := trimRelayPage(results, filters.Pagination)
+		if filters.Pagination.IsBackward() {
+			results = results.Reversed()
+		}
+
+		return results, hasMoreResults, nil
+	}
+}
@@ -0,0 +1,114 @@
+package tidbrepository
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"transport-app/app/domain"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/paulmach/orb"
+)
+
+const (
+	earthRadiusMeters = 6371000.0
+	metersPerDegree   = 111320.0
+)
+
+// geoFilterExpressions traduce el filtro geográfico a condiciones sobre las columnas latitude y
+// longitude de address_infos (alias). Las coordenadas se guardan como números, sin tipos
+// espaciales, por lo que sólo se usan funciones matemáticas disponibles tanto en PostgreSQL
+// como en TiDB. Cada filtro agrega además un rango por caja envolvente que permite usar índices.
+func geoFilterExpressions(alias string, filter domain.GeoFilter) ([]exp.Expression, error) {
+	if filter.IsEmpty() {
+		return nil, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	lat := goqu.I(alias + ".latitude")
+	lon := goqu.I(alias + ".longitude")
+	var expressions []exp.Expression
+
+	if filter.BoundingBox != nil {
+		expressions = append(expressions, boundExpressions(lat, lon, *filter.BoundingBox)...)
+	}
+
+	if radius := filter.WithinRadius; radius != nil {
+		expressions = append(expressions, boundExpressions(lat, lon, radiusBound(*radius))...)
+		// Distancia haversine; LEAST evita un argumento de ASIN mayor a 1 por redondeo
+		expressions = append(expressions, goqu.L(
+			"2 * "+sqlFloat(earthRadiusMeters)+" * ASIN(LEAST(1, SQRT("+
+				"POWER(SIN(RADIANS(? - "+sqlFloat(radius.Center.Lat())+") / 2), 2) + "+
+				"COS(RADIANS("+sqlFloat(radius.Center.Lat())+")) * COS(RADIANS(?)) * "+
+				"POWER(SIN(RADIANS(? - "+sqlFloat(radius.Center.Lon())+") / 2), 2)))) <= "+sqlFloat(radius.Meters),
+			lat, lat, lon))
+	}
+
+	if len(filter.WithinPolygon) > 0 {
+		expressions = append(expressions, boundExpressions(lat, lon, filter.WithinPolygon.Bound())...)
+		expressions = append(expressions, pointInPolygonExpression(lat, lon, filter.WithinPolygon))
+	}
+
+	return expressions, nil
+}
+
+func boundExpressions(lat, lon exp.IdentifierExpression, bound orb.Bound) []exp.Expression {
+	return []exp.Expression{
+		lat.Between(exp.NewRangeVal(bound.Min.Lat(), bound.Max.Lat())),
+		lon.Between(exp.NewRangeVal(bound.Min.Lon(), bound.Max.Lon())),
+	}
+}
+
+// radiusBound calcula la caja que contiene el círculo; cerca de los polos no acota la longitud
+func radiusBound(radius domain.RadiusFilter) orb.Bound {
+	deltaLat := radius.Meters / metersPerDegree
+	deltaLon := 180.0
+	if cos := math.Cos(radius.Center.Lat() * math.Pi / 180); cos > 0.01 {
+		deltaLon = math.Min(deltaLat/cos, 180)
+	}
+	return orb.Bound{
+		Min: orb.Point{radius.Center.Lon() - deltaLon, radius.Center.Lat() - deltaLat},
+		Max: orb.Point{radius.Center.Lon() + deltaLon, radius.Center.Lat() + deltaLat},
+	}
+}
+
+// pointInPolygonExpression aplica ray casting: un punto está dentro si un rayo hacia el este
+// cruza un número impar de aristas. Considera todos los anillos, por lo que los agujeros quedan
+// fuera. Las aristas horizontales no cruzan el rayo y se omiten, evitando divisiones por cero.
+func pointInPolygonExpression(lat, lon exp.IdentifierExpression, polygon orb.Polygon) exp.Expression {
+	var (
+		crossings []string
+		args      []any
+	)
+	for _, ring := range polygon {
+		n := len(ring)
+		if n > 1 && ring[0] == ring[n-1] {
+			n--
+		}
+		for i := 0; i < n; i++ {
+			from, to := ring[i], ring[(i+1)%n]
+			if from.Lat() == to.Lat() {
+				continue
+			}
+			slope := (to.Lon() - from.Lon()) / (to.Lat() - from.Lat())
+			crossings = append(crossings,
+				"CASE WHEN (? > "+sqlFloat(from.Lat())+") <> (? > "+sqlFloat(to.Lat())+") "+
+					"AND ? < "+sqlFloat(from.Lon())+" + "+sqlFloat(slope)+" * (? - "+sqlFloat(from.Lat())+") "+
+					"THEN 1 ELSE 0 END")
+			args = append(args, lat, lat, lon, lat)
+		}
+	}
+	if len(crossings) == 0 {
+		return goqu.L("FALSE")
+	}
+	return goqu.L("MOD("+strings.Join(crossings, " + ")+", 2) = 1", args...)
+}
+
+// sqlFloat formatea una constante calculada en Go; los paréntesis evitan que un signo negativo
+// junto a una resta forme un comentario "--"
+func sqlFloat(v float64) string {
+	return "(" + strconv.FormatFloat(v, 'f', -1, 64) + ")"
+}
@@ -0,0 +1,30 @@
+package tidbrepository
+
+import (
+	"github.com/doug-martin/goqu/v9"
+	"github.com/paulmach/orb"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pointInPolygonExpression", func() {
+	render := func(polygon orb.Polygon) string {
+		sql, _, err := goqu.Dialect("mysql").From("address_infos").
+			Where(pointInPolygonExpression(goqu.I("a.latitude"), goqu.I("a.longitude"), polygon)).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		return sql
+	}
+
+	It("should match nothing when every edge is horizontal", func() {
+		sql := render(orb.Polygon{{{-70.7, -33.5}, {-70.6, -33.5}, {-70.5, -33.5}}})
+		Expect(sql).To(ContainSubstring("WHERE FALSE"))
+		Expect(sql).ToNot(ContainSubstring("MOD("))
+	})
+
+	It("should count ray crossings for a regular ring", func() {
+		sql := render(orb.Polygon{{{-70.7, -33.5}, {-70.5, -33.5}, {-70.5, -33.3}, {-70.7, -33.3}}})
+		Expect(sql).To(ContainSubstring("MOD("))
+	})
+})
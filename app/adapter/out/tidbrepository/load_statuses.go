@@ -51,6 +51,10 @@ func NewLoadStatuses(conn database.ConnectionFactory) LoadStatuses {
 			ID:     8,
 			Status: domain.StatusPending,
 		}
+		partiallyDelivered := domain.Status{
+			ID:     9,
+			Status: domain.StatusPartiallyDelivered,
+		}
 		var records = []table.Status{
 			{ID: 1, Status: available.Status, DocumentID: available.DocID().String()},
 			{ID: 2, Status: scanned.Status, DocumentID: scanned.DocID().String()},
@@ -60,6 +64,7 @@ func NewLoadStatuses(conn database.ConnectionFactory) LoadStatuses {
 			{ID: 6, Status: cancelled.Status, DocumentID: cancelled.DocID().String()},
 			{ID: 7, Status: finished.Status, DocumentID: finished.DocID().String()},
 			{ID: 8, Status: pending.Status, DocumentID: pending.DocID().String()},
+			{ID: 9, Status: partiallyDelivered.Status, DocumentID: partiallyDelivered.DocID().String()},
 		}
 		if conn.Strategy == "disabled" {
 			return nil
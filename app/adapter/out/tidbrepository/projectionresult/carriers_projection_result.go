@@ -0,0 +1,18 @@
+package projectionresult
+
+import "slices"
+
+type CarriersProjectionResult struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	NationalID string `json:"national_id"`
+}
+
+type CarriersProjectionResults []CarriersProjectionResult
+
+func (r CarriersProjectionResults) Reversed() CarriersProjectionResults {
+	copied := make(CarriersProjectionResults, len(r))
+	copy(copied, r)
+	slices.Reverse(copied)
+	return copied
+}
@@ -67,6 +67,13 @@ type DeliveryUnitsProjectionResult struct {
 	// Evidence Photos Information
 	EvidencePhotos table.JSONEvidencePhotos `json:"evidence_photos" gorm:"type:json;"`
 
+	// Geofence Validation Information
+	DistanceToDestinationMeters *float64 `json:"distance_to_destination_meters"`
+	SuspiciousLocation          bool     `json:"suspicious_location"`
+
+	// Partial Delivery Information
+	ItemConfirmations table.JSONItemConfirmations `json:"item_confirmations" gorm:"type:json;"`
+
 	// OrderType Information
 	OrderType            string `json:"order_type"`
 	OrderTypeDescription string `json:"order_type_description"`
@@ -0,0 +1,20 @@
+package projectionresult
+
+import "time"
+
+// DeliveryUnitsStatsResult es una fila de la agregación de unidades de entrega. Las columnas de
+// agrupación quedan en nil cuando no forman parte del groupBy o la unidad no tiene el dato.
+// Las sumas se leen como float64 porque SUM sobre bigint retorna numeric/decimal.
+type DeliveryUnitsStatsResult struct {
+	Status          *string    `json:"status"`
+	AdminAreaLevel1 *string    `json:"admin_area_level1"`
+	AdminAreaLevel2 *string    `json:"admin_area_level2"`
+	AdminAreaLevel3 *string    `json:"admin_area_level3"`
+	AdminAreaLevel4 *string    `json:"admin_area_level4"`
+	PromisedDate    *time.Time `json:"promised_date"`
+	OrderType       *string    `json:"order_type"`
+	Count           int64      `json:"count"`
+	TotalWeight     float64    `json:"total_weight"`
+	TotalVolume     float64    `json:"total_volume"`
+	TotalPrice      float64    `json:"total_price"`
+}
@@ -0,0 +1,19 @@
+package projectionresult
+
+import "slices"
+
+type DriversProjectionResult struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	NationalID string `json:"national_id"`
+	Email      string `json:"email"`
+}
+
+type DriversProjectionResults []DriversProjectionResult
+
+func (r DriversProjectionResults) Reversed() DriversProjectionResults {
+	copied := make(DriversProjectionResults, len(r))
+	copy(copied, r)
+	slices.Reverse(copied)
+	return copied
+}
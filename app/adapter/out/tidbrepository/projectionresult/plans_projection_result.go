@@ -0,0 +1,25 @@
+package projectionresult
+
+import (
+	"slices"
+	"time"
+)
+
+type PlansProjectionResult struct {
+	ID          int64     `json:"id"`
+	ReferenceID string    `json:"reference_id"`
+	PlannedDate time.Time `json:"planned_date"`
+	CreatedAt   time.Time `json:"created_at"`
+	Commerce    string    `json:"commerce"`
+	Consumer    string    `json:"consumer"`
+	Channel     string    `json:"channel"`
+}
+
+type PlansProjectionResults []PlansProjectionResult
+
+func (r PlansProjectionResults) Reversed() PlansProjectionResults {
+	copied := make(PlansProjectionResults, len(r))
+	copy(copied, r)
+	slices.Reverse(copied)
+	return copied
+}
@@ -0,0 +1,78 @@
+package projectionresult
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+)
+
+// RouteContract conserva el contrato original de la ruta (visitas, órdenes y unidades) tal como se guardó
+type RouteContract json.RawMessage
+
+// Value implements the driver.Valuer interface
+func (c RouteContract) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	return []byte(c), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (c *RouteContract) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		*c = append(RouteContract(nil), v...)
+		return nil
+	case string:
+		*c = RouteContract(v)
+		return nil
+	default:
+		return errors.New("type assertion to []byte or string failed")
+	}
+}
+
+type RoutesProjectionResult struct {
+	ID                  int64         `json:"id"`
+	ReferenceID         string        `json:"reference_id"`
+	CreatedAt           time.Time     `json:"created_at"`
+	PlanReferenceID     string        `json:"plan_reference_id"`
+	NeedsReoptimization bool          `json:"needs_reoptimization"`
+	VehiclePlate        string        `json:"vehicle_plate"`
+	DriverName          string        `json:"driver_name"`
+	DriverNationalID    string        `json:"driver_national_id"`
+	DriverEmail         string        `json:"driver_email"`
+	CarrierName         string        `json:"carrier_name"`
+	CarrierNationalID   string        `json:"carrier_national_id"`
+	Contract            RouteContract `json:"contract"`
+	// DeliveryUnitStatuses se completa después de la consulta principal
+	DeliveryUnitStatuses RouteDeliveryUnitStatuses `json:"-" gorm:"-"`
+}
+
+// RouteDeliveryUnitStatuses indexa el último estado de cada unidad de la ruta por referencia de
+// orden y LPN
+type RouteDeliveryUnitStatuses map[string]map[string]string
+
+func (s RouteDeliveryUnitStatuses) Set(orderReferenceID, lpn, status string) {
+	if s[orderReferenceID] == nil {
+		s[orderReferenceID] = make(map[string]string)
+	}
+	s[orderReferenceID][lpn] = status
+}
+
+func (s RouteDeliveryUnitStatuses) Status(orderReferenceID, lpn string) string {
+	return s[orderReferenceID][lpn]
+}
+
+type RoutesProjectionResults []RoutesProjectionResult
+
+func (r RoutesProjectionResults) Reversed() RoutesProjectionResults {
+	copied := make(RoutesProjectionResults, len(r))
+	copy(copied, r)
+	slices.Reverse(copied)
+	return copied
+}
@@ -0,0 +1,22 @@
+package projectionresult
+
+import "slices"
+
+type VehiclesProjectionResult struct {
+	ID                          int64  `json:"id"`
+	Plate                       string `json:"plate"`
+	CertificateDate             string `json:"certificate_date"`
+	CategoryType                string `json:"category_type"`
+	CategoryMaxPackagesQuantity int    `json:"category_max_packages_quantity"`
+	CarrierName                 string `json:"carrier_name"`
+	CarrierNationalID           string `json:"carrier_national_id"`
+}
+
+type VehiclesProjectionResults []VehiclesProjectionResult
+
+func (r VehiclesProjectionResults) Reversed() VehiclesProjectionResults {
+	copied := make(VehiclesProjectionResults, len(r))
+	copy(copied, r)
+	slices.Reverse(copied)
+	return copied
+}
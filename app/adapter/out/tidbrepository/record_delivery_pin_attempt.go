@@ -0,0 +1,45 @@
+package tidbrepository
+
+import (
+	"context"
+	"time"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// RecordDeliveryPinAttempt registra el resultado de verificar el PIN de una orden. Un acierto
+// reinicia el contador; un fallo lo incrementa y bloquea la orden al alcanzar el límite
+type RecordDeliveryPinAttempt func(ctx context.Context, o domain.Order, verified bool, now time.Time) error
+
+func init() {
+	ioc.Registry(NewRecordDeliveryPinAttempt, database.NewConnectionFactory)
+}
+
+func NewRecordDeliveryPinAttempt(conn database.ConnectionFactory) RecordDeliveryPinAttempt {
+	return func(ctx context.Context, o domain.Order, verified bool, now time.Time) error {
+		if verified {
+			return conn.DB.WithContext(ctx).Exec(
+				`UPDATE orders
+				SET delivery_pin_failed_attempts = 0, delivery_pin_locked_until = NULL
+				WHERE document_id = ?`,
+				o.DocID(ctx).String()).Error
+		}
+
+		// El incremento se hace en la misma sentencia para no perder intentos concurrentes.
+		// delivery_pin_locked_until se asigna primero porque TiDB evalúa las asignaciones en
+		// orden y vería el contador ya incrementado
+		return conn.DB.WithContext(ctx).Exec(
+			`UPDATE orders
+			SET delivery_pin_locked_until = CASE
+					WHEN COALESCE(delivery_pin_failed_attempts, 0) + 1 >= ? THEN ?
+					ELSE delivery_pin_locked_until
+				END,
+				delivery_pin_failed_attempts = COALESCE(delivery_pin_failed_attempts, 0) + 1
+			WHERE document_id = ?`,
+			domain.MaxDeliveryPinAttempts,
+			now.Add(domain.DeliveryPinLockoutDuration),
+			o.DocID(ctx).String()).Error
+	}
+}
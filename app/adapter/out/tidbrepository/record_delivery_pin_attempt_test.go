@@ -0,0 +1,55 @@
+package tidbrepository
+
+import (
+	"context"
+	"time"
+
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecordDeliveryPinAttempt", func() {
+	var (
+		ctx    context.Context
+		record RecordDeliveryPinAttempt
+		find   FindOrderDeliveryPins
+		order  domain.Order
+	)
+
+	BeforeEach(func() {
+		var err error
+		_, ctx, err = CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+		record = NewRecordDeliveryPinAttempt(connection)
+		find = NewFindOrderDeliveryPins(connection)
+
+		order = domain.Order{ReferenceID: "ORDER-PIN-ATTEMPTS"}
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+	})
+
+	It("should lock the order after the maximum failed attempts and reset on success", func() {
+		now := time.Now().UTC().Truncate(time.Second)
+		for i := 0; i < domain.MaxDeliveryPinAttempts-1; i++ {
+			Expect(record(ctx, order, false, now)).To(Succeed())
+		}
+
+		pins, err := find(ctx, []domain.Order{order})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pins[order.DocID(ctx)].Attempts.Failed).To(Equal(domain.MaxDeliveryPinAttempts - 1))
+		Expect(pins[order.DocID(ctx)].Attempts.IsLocked(now)).To(BeFalse())
+
+		Expect(record(ctx, order, false, now)).To(Succeed())
+		pins, err = find(ctx, []domain.Order{order})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pins[order.DocID(ctx)].Attempts.IsLocked(now)).To(BeTrue())
+		Expect(pins[order.DocID(ctx)].Attempts.IsLocked(now.Add(domain.DeliveryPinLockoutDuration))).To(BeFalse())
+
+		Expect(record(ctx, order, true, now)).To(Succeed())
+		pins, err = find(ctx, []domain.Order{order})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pins[order.DocID(ctx)].Attempts.Failed).To(BeZero())
+		Expect(pins[order.DocID(ctx)].Attempts.LockedUntil).To(BeNil())
+	})
+})
@@ -0,0 +1,57 @@
+package tidbrepository
+
+import (
+	"errors"
+	"transport-app/app/domain"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+// relayPageSize retorna el tamaño de página pedido y si se recorre hacia atrás; sin first ni last
+// se devuelve la primera página de domain.DefaultPageSize
+func relayPageSize(pagination domain.Pagination) (int, bool) {
+	if pagination.IsBackward() {
+		return *pagination.Last, true
+	}
+	if pagination.IsForward() {
+		return *pagination.First, false
+	}
+	return domain.DefaultPageSize, false
+}
+
+// applyRelayPagination ordena por la columna id y pide un registro extra para saber si hay más resultados
+func applyRelayPagination(ds *goqu.SelectDataset, idColumn string, pagination domain.Pagination) (*goqu.SelectDataset, error) {
+	pageSize, backward := relayPageSize(pagination)
+	if pageSize < 0 {
+		return nil, errors.New("invalid pagination: page size must not be negative")
+	}
+
+	if backward {
+		beforeID, err := pagination.BeforeID()
+		if err != nil {
+			return nil, err
+		}
+		if beforeID != nil {
+			ds = ds.Where(goqu.I(idColumn).Lt(*beforeID))
+		}
+		return ds.Order(goqu.I(idColumn).Desc()).Limit(uint(pageSize + 1)), nil
+	}
+
+	afterID, err := pagination.AfterID()
+	if err != nil {
+		return nil, err
+	}
+	if afterID != nil {
+		ds = ds.Where(goqu.I(idColumn).Gt(*afterID))
+	}
+	return ds.Order(goqu.I(idColumn).Asc()).Limit(uint(pageSize + 1)), nil
+}
+
+// trimRelayPage descarta el registro extra pedido por applyRelayPagination e indica si existía
+func trimRelayPage[T any](results []T, pagination domain.Pagination) ([]T, bool) {
+	pageSize, _ := relayPageSize(pagination)
+	if pageSize >= 0 && len(results) > pageSize {
+		return results[:pageSize], true
+	}
+	return results, false
+}
@@ -0,0 +1,44 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm"
+)
+
+// SaveOrderDeliveryPin guarda el hash del PIN de entrega generado para la orden
+type SaveOrderDeliveryPin func(ctx context.Context, o domain.Order, pinHash string, fsmState ...domain.FSMState) error
+
+func init() {
+	ioc.Registry(
+		NewSaveOrderDeliveryPin,
+		database.NewConnectionFactory,
+		NewSaveFSMTransition)
+}
+
+func NewSaveOrderDeliveryPin(conn database.ConnectionFactory, saveFSMTransition SaveFSMTransition) SaveOrderDeliveryPin {
+	return func(ctx context.Context, o domain.Order, pinHash string, fsmState ...domain.FSMState) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			result := tx.WithContext(ctx).
+				Model(&table.Order{}).
+				Where("document_id = ?", o.DocID(ctx)).
+				Update("delivery_pin_hash", pinHash)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrOrderNotFound
+			}
+
+			// Persistir FSMState si está presente
+			if len(fsmState) > 0 && saveFSMTransition != nil {
+				return saveFSMTransition(ctx, fsmState[0], tx)
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SaveOrderDeliveryPin", func() {
+	var (
+		ctx  context.Context
+		save SaveOrderDeliveryPin
+	)
+
+	BeforeEach(func() {
+		var err error
+		_, ctx, err = CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+		save = NewSaveOrderDeliveryPin(connection, nil)
+	})
+
+	It("should store the pin hash and keep it when the order is upserted again", func() {
+		order := domain.Order{ReferenceID: "ORDER-PIN-001"}
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+
+		pinHash := order.DeliveryPinHash(ctx, []byte("test-delivery-pin-key"), "123456")
+		Expect(save(ctx, order, pinHash)).To(Succeed())
+
+		order.DeliveryInstructions = "Dejar en conserjería"
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+
+		var dbOrder table.Order
+		err := connection.DB.WithContext(ctx).
+			Table("orders").
+			Where("document_id = ?", order.DocID(ctx)).
+			First(&dbOrder).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbOrder.DeliveryPinHash).To(Equal(pinHash))
+	})
+
+	It("should fail when the order does not exist", func() {
+		err := save(ctx, domain.Order{ReferenceID: "ORDER-PIN-MISSING"}, "hash")
+		Expect(err).To(MatchError(ErrOrderNotFound))
+	})
+})
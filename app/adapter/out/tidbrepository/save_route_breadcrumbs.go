@@ -0,0 +1,41 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/adapter/out/tidbrepository/table/mapper"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm/clause"
+)
+
+// SaveRouteBreadcrumbs agrega los puntos al recorrido de cada ruta. Un punto cuya ventana de
+// tiempo ya fue registrada choca con el índice único de document_id y se ignora, por lo que
+// reprocesar un lote es idempotente
+type SaveRouteBreadcrumbs func(context.Context, []domain.RouteBreadcrumb) error
+
+func init() {
+	ioc.Registry(NewSaveRouteBreadcrumbs, database.NewConnectionFactory)
+}
+
+func NewSaveRouteBreadcrumbs(conn database.ConnectionFactory) SaveRouteBreadcrumbs {
+	return func(ctx context.Context, breadcrumbs []domain.RouteBreadcrumb) error {
+		if len(breadcrumbs) == 0 {
+			return nil
+		}
+
+		records := make([]table.RouteBreadcrumb, 0, len(breadcrumbs))
+		for _, breadcrumb := range breadcrumbs {
+			records = append(records, mapper.MapRouteBreadcrumbTable(ctx, breadcrumb))
+		}
+		return conn.WithContext(ctx).
+			Omit("Tenant").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "document_id"}},
+				DoNothing: true,
+			}).
+			Create(&records).Error
+	}
+}
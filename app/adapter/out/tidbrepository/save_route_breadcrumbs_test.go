@@ -0,0 +1,56 @@
+package tidbrepository
+
+import (
+	"context"
+	"time"
+
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+
+	"github.com/paulmach/orb"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SaveRouteBreadcrumbs", func() {
+	start := time.Date(2025, 6, 6, 14, 30, 0, 0, time.UTC)
+	ping := func(offset time.Duration) domain.DriverLocation {
+		return domain.DriverLocation{
+			RouteReferenceID: "ROUTE-BREADCRUMB",
+			VehiclePlate:     "ABC123",
+			Driver:           domain.Driver{NationalID: "1234567890"},
+			Point:            orb.Point{-70.6130425, -33.5147889},
+			RecordedAt:       start.Add(offset),
+			SpeedKmh:         35,
+		}
+	}
+
+	It("should store one breadcrumb per window and ignore reprocessed batches", func() {
+		tenant, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+
+		breadcrumbs := domain.DownsampleDriverLocations([]domain.DriverLocation{
+			ping(5 * time.Second),
+			ping(20 * time.Second),
+			ping(35 * time.Second),
+		}, 30*time.Second)
+
+		save := NewSaveRouteBreadcrumbs(connection)
+		Expect(save(ctx, breadcrumbs)).To(Succeed())
+		Expect(save(ctx, breadcrumbs)).To(Succeed())
+
+		var stored []table.RouteBreadcrumb
+		err = connection.DB.WithContext(ctx).
+			Where("tenant_id = ?", tenant.ID).
+			Order("recorded_at").
+			Find(&stored).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored).To(HaveLen(2))
+		Expect(stored[0].RouteReferenceID).To(Equal("ROUTE-BREADCRUMB"))
+		Expect(stored[0].RouteDoc).To(Equal(domain.Route{ReferenceID: "ROUTE-BREADCRUMB"}.DocID(ctx).String()))
+		Expect(stored[0].Latitude).To(Equal(-33.5147889))
+		Expect(stored[0].RecordedAt.UTC()).To(Equal(start.Add(5 * time.Second)))
+		Expect(stored[1].RecordedAt.UTC()).To(Equal(start.Add(35 * time.Second)))
+	})
+})
@@ -0,0 +1,34 @@
+package table
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type ItemConfirmation struct {
+	Sku               string `json:"sku"`
+	DeliveredQuantity int    `json:"delivered_quantity"`
+	RejectedQuantity  int    `json:"rejected_quantity"`
+	ReasonCode        string `json:"reason_code"`
+}
+
+type JSONItemConfirmations []ItemConfirmation
+
+// Implementamos los métodos necesarios para el manejo de JSON
+func (j *JSONItemConfirmations) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal JSONItemConfirmations value: %v", value)
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+// Value implementa la interfaz driver.Valuer para convertir la estructura en JSON
+func (j JSONItemConfirmations) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
@@ -0,0 +1,40 @@
+package table
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"transport-app/app/domain"
+)
+
+type UnassignedOrder struct {
+	ReferenceID string `json:"reference_id"`
+	Reason      string `json:"reason"`
+}
+
+type JSONUnassignedOrders []UnassignedOrder
+
+func (j JSONUnassignedOrders) Map() []domain.Order {
+	orders := make([]domain.Order, len(j))
+	for i, o := range j {
+		orders[i] = domain.Order{
+			ReferenceID:      domain.ReferenceID(o.ReferenceID),
+			UnassignedReason: o.Reason,
+		}
+	}
+	return orders
+}
+
+// Scan implementa la interfaz sql.Scanner para convertir datos JSON desde la base de datos
+func (j *JSONUnassignedOrders) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal JSONUnassignedOrders value: %v", value)
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+// Value implementa la interfaz driver.Valuer para convertir la estructura en JSON al guardar en la base de datos
+func (j JSONUnassignedOrders) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
@@ -3,6 +3,7 @@ package mapper
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 	"transport-app/app/adapter/out/tidbrepository/table"
 	"transport-app/app/domain"
@@ -15,6 +16,37 @@ func MapDeliveryUnitsHistoryTable(ctx context.Context, p domain.Plan) []table.De
 	for _, route := range p.Routes {
 		for _, order := range route.Orders {
 			for _, pkg := range order.DeliveryUnits {
+				docInputs := []string{
+					string(order.DocID(ctx)),
+					string(pkg.DocID(ctx)),
+					string(route.DocID(ctx)),
+					string(p.DocID(ctx)),
+					string(pkg.Status.DocID()),
+					string(pkg.ConfirmDelivery.NonDeliveryReason.DocID(ctx)),
+					pkg.ConfirmDelivery.Recipient.FullName,
+					pkg.ConfirmDelivery.Recipient.NationalID,
+					pkg.ConfirmDelivery.EvidencePhotos.DocID(ctx).String(),
+					pkg.ConfirmDelivery.HandledAt.Format(time.RFC3339),
+					fmt.Sprintf("%f", pkg.ConfirmDelivery.Latitude),
+					fmt.Sprintf("%f", pkg.ConfirmDelivery.Longitude),
+					pkg.ConfirmDelivery.ManualChange.PerformedBy,
+					pkg.ConfirmDelivery.ManualChange.Reason,
+				}
+				var itemConfirmations table.JSONItemConfirmations
+				// El detalle por item solo participa del hash cuando existe, para no alterar
+				// el DocumentID de los registros de entregas completas ya persistidos
+				if len(pkg.ConfirmDelivery.Items) > 0 {
+					docInputs = append(docInputs, pkg.ConfirmDelivery.Items.DocID(ctx).String())
+					itemConfirmations = MapItemConfirmationsTable(pkg.ConfirmDelivery.Items)
+				}
+				// Igual que el detalle por item, la verificación de PIN solo participa cuando se exigió
+				pinVerification := pkg.ConfirmDelivery.PinVerification
+				if pinVerification.Required {
+					docInputs = append(docInputs, "delivery_pin", strconv.FormatBool(pinVerification.Verified))
+				}
+				if distance := pkg.ConfirmDelivery.DistanceToDestinationMeters; distance != nil {
+					docInputs = append(docInputs, "distance_to_destination", fmt.Sprintf("%f", *distance))
+				}
 				deliveryUnitsHistory = append(deliveryUnitsHistory, table.DeliveryUnitsStatusHistory{
 					OrderDoc:                     string(order.DocID(ctx)),
 					TenantID:                     sharedcontext.TenantIDFromContext(ctx),
@@ -28,28 +60,18 @@ func MapDeliveryUnitsHistoryTable(ctx context.Context, p domain.Plan) []table.De
 					RecipientFullName:            pkg.ConfirmDelivery.Recipient.FullName,
 					RecipientNationalID:          pkg.ConfirmDelivery.Recipient.NationalID,
 					EvidencePhotos:               MapEvidencePhotosTable(ctx, pkg.ConfirmDelivery.EvidencePhotos),
+					ItemConfirmations:            itemConfirmations,
+					DeliveryPinPolicy:            pinVerification.Policy,
+					DeliveryPinRequired:          pinVerification.Required,
+					DeliveryPinVerified:          pinVerification.Verified,
+					DistanceToDestinationMeters:  pkg.ConfirmDelivery.DistanceToDestinationMeters,
+					SuspiciousLocation:           pkg.ConfirmDelivery.SuspiciousLocation,
 					ConfirmDeliveryHandledAt:     pkg.ConfirmDelivery.HandledAt,
 					ConfirmDeliveryLatitude:      pkg.ConfirmDelivery.Latitude,
 					ConfirmDeliveryLongitude:     pkg.ConfirmDelivery.Longitude,
 					ManualChangePerformedBy:      pkg.ConfirmDelivery.ManualChange.PerformedBy,
 					ManualChangeReason:           pkg.ConfirmDelivery.ManualChange.Reason,
-					DocumentID: domain.HashByTenant(
-						ctx,
-						string(order.DocID(ctx)),
-						string(pkg.DocID(ctx)),
-						string(route.DocID(ctx)),
-						string(p.DocID(ctx)),
-						string(pkg.Status.DocID()),
-						string(pkg.ConfirmDelivery.NonDeliveryReason.DocID(ctx)),
-						pkg.ConfirmDelivery.Recipient.FullName,
-						pkg.ConfirmDelivery.Recipient.NationalID,
-						pkg.ConfirmDelivery.EvidencePhotos.DocID(ctx).String(),
-						pkg.ConfirmDelivery.HandledAt.Format(time.RFC3339),
-						fmt.Sprintf("%f", pkg.ConfirmDelivery.Latitude),
-						fmt.Sprintf("%f", pkg.ConfirmDelivery.Longitude),
-						pkg.ConfirmDelivery.ManualChange.PerformedBy,
-						pkg.ConfirmDelivery.ManualChange.Reason,
-					).String(),
+					DocumentID:                   domain.HashByTenant(ctx, docInputs...).String(),
 				})
 			}
 		}
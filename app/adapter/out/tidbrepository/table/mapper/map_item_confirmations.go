@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+)
+
+func MapItemConfirmationsTable(items domain.ItemConfirmations) table.JSONItemConfirmations {
+	result := make(table.JSONItemConfirmations, len(items))
+	for i, item := range items {
+		result[i] = table.ItemConfirmation{
+			Sku:               item.Sku,
+			DeliveredQuantity: item.DeliveredQuantity,
+			RejectedQuantity:  item.RejectedQuantity,
+			ReasonCode:        item.ReasonCode,
+		}
+	}
+	return result
+}
@@ -8,11 +8,19 @@ import (
 )
 
 func MapPlanToTable(ctx context.Context, p domain.Plan) table.Plan {
+	var unassignedOrders table.JSONUnassignedOrders
+	for _, o := range p.UnassignedOrders {
+		unassignedOrders = append(unassignedOrders, table.UnassignedOrder{
+			ReferenceID: o.ReferenceID.String(),
+			Reason:      o.UnassignedReason,
+		})
+	}
 	return table.Plan{
-		ReferenceID:    p.ReferenceID,
-		DocumentID:     string(p.DocID(ctx)),
-		TenantID:       sharedcontext.TenantIDFromContext(ctx),
-		PlannedDate:    p.PlannedDate,
-		PlanHeadersDoc: p.Headers.DocID(ctx).String(),
+		ReferenceID:      p.ReferenceID,
+		DocumentID:       string(p.DocID(ctx)),
+		TenantID:         sharedcontext.TenantIDFromContext(ctx),
+		PlannedDate:      p.PlannedDate,
+		PlanHeadersDoc:   p.Headers.DocID(ctx).String(),
+		UnassignedOrders: unassignedOrders,
 	}
 }
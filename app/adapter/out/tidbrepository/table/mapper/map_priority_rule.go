@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+)
+
+func MapPriorityRuleTable(ctx context.Context, r domain.PriorityRule) table.PriorityRule {
+	return table.PriorityRule{
+		ServiceCategory:       r.ServiceCategory,
+		MaxDaysToPromisedDate: r.MaxDaysToPromisedDate,
+		Priority:              r.Priority,
+		DocumentID:            string(r.DocID(ctx)),
+		TenantID:              sharedcontext.TenantIDFromContext(ctx),
+	}
+}
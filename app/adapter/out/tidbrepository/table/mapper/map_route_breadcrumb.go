@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+)
+
+func MapRouteBreadcrumbTable(ctx context.Context, b domain.RouteBreadcrumb) table.RouteBreadcrumb {
+	var driverDoc string
+	if b.Driver.NationalID != "" {
+		driverDoc = b.Driver.DocID(ctx).String()
+	}
+	return table.RouteBreadcrumb{
+		DocumentID:       b.DocID(ctx).String(),
+		TenantID:         sharedcontext.TenantIDFromContext(ctx),
+		RouteDoc:         domain.Route{ReferenceID: b.RouteReferenceID}.DocID(ctx).String(),
+		RouteReferenceID: b.RouteReferenceID,
+		VehiclePlate:     b.VehiclePlate,
+		DriverDoc:        driverDoc,
+		Latitude:         b.Point.Lat(),
+		Longitude:        b.Point.Lon(),
+		SpeedKmh:         b.SpeedKmh,
+		AccuracyMeters:   b.AccuracyMeters,
+		RecordedAt:       b.RecordedAt,
+	}
+}
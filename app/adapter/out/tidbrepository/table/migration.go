@@ -63,6 +63,8 @@ func NewRunMigrations(
 			&ClientCredential{},
 			&FSMStateHistory{},
 			&Webhook{},
+			&PriorityRule{},
+			&RouteBreadcrumb{},
 		}
 
 		// Crear las tablas nuevamente
@@ -51,6 +51,17 @@ type Order struct {
 	DestinationAddressInfoDoc string      `gorm:"type:char(64);index"`
 	DestinationAddressInfo    AddressInfo `gorm:"-"`
 
+	// Última corrección manual del destino
+	DestinationFixedBy   string `gorm:"default:null"`
+	DestinationFixReason string `gorm:"default:null"`
+
+	// Hash del PIN de entrega enviado al destinatario
+	DeliveryPinHash string `gorm:"type:char(64);default:null"`
+
+	// Intentos fallidos de PIN y bloqueo de la verificación
+	DeliveryPinFailedAttempts int        `gorm:"default:0"`
+	DeliveryPinLockedUntil    *time.Time `gorm:"default:null"`
+
 	// Nodo de Origen de la orden (en caso de que tenga)
 	OriginNodeInfoDoc string   `gorm:"type:char(64);index"`
 	OriginNodeInfo    NodeInfo `gorm:"-"`
@@ -15,11 +15,14 @@ type OrderType struct {
 	TenantID    uuid.UUID `gorm:"not null;"`
 	Tenant      Tenant    `gorm:"foreignKey:TenantID"`
 	Description string    `gorm:"type:text"`
+	// Política de PIN de entrega de las órdenes de este tipo
+	DeliveryPinPolicy string `gorm:"default:''"`
 }
 
 func (o OrderType) Map() domain.OrderType {
 	return domain.OrderType{
-		Type:        o.Type,
-		Description: o.Description,
+		Type:              o.Type,
+		Description:       o.Description,
+		DeliveryPinPolicy: o.DeliveryPinPolicy,
 	}
 }
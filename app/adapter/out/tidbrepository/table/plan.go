@@ -18,11 +18,14 @@ type Plan struct {
 	TenantID       uuid.UUID   `gorm:"not null"`
 	Tenant         Tenant      `gorm:"foreignKey:TenantID"`
 	PlannedDate    time.Time   `gorm:"default:null"`
+	// Órdenes que la optimización no pudo asignar, con su motivo
+	UnassignedOrders JSONUnassignedOrders `gorm:"type:json;default:null"`
 }
 
 func (p Plan) Map() domain.Plan {
 	return domain.Plan{
-		ReferenceID: p.ReferenceID,
-		PlannedDate: p.PlannedDate,
+		ReferenceID:      p.ReferenceID,
+		PlannedDate:      p.PlannedDate,
+		UnassignedOrders: p.UnassignedOrders.Map(),
 	}
 }
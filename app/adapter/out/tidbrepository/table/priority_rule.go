@@ -0,0 +1,27 @@
+package table
+
+import (
+	"transport-app/app/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PriorityRule struct {
+	gorm.Model
+	ID                    int64     `gorm:"primaryKey"`
+	DocumentID            string    `gorm:"type:char(64);uniqueIndex"`
+	TenantID              uuid.UUID `gorm:"not null;index"`
+	Tenant                Tenant    `gorm:"foreignKey:TenantID"`
+	ServiceCategory       string
+	MaxDaysToPromisedDate *int `gorm:"default:null"`
+	Priority              int  `gorm:"not null"`
+}
+
+func (t PriorityRule) Map() domain.PriorityRule {
+	return domain.PriorityRule{
+		ServiceCategory:       t.ServiceCategory,
+		MaxDaysToPromisedDate: t.MaxDaysToPromisedDate,
+		Priority:              t.Priority,
+	}
+}
@@ -29,6 +29,9 @@ type Route struct {
 	Driver            Driver               `gorm:"-"`
 	CarrierDoc        string               `gorm:"type:char(64);index"`
 	Carrier           Carrier              `gorm:"-"`
+	// NeedsReoptimization se activa cuando se corrige el destino de una orden de la ruta
+	// y se limpia al volver a guardar la ruta reoptimizada
+	NeedsReoptimization bool `gorm:"default:false"`
 }
 
 func (r Route) Map() domain.Route {
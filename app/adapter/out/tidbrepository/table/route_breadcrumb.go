@@ -0,0 +1,25 @@
+package table
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RouteBreadcrumb struct {
+	gorm.Model
+	ID               int64     `gorm:"primaryKey"`
+	DocumentID       string    `gorm:"type:char(64);uniqueIndex"`
+	TenantID         uuid.UUID `gorm:"not null;index"`
+	Tenant           Tenant    `gorm:"foreignKey:TenantID"`
+	RouteDoc         string    `gorm:"type:char(64);index"`
+	RouteReferenceID string    `gorm:"type:varchar(255);not null"`
+	VehiclePlate     string    `gorm:"type:varchar(50)"`
+	DriverDoc        string    `gorm:"type:char(64);default:null"`
+	Latitude         float64   `gorm:"not null"`
+	Longitude        float64   `gorm:"not null"`
+	SpeedKmh         float64   `gorm:"default:0"`
+	AccuracyMeters   float64   `gorm:"default:0"`
+	RecordedAt       time.Time `gorm:"not null;index"`
+}
@@ -13,6 +13,12 @@ type Tenant struct {
 	ID      uuid.UUID `gorm:"type:char(36);primaryKey"`
 	Name    string    `gorm:"type:varchar(255);not null;"`
 	Country string    `gorm:"type:varchar(255);not null;"`
+	// Radio del geocerco de entrega; cero usa el valor por defecto de la plataforma
+	DeliveryGeofenceRadiusMeters float64 `gorm:"default:0"`
+	// Política de PIN de entrega por defecto; el tipo de orden puede sobrescribirla
+	DeliveryPinPolicy string `gorm:"default:''"`
+	// Mapeo clave canónica -> cabecera guardado para POST /orders/import
+	OrderImportMapping JSONB `gorm:"type:jsonb;default:null"`
 }
 
 func (o Tenant) Map() domain.Tenant {
@@ -20,5 +26,8 @@ func (o Tenant) Map() domain.Tenant {
 		ID:      o.ID,
 		Name:    o.Name,
 		Country: countries.ByName(o.Country),
+
+		DeliveryGeofenceRadiusMeters: o.DeliveryGeofenceRadiusMeters,
+		DeliveryPinPolicy:            o.DeliveryPinPolicy,
 	}
 }
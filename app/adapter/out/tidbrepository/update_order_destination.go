@@ -0,0 +1,56 @@
+package tidbrepository
+
+import (
+	"context"
+	"fmt"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm"
+)
+
+// UpdateOrderDestination apunta las órdenes a la nueva versión de su dirección de destino,
+// registrando quién hizo la corrección y por qué. Las órdenes se actualizan en una sola
+// transacción: si alguna no existe, ninguna queda corregida. Las rutas que ya contienen
+// alguna de estas órdenes quedan marcadas para reoptimizar en la misma transacción
+type UpdateOrderDestination func(context.Context, []domain.Order, domain.ManualChange) error
+
+func init() {
+	ioc.Registry(NewUpdateOrderDestination, database.NewConnectionFactory)
+}
+
+func NewUpdateOrderDestination(conn database.ConnectionFactory) UpdateOrderDestination {
+	return func(ctx context.Context, orders []domain.Order, change domain.ManualChange) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			orderDocs := make([]string, 0, len(orders))
+			for _, o := range orders {
+				result := tx.WithContext(ctx).
+					Model(&table.Order{}).
+					Where("document_id = ?", o.DocID(ctx)).
+					Updates(map[string]any{
+						"destination_address_info_doc": o.Destination.AddressInfo.DocID(ctx).String(),
+						"destination_fixed_by":         change.PerformedBy,
+						"destination_fix_reason":       change.Reason,
+					})
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					return fmt.Errorf("%w: %s", ErrOrderNotFound, o.ReferenceID)
+				}
+				orderDocs = append(orderDocs, o.DocID(ctx).String())
+			}
+
+			return tx.WithContext(ctx).Exec(
+				`UPDATE routes
+				SET needs_reoptimization = true
+				WHERE document_id IN (
+					SELECT route_doc FROM delivery_units_status_histories
+					WHERE order_doc IN ? AND route_doc <> ''
+				)`,
+				orderDocs).Error
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/paulmach/orb"
+)
+
+var _ = Describe("UpdateOrderDestination", func() {
+	var (
+		ctx    context.Context
+		tenant domain.Tenant
+		update UpdateOrderDestination
+	)
+
+	BeforeEach(func() {
+		var err error
+		tenant, ctx, err = CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+		update = NewUpdateOrderDestination(connection)
+	})
+
+	It("should point the order to the corrected destination and record the change", func() {
+		order := domain.Order{
+			ReferenceID: "ORDER-FIX-001",
+			Destination: domain.NodeInfo{
+				AddressInfo: domain.AddressInfo{
+					AddressLine1: "direccion original 123",
+					Coordinates:  domain.Coordinates{Point: orb.Point{-70.60, -33.45}},
+				},
+			},
+		}
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+
+		order.Destination.AddressInfo = domain.AddressInfo{
+			AddressLine1: "inglaterra 59",
+			Coordinates:  domain.Coordinates{Point: orb.Point{-70.6130425, -33.5147889}},
+		}
+		err := update(ctx, []domain.Order{order}, domain.ManualChange{
+			PerformedBy: "juan@example.com",
+			Reason:      "PROVIDER_RESULT_OUT_OF_DISTRICT",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		var dbOrder table.Order
+		err = connection.DB.WithContext(ctx).
+			Table("orders").
+			Where("document_id = ?", order.DocID(ctx)).
+			First(&dbOrder).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbOrder.DestinationAddressInfoDoc).To(Equal(order.Destination.AddressInfo.DocID(ctx).String()))
+		Expect(dbOrder.DestinationFixedBy).To(Equal("juan@example.com"))
+		Expect(dbOrder.DestinationFixReason).To(Equal("PROVIDER_RESULT_OUT_OF_DISTRICT"))
+	})
+
+	It("should leave every order untouched when one of them does not exist", func() {
+		order := domain.Order{ReferenceID: "ORDER-FIX-002"}
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+		original := order.Destination.AddressInfo.DocID(ctx).String()
+
+		order.Destination.AddressInfo = domain.AddressInfo{AddressLine1: "inglaterra 59"}
+		err := update(ctx, []domain.Order{order, {ReferenceID: "ORDER-MISSING"}}, domain.ManualChange{PerformedBy: "juan@example.com"})
+		Expect(err).To(MatchError(ErrOrderNotFound))
+
+		var dbOrder table.Order
+		err = connection.DB.WithContext(ctx).
+			Table("orders").
+			Where("document_id = ?", order.DocID(ctx)).
+			First(&dbOrder).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbOrder.DestinationAddressInfoDoc).To(Equal(original))
+		Expect(dbOrder.DestinationFixedBy).To(BeEmpty())
+	})
+
+	It("should flag the routes containing the fixed orders for reoptimization", func() {
+		order := domain.Order{ReferenceID: "ORDER-FIX-003"}
+		Expect(NewUpsertOrder(connection, nil)(ctx, order)).To(Succeed())
+
+		route := domain.Route{ReferenceID: "ROUTE-FIX-003"}
+		Expect(NewUpsertRoute(connection, nil)(ctx, route, nil, "")).To(Succeed())
+		untouched := domain.Route{ReferenceID: "ROUTE-FIX-004"}
+		Expect(NewUpsertRoute(connection, nil)(ctx, untouched, nil, "")).To(Succeed())
+
+		Expect(connection.DB.WithContext(ctx).Create(&table.DeliveryUnitsStatusHistory{
+			TenantID:   tenant.ID,
+			DocumentID: "history-fix-003",
+			OrderDoc:   order.DocID(ctx).String(),
+			RouteDoc:   route.DocID(ctx).String(),
+		}).Error).To(Succeed())
+
+		order.Destination.AddressInfo = domain.AddressInfo{AddressLine1: "inglaterra 59"}
+		Expect(update(ctx, []domain.Order{order}, domain.ManualChange{PerformedBy: "juan@example.com"})).To(Succeed())
+
+		var flagged, other table.Route
+		Expect(connection.DB.WithContext(ctx).
+			Where("document_id = ?", route.DocID(ctx).String()).
+			First(&flagged).Error).To(Succeed())
+		Expect(flagged.NeedsReoptimization).To(BeTrue())
+		Expect(connection.DB.WithContext(ctx).
+			Where("document_id = ?", untouched.DocID(ctx).String()).
+			First(&other).Error).To(Succeed())
+		Expect(other.NeedsReoptimization).To(BeFalse())
+	})
+})
@@ -0,0 +1,34 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// UpdateTenantDeliveryGeofence configura el radio del geocerco de entrega del tenant del contexto
+type UpdateTenantDeliveryGeofence func(context.Context, domain.DeliveryGeofence) error
+
+func init() {
+	ioc.Registry(NewUpdateTenantDeliveryGeofence, database.NewConnectionFactory)
+}
+
+func NewUpdateTenantDeliveryGeofence(conn database.ConnectionFactory) UpdateTenantDeliveryGeofence {
+	return func(ctx context.Context, geofence domain.DeliveryGeofence) error {
+		result := conn.DB.WithContext(ctx).
+			Model(&table.Tenant{}).
+			Where("id = ?", sharedcontext.TenantIDFromContext(ctx)).
+			Update("delivery_geofence_radius_meters", geofence.RadiusMeters)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTenantNotFound
+		}
+		return nil
+	}
+}
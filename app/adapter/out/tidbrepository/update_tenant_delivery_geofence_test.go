@@ -0,0 +1,33 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateTenantDeliveryGeofence", func() {
+	It("should store the radius read back by FindTenantDeliveryGeofence", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+
+		find := NewFindTenantDeliveryGeofence(connection)
+		geofence, err := find(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(geofence.RadiusMeters).To(BeZero())
+
+		Expect(NewUpdateTenantDeliveryGeofence(connection)(ctx, domain.DeliveryGeofence{RadiusMeters: 250})).To(Succeed())
+
+		geofence, err = find(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(geofence.RadiusMeters).To(Equal(250.0))
+	})
+
+	It("should fail for an unknown tenant", func() {
+		err := NewUpdateTenantDeliveryGeofence(connection)(context.Background(), domain.DeliveryGeofence{RadiusMeters: 250})
+		Expect(err).To(MatchError(ErrTenantNotFound))
+	})
+})
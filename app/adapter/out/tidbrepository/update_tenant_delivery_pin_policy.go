@@ -0,0 +1,34 @@
+package tidbrepository
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// UpdateTenantDeliveryPinPolicy configura la política de PIN de entrega por defecto del tenant
+// del contexto. Una política vacía deja la decisión a cada tipo de orden
+type UpdateTenantDeliveryPinPolicy func(ctx context.Context, policy string) error
+
+func init() {
+	ioc.Registry(NewUpdateTenantDeliveryPinPolicy, database.NewConnectionFactory)
+}
+
+func NewUpdateTenantDeliveryPinPolicy(conn database.ConnectionFactory) UpdateTenantDeliveryPinPolicy {
+	return func(ctx context.Context, policy string) error {
+		result := conn.DB.WithContext(ctx).
+			Model(&table.Tenant{}).
+			Where("id = ?", sharedcontext.TenantIDFromContext(ctx)).
+			Update("delivery_pin_policy", policy)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTenantNotFound
+		}
+		return nil
+	}
+}
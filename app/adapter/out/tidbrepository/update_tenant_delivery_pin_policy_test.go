@@ -0,0 +1,38 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateTenantDeliveryPinPolicy", func() {
+	It("should apply the tenant policy to order types without their own", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(NewUpdateTenantDeliveryPinPolicy(connection)(ctx, domain.DeliveryPinPolicyReject)).To(Succeed())
+
+		inherited := domain.Order{ReferenceID: "ORDER-PIN-TENANT-1", OrderType: domain.OrderType{Type: "INHERITED"}}
+		overridden := domain.Order{
+			ReferenceID: "ORDER-PIN-TENANT-2",
+			OrderType:   domain.OrderType{Type: "OVERRIDDEN", DeliveryPinPolicy: domain.DeliveryPinPolicyDisabled},
+		}
+		Expect(NewUpsertOrderType(connection, nil)(ctx, inherited.OrderType)).To(Succeed())
+		Expect(NewUpsertOrderType(connection, nil)(ctx, overridden.OrderType)).To(Succeed())
+		Expect(NewUpsertOrder(connection, nil)(ctx, inherited)).To(Succeed())
+		Expect(NewUpsertOrder(connection, nil)(ctx, overridden)).To(Succeed())
+
+		pins, err := NewFindOrderDeliveryPins(connection)(ctx, []domain.Order{inherited, overridden})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pins[inherited.DocID(ctx)].OrderType.DeliveryPinPolicy).To(Equal(domain.DeliveryPinPolicyReject))
+		Expect(pins[overridden.DocID(ctx)].OrderType.DeliveryPinPolicy).To(Equal(domain.DeliveryPinPolicyDisabled))
+	})
+
+	It("should fail for an unknown tenant", func() {
+		err := NewUpdateTenantDeliveryPinPolicy(connection)(context.Background(), domain.DeliveryPinPolicyFlag)
+		Expect(err).To(MatchError(ErrTenantNotFound))
+	})
+})
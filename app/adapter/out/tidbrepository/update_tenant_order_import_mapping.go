@@ -0,0 +1,39 @@
+package tidbrepository
+
+import (
+	"context"
+	"encoding/json"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// UpdateTenantOrderImportMapping guarda el mapeo de columnas de importación de órdenes del tenant del contexto
+type UpdateTenantOrderImportMapping func(context.Context, domain.OrderImportMapping) error
+
+func init() {
+	ioc.Registry(NewUpdateTenantOrderImportMapping, database.NewConnectionFactory)
+}
+
+func NewUpdateTenantOrderImportMapping(conn database.ConnectionFactory) UpdateTenantOrderImportMapping {
+	return func(ctx context.Context, mapping domain.OrderImportMapping) error {
+		columns, err := json.Marshal(mapping.Columns)
+		if err != nil {
+			return err
+		}
+		result := conn.DB.WithContext(ctx).
+			Model(&table.Tenant{}).
+			Where("id = ?", sharedcontext.TenantIDFromContext(ctx)).
+			Update("order_import_mapping", table.JSONB(columns))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTenantNotFound
+		}
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package tidbrepository
+
+import (
+	"context"
+
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateTenantOrderImportMapping", func() {
+	It("should store the mapping read back by FindTenantOrderImportMapping", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+
+		find := NewFindTenantOrderImportMapping(connection)
+		mapping, err := find(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mapping.IsEmpty()).To(BeTrue())
+
+		saved := domain.OrderImportMapping{Columns: map[string]string{
+			"referenceID":       "Folio",
+			"deliveryUnits.lpn": "Bulto",
+		}}
+		Expect(NewUpdateTenantOrderImportMapping(connection)(ctx, saved)).To(Succeed())
+
+		mapping, err = find(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mapping.Columns).To(Equal(saved.Columns))
+	})
+
+	It("should fail for an unknown tenant", func() {
+		err := NewUpdateTenantOrderImportMapping(connection)(context.Background(), domain.OrderImportMapping{
+			Columns: map[string]string{"referenceID": "Folio"},
+		})
+		Expect(err).To(MatchError(ErrTenantNotFound))
+	})
+})
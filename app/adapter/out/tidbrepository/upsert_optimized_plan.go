@@ -0,0 +1,50 @@
+package tidbrepository
+
+import (
+	"context"
+	"fmt"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm"
+)
+
+// UpsertOptimizedPlan guarda el plan, sus rutas y la planificación de sus unidades en una sola
+// transacción; si alguna escritura falla no queda un plan parcial y el reintento parte de cero.
+// contracts acompaña a routes en el mismo orden
+type UpsertOptimizedPlan func(ctx context.Context, plan domain.Plan, routes []domain.Route, contracts []interface{}) error
+
+func init() {
+	ioc.Registry(NewUpsertOptimizedPlan, database.NewConnectionFactory)
+}
+
+func NewUpsertOptimizedPlan(conn database.ConnectionFactory) UpsertOptimizedPlan {
+	return func(ctx context.Context, plan domain.Plan, routes []domain.Route, contracts []interface{}) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			// Los repositorios abren su propia transacción; sobre tx quedan anidadas como savepoints
+			txConn := database.ConnectionFactory{DB: tx, Strategy: conn.Strategy}
+			saveFSMTransition := NewSaveFSMTransition(txConn)
+
+			if err := NewUpsertPlan(txConn, saveFSMTransition)(ctx, plan); err != nil {
+				return fmt.Errorf("failed to upsert plan: %w", err)
+			}
+
+			planDoc := plan.DocID(ctx).String()
+			upsertRoute := NewUpsertRoute(txConn, saveFSMTransition)
+			for i, route := range routes {
+				if err := upsertRoute(ctx, route, contracts[i], planDoc); err != nil {
+					return fmt.Errorf("failed to upsert route %s: %w", route.ReferenceID, err)
+				}
+			}
+
+			if len(plan.Routes) == 0 {
+				return nil
+			}
+			if err := NewUpsertDeliveryUnitsHistory(txConn, saveFSMTransition)(ctx, plan); err != nil {
+				return fmt.Errorf("failed to plan delivery units: %w", err)
+			}
+			return nil
+		})
+	}
+}
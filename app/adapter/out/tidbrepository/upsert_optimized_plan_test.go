@@ -0,0 +1,50 @@
+package tidbrepository
+
+import (
+	"context"
+	"time"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpsertOptimizedPlan", func() {
+	It("should persist the plan with every route linked to it", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), connection)
+		Expect(err).ToNot(HaveOccurred())
+
+		routes := []domain.Route{
+			{ReferenceID: "ROUTE-001", Vehicle: domain.Vehicle{Plate: "ABC123"}},
+			{ReferenceID: "UNASSIGNED-001"},
+		}
+		plan := domain.Plan{
+			ReferenceID: "PLAN-OPT-001",
+			PlannedDate: time.Now(),
+			Routes:      routes[:1],
+		}
+		contracts := []interface{}{
+			map[string]interface{}{"referenceID": "ROUTE-001"},
+			map[string]interface{}{"referenceID": "UNASSIGNED-001"},
+		}
+
+		err = NewUpsertOptimizedPlan(connection)(ctx, plan, routes, contracts)
+		Expect(err).ToNot(HaveOccurred())
+
+		var dbPlan table.Plan
+		err = connection.DB.WithContext(ctx).
+			Table("plans").
+			Where("document_id = ?", plan.DocID(ctx)).
+			First(&dbPlan).Error
+		Expect(err).ToNot(HaveOccurred())
+
+		var dbRoutes []table.Route
+		err = connection.DB.WithContext(ctx).
+			Table("routes").
+			Where("plan_doc = ?", plan.DocID(ctx).String()).
+			Find(&dbRoutes).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbRoutes).To(HaveLen(2))
+	})
+})
@@ -59,6 +59,9 @@ func NewUpsertOrder(conn database.ConnectionFactory, saveFSMTransition SaveFSMTr
 			DBOrderToUpdate.OriginAddressInfoDoc = order.OriginAddressInfoDoc
 			DBOrderToUpdate.DestinationAddressInfoDoc = order.DestinationAddressInfoDoc
 			DBOrderToUpdate.CreatedAt = order.CreatedAt
+			DBOrderToUpdate.DeliveryPinHash = order.DeliveryPinHash
+			DBOrderToUpdate.DeliveryPinFailedAttempts = order.DeliveryPinFailedAttempts
+			DBOrderToUpdate.DeliveryPinLockedUntil = order.DeliveryPinLockedUntil
 			//DBOrderToUpdate.RouteDoc = order.RouteDoc
 
 			// Actualizar IDs de documento si han cambiado
@@ -126,6 +126,32 @@ var _ = Describe("UpsertPlan", func() {
 		Expect(dbPlan2.TenantID.String()).To(Equal(tenant2.ID.String()))
 	})
 
+	It("should persist unassigned orders with their reasons", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		plan := domain.Plan{
+			ReferenceID: "PLAN-UNASSIGNED",
+			PlannedDate: time.Now(),
+			UnassignedOrders: []domain.Order{
+				{ReferenceID: "ORDER-1", UnassignedReason: "Vehicle capacity exceeded"},
+			},
+		}
+
+		err = upsert(ctx, plan)
+		Expect(err).ToNot(HaveOccurred())
+
+		var dbPlan table.Plan
+		err = conn.DB.WithContext(ctx).
+			Table("plans").
+			Where("document_id = ?", plan.DocID(ctx)).
+			First(&dbPlan).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbPlan.UnassignedOrders).To(Equal(table.JSONUnassignedOrders{
+			{ReferenceID: "ORDER-1", Reason: "Vehicle capacity exceeded"},
+		}))
+	})
+
 	It("should fail if database has no plans table", func() {
 		// Create a new tenant for this test
 		_, ctx, err := CreateTestTenant(context.Background(), conn)
@@ -0,0 +1,72 @@
+package tidbrepository
+
+import (
+	"context"
+	"errors"
+
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/adapter/out/tidbrepository/table/mapper"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"gorm.io/gorm"
+)
+
+// UpsertPriorityRules guarda las reglas en una sola transacción: si alguna falla, ninguna queda guardada
+type UpsertPriorityRules func(context.Context, domain.PriorityRules, ...domain.FSMState) error
+
+func init() {
+	ioc.Registry(
+		NewUpsertPriorityRules,
+		database.NewConnectionFactory,
+		NewSaveFSMTransition,
+	)
+}
+
+func NewUpsertPriorityRules(conn database.ConnectionFactory, saveFSMTransition SaveFSMTransition) UpsertPriorityRules {
+	return func(ctx context.Context, rules domain.PriorityRules, fsmState ...domain.FSMState) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			for _, rule := range rules {
+				if err := upsertPriorityRule(ctx, tx, rule); err != nil {
+					return err
+				}
+			}
+
+			// Persistir FSMState si está presente
+			if len(fsmState) > 0 && saveFSMTransition != nil {
+				return saveFSMTransition(ctx, fsmState[0], tx)
+			}
+			return nil
+		})
+	}
+}
+
+func upsertPriorityRule(ctx context.Context, tx *gorm.DB, rule domain.PriorityRule) error {
+	var existing table.PriorityRule
+
+	err := tx.WithContext(ctx).
+		Table("priority_rules").
+		Where("document_id = ?", rule.DocID(ctx)).
+		First(&existing).Error
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		newRecord := mapper.MapPriorityRuleTable(ctx, rule)
+		return tx.Omit("Tenant").Create(&newRecord).Error
+	}
+
+	updated, changed := existing.Map().UpdateIfChanged(rule)
+	if !changed {
+		return nil
+	}
+
+	updateData := mapper.MapPriorityRuleTable(ctx, updated)
+	updateData.ID = existing.ID
+	updateData.CreatedAt = existing.CreatedAt
+
+	return tx.Omit("Tenant").Save(&updateData).Error
+}
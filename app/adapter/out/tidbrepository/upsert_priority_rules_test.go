@@ -0,0 +1,126 @@
+package tidbrepository
+
+import (
+	"context"
+	"errors"
+	"transport-app/app/adapter/out/tidbrepository/table"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/database"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("UpsertPriorityRules", func() {
+	var (
+		conn database.ConnectionFactory
+	)
+
+	BeforeEach(func() {
+		conn = connection
+	})
+
+	It("should insert priority rule if not exists", func() {
+		tenant, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		rule := domain.PriorityRule{
+			ServiceCategory: "express",
+			Priority:        100,
+		}
+
+		upsert := NewUpsertPriorityRules(conn, nil)
+		err = upsert(ctx, domain.PriorityRules{rule})
+		Expect(err).ToNot(HaveOccurred())
+
+		var dbRule table.PriorityRule
+		err = conn.DB.WithContext(ctx).
+			Table("priority_rules").
+			Where("document_id = ?", rule.DocID(ctx)).
+			First(&dbRule).Error
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbRule.Priority).To(Equal(100))
+		Expect(dbRule.TenantID.String()).To(Equal(tenant.ID.String()))
+	})
+
+	It("should update the priority of an existing rule", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		upsert := NewUpsertPriorityRules(conn, nil)
+		err = upsert(ctx, domain.PriorityRules{{ServiceCategory: "same day", Priority: 60}})
+		Expect(err).ToNot(HaveOccurred())
+		err = upsert(ctx, domain.PriorityRules{{ServiceCategory: "SAME DAY", Priority: 80}})
+		Expect(err).ToNot(HaveOccurred())
+
+		rules, err := NewFindPriorityRules(conn)(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Priority).To(Equal(80))
+	})
+
+	It("should only find the rules of the current tenant", func() {
+		_, ctx1, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+		_, ctx2, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		upsert := NewUpsertPriorityRules(conn, nil)
+		err = upsert(ctx1, domain.PriorityRules{{ServiceCategory: "express", Priority: 100}})
+		Expect(err).ToNot(HaveOccurred())
+
+		rules, err := NewFindPriorityRules(conn)(ctx2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+	})
+
+	It("should save none of the rules when one of them fails", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		failingTransition := func(context.Context, domain.FSMState, ...*gorm.DB) error {
+			return errors.New("transition failed")
+		}
+		upsert := NewUpsertPriorityRules(conn, failingTransition)
+		err = upsert(ctx, domain.PriorityRules{
+			{ServiceCategory: "express", Priority: 100},
+			{ServiceCategory: "next day", Priority: 40},
+		}, domain.FSMState{State: "priority_rules_upserted"})
+		Expect(err).To(MatchError("transition failed"))
+
+		rules, err := NewFindPriorityRules(conn)(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+	})
+
+	It("should delete a rule and allow creating it again", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		rule := domain.PriorityRule{ServiceCategory: "express", Priority: 100}
+		upsert := NewUpsertPriorityRules(conn, nil)
+		Expect(upsert(ctx, domain.PriorityRules{rule})).To(Succeed())
+
+		remove := NewDeletePriorityRule(conn)
+		Expect(remove(ctx, rule)).To(Succeed())
+		Expect(remove(ctx, rule)).To(MatchError(ErrPriorityRuleNotFound))
+
+		rules, err := NewFindPriorityRules(conn)(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+
+		Expect(upsert(ctx, domain.PriorityRules{rule})).To(Succeed())
+	})
+
+	It("should fail if database has no priority_rules table", func() {
+		_, ctx, err := CreateTestTenant(context.Background(), conn)
+		Expect(err).ToNot(HaveOccurred())
+
+		upsert := NewUpsertPriorityRules(noTablesContainerConnection, nil)
+		err = upsert(ctx, domain.PriorityRules{{ServiceCategory: "express", Priority: 100}})
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("priority_rules"))
+	})
+})
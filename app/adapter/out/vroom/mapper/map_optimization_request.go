@@ -3,11 +3,15 @@ package mapper
 import (
 	"context"
 	"fmt"
+	"sort"
 	"transport-app/app/adapter/out/vroom/model"
 	"transport-app/app/domain"
 	"transport-app/app/domain/optimization"
 )
 
+// UnavailableBreakDescription identifica las pausas generadas por huecos entre ventanas del vehículo
+const UnavailableBreakDescription = "unavailable"
+
 type skillRegistry struct {
 	counter int64
 	mapping map[string]int64
@@ -123,9 +127,32 @@ func MapOptimizationRequest(ctx context.Context, req optimization.FleetOptimizat
 			vehicle.Skills = mapSkills(v.Skills, registry)
 		}
 
-		// Solo incluir TimeWindow si los valores son válidos
-		if v.TimeWindow.Start != "" && v.TimeWindow.End != "" {
-			vehicle.TimeWindow = parseTimeRange(v.TimeWindow.Start, v.TimeWindow.End)
+		// VROOM admite una sola ventana por vehículo: usar la envolvente de todas las ventanas
+		if windows := v.Windows(); len(windows) > 0 {
+			vehicle.TimeWindow = envelopeTimeWindow(windows)
+		}
+
+		// Pausas del conductor y huecos entre ventanas del vehículo
+		vehicle.Breaks = mapBreaks(v)
+
+		// Límites de la ruta: cero o negativo significa sin límite
+		if v.MaxTasks > 0 {
+			vehicle.MaxTasks = v.MaxTasks
+		}
+		if v.MaxTravelTime > 0 {
+			vehicle.MaxTravelTime = v.MaxTravelTime
+		}
+		if v.MaxDistance > 0 {
+			vehicle.MaxDistance = v.MaxDistance
+		}
+
+		// Solo incluir Costs si se configuró algún costo; si no VROOM usa la duración como costo
+		if !v.Costs.IsZero() {
+			vehicle.Costs = &model.VroomCosts{
+				Fixed:   v.Costs.Fixed,
+				PerHour: v.Costs.PerHour,
+				PerKm:   v.Costs.PerKm,
+			}
 		}
 
 		vehicles = append(vehicles, vehicle)
@@ -197,10 +224,10 @@ func MapOptimizationRequest(ctx context.Context, req optimization.FleetOptimizat
 				job.Service = visit.Delivery.ServiceTime
 			}
 
-			// Solo incluir TimeWindows si los valores son válidos
-			if visit.Delivery.TimeWindow.Start != "" && visit.Delivery.TimeWindow.End != "" {
-				job.TimeWindows = [][]int{parseTimeRange(visit.Delivery.TimeWindow.Start, visit.Delivery.TimeWindow.End)}
-			}
+			// Incluir todas las ventanas válidas de la entrega
+			job.TimeWindows = mapTimeWindows(visit.Delivery.Windows())
+
+			job.Priority = clampPriority(visit.Priority)
 
 			// Solo incluir CustomUserData si hay orders o información de contacto
 			customData := make(map[string]any)
@@ -234,10 +261,9 @@ func MapOptimizationRequest(ctx context.Context, req optimization.FleetOptimizat
 				},
 			}
 
-			// Solo incluir TimeWindows si los valores son válidos
-			if visit.Pickup.TimeWindow.Start != "" && visit.Pickup.TimeWindow.End != "" {
-				pickup.TimeWindows = [][]int{parseTimeRange(visit.Pickup.TimeWindow.Start, visit.Pickup.TimeWindow.End)}
-			}
+			// Incluir todas las ventanas válidas del retiro
+			pickup.TimeWindows = mapTimeWindows(visit.Pickup.Windows())
+			pickup.Service = visit.Pickup.ServiceTime
 
 			delivery := model.VroomStep{
 				ID: int(deliveryID),
@@ -247,15 +273,15 @@ func MapOptimizationRequest(ctx context.Context, req optimization.FleetOptimizat
 				},
 			}
 
-			// Solo incluir TimeWindows si los valores son válidos
-			if visit.Delivery.TimeWindow.Start != "" && visit.Delivery.TimeWindow.End != "" {
-				delivery.TimeWindows = [][]int{parseTimeRange(visit.Delivery.TimeWindow.Start, visit.Delivery.TimeWindow.End)}
-			}
+			// Incluir todas las ventanas válidas de la entrega
+			delivery.TimeWindows = mapTimeWindows(visit.Delivery.Windows())
+			delivery.Service = visit.Delivery.ServiceTime
 
 			shipment := model.VroomShipment{
 				ID:       shipmentCounter,
 				Pickup:   pickup,
 				Delivery: delivery,
+				Priority: clampPriority(visit.Priority),
 			}
 			shipmentCounter++
 
@@ -317,16 +343,100 @@ func MapOptimizationRequest(ctx context.Context, req optimization.FleetOptimizat
 	}, nil
 }
 
+// clampPriority ajusta la prioridad al rango 0-100 aceptado por VROOM
+func clampPriority(priority int) int {
+	if priority < domain.MinVisitPriority {
+		return domain.MinVisitPriority
+	}
+	if priority > domain.MaxVisitPriority {
+		return domain.MaxVisitPriority
+	}
+	return priority
+}
+
 func parseTimeRange(start, end string) []int {
 	// Convierte "08:00" a segundos desde medianoche
-	// Retorna [inicio, fin] en segundos
-	return []int{
-		toSeconds(start),
-		toSeconds(end),
+	// Retorna [inicio, fin] en segundos. Si el fin es anterior al inicio la ventana cruza
+	// la medianoche y termina al día siguiente.
+	startSeconds, endSeconds := ToSeconds(start), ToSeconds(end)
+	if endSeconds <= startSeconds {
+		endSeconds += 24 * 3600
 	}
+	return []int{startSeconds, endSeconds}
+}
+
+// mapTimeWindows convierte las ventanas a segundos desde medianoche ordenadas por inicio,
+// como exige VROOM para múltiples ventanas
+func mapTimeWindows(windows []optimization.TimeWindow) [][]int {
+	if len(windows) == 0 {
+		return nil
+	}
+	out := make([][]int, 0, len(windows))
+	for _, tw := range windows {
+		out = append(out, parseTimeRange(tw.Start, tw.End))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i][0] < out[j][0]
+	})
+	return out
+}
+
+// envelopeTimeWindow retorna [inicio más temprano, fin más tardío] de las ventanas
+func envelopeTimeWindow(windows []optimization.TimeWindow) []int {
+	mapped := mapTimeWindows(windows)
+	envelope := []int{mapped[0][0], mapped[0][1]}
+	for _, tw := range mapped[1:] {
+		if tw[1] > envelope[1] {
+			envelope[1] = tw[1]
+		}
+	}
+	return envelope
+}
+
+// mapBreaks convierte las pausas del vehículo a breaks de VROOM. Los huecos entre
+// ventanas de disponibilidad se modelan como pausas fijas al final de cada ventana.
+func mapBreaks(v optimization.Vehicle) []model.VroomBreak {
+	var breaks []model.VroomBreak
+	for _, b := range v.Breaks {
+		vroomBreak := model.VroomBreak{
+			ID:          len(breaks) + 1,
+			TimeWindows: mapTimeWindows(b.TimeWindows),
+			Service:     b.Duration,
+			Description: b.Description,
+		}
+		if b.MaxLoad != nil {
+			vroomBreak.MaxLoad = []int64{
+				b.MaxLoad.Weight,
+				b.MaxLoad.Volume,
+				b.MaxLoad.Insurance,
+			}
+		}
+		breaks = append(breaks, vroomBreak)
+	}
+
+	windows := mapTimeWindows(v.Windows())
+	for i := 1; i < len(windows); i++ {
+		gapStart, gapEnd := windows[i-1][1], windows[i][0]
+		if gapEnd <= gapStart {
+			continue
+		}
+		breaks = append(breaks, model.VroomBreak{
+			ID:          len(breaks) + 1,
+			TimeWindows: [][]int{{gapStart, gapStart}},
+			Service:     int64(gapEnd - gapStart),
+			Description: UnavailableBreakDescription,
+		})
+	}
+	return breaks
+}
+
+// FormatSeconds convierte segundos desde medianoche al formato "HH:MM"
+func FormatSeconds(seconds int64) string {
+	return fmt.Sprintf("%02d:%02d", seconds/3600, (seconds%3600)/60)
 }
 
-func toSeconds(timeStr string) int {
+// ToSeconds convierte "HH:MM" a segundos desde medianoche
+func ToSeconds(timeStr string) int {
 	// espera formato "HH:MM"
 	var h, m int
 	fmt.Sscanf(timeStr, "%02d:%02d", &h, &m)
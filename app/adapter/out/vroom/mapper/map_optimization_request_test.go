@@ -0,0 +1,245 @@
+package mapper
+
+import (
+	"context"
+	"transport-app/app/adapter/out/vroom/model"
+	"transport-app/app/domain/optimization"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MapOptimizationRequest", func() {
+	var ctx context.Context
+
+	deliveryAt := func(lat, lon float64) optimization.VisitLocation {
+		return optimization.VisitLocation{
+			AddressInfo: optimization.AddressInfo{
+				Coordinates: optimization.Coordinates{Latitude: lat, Longitude: lon},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Describe("time windows", func() {
+		It("should map every delivery window of a job sorted by start", func() {
+			delivery := deliveryAt(-33.45, -70.66)
+			delivery.TimeWindows = []optimization.TimeWindow{
+				{Start: "15:00", End: "18:00"},
+				{Start: "09:00", End: "12:00"},
+			}
+
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Visits: []optimization.Visit{{Delivery: delivery}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Jobs).To(HaveLen(1))
+			Expect(req.Jobs[0].TimeWindows).To(Equal([][]int{
+				{9 * 3600, 12 * 3600},
+				{15 * 3600, 18 * 3600},
+			}))
+		})
+
+		It("should fall back to the single time window", func() {
+			delivery := deliveryAt(-33.45, -70.66)
+			delivery.TimeWindow = optimization.TimeWindow{Start: "08:30", End: "10:00"}
+
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Visits: []optimization.Visit{{Delivery: delivery}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Jobs[0].TimeWindows).To(Equal([][]int{{8*3600 + 30*60, 10 * 3600}}))
+		})
+
+		It("should map pickup and delivery windows of a shipment", func() {
+			pickup := deliveryAt(-33.40, -70.60)
+			pickup.TimeWindows = []optimization.TimeWindow{{Start: "08:00", End: "09:00"}}
+			delivery := deliveryAt(-33.45, -70.66)
+			delivery.TimeWindows = []optimization.TimeWindow{
+				{Start: "10:00", End: "11:00"},
+				{Start: "16:00", End: "17:00"},
+			}
+
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Visits: []optimization.Visit{{Pickup: pickup, Delivery: delivery}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Shipments).To(HaveLen(1))
+			Expect(req.Shipments[0].Pickup.TimeWindows).To(Equal([][]int{{8 * 3600, 9 * 3600}}))
+			Expect(req.Shipments[0].Delivery.TimeWindows).To(HaveLen(2))
+		})
+
+		It("should map the service time of each shipment step", func() {
+			pickup := deliveryAt(-33.40, -70.60)
+			pickup.ServiceTime = 600
+			delivery := deliveryAt(-33.45, -70.66)
+			delivery.ServiceTime = 120
+
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Visits: []optimization.Visit{{Pickup: pickup, Delivery: delivery}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Shipments[0].Pickup.Service).To(Equal(int64(600)))
+			Expect(req.Shipments[0].Delivery.Service).To(Equal(int64(120)))
+		})
+
+		It("should use the envelope of the vehicle windows", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{
+					Plate: "ABCD12",
+					TimeWindows: []optimization.TimeWindow{
+						{Start: "14:00", End: "19:00"},
+						{Start: "08:00", End: "12:00"},
+					},
+				}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].TimeWindow).To(Equal([]int{8 * 3600, 19 * 3600}))
+		})
+
+		It("should end windows crossing midnight on the next day", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{
+					Plate:       "ABCD12",
+					TimeWindows: []optimization.TimeWindow{{Start: "22:00", End: "02:00"}},
+				}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].TimeWindow).To(Equal([]int{22 * 3600, 26 * 3600}))
+		})
+	})
+
+	Describe("breaks", func() {
+		It("should map driver breaks with their windows, duration and max load", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{
+					Plate: "ABCD12",
+					Breaks: []optimization.Break{{
+						TimeWindows: []optimization.TimeWindow{{Start: "12:00", End: "14:00"}},
+						Duration:    1800,
+						Description: "Lunch",
+						MaxLoad:     &optimization.Capacity{Weight: 100, Volume: 50, Insurance: 10},
+					}},
+				}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].Breaks).To(HaveLen(1))
+			b := req.Vehicles[0].Breaks[0]
+			Expect(b.ID).To(Equal(1))
+			Expect(b.TimeWindows).To(Equal([][]int{{12 * 3600, 14 * 3600}}))
+			Expect(b.Service).To(Equal(int64(1800)))
+			Expect(b.Description).To(Equal("Lunch"))
+			Expect(b.MaxLoad).To(Equal([]int64{100, 50, 10}))
+		})
+
+		It("should model gaps between vehicle windows as fixed breaks", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{
+					Plate: "ABCD12",
+					TimeWindows: []optimization.TimeWindow{
+						{Start: "08:00", End: "12:00"},
+						{Start: "14:00", End: "19:00"},
+					},
+				}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].Breaks).To(Equal([]model.VroomBreak{{
+				ID:          1,
+				TimeWindows: [][]int{{12 * 3600, 12 * 3600}},
+				Service:     2 * 3600,
+				Description: UnavailableBreakDescription,
+			}}))
+		})
+	})
+
+	Describe("priority", func() {
+		It("should map the visit priority to jobs and shipments", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Visits: []optimization.Visit{
+					{Delivery: deliveryAt(-33.45, -70.66), Priority: 80},
+					{Pickup: deliveryAt(-33.40, -70.60), Delivery: deliveryAt(-33.46, -70.67), Priority: 100},
+				},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Jobs[0].Priority).To(Equal(80))
+			Expect(req.Shipments[0].Priority).To(Equal(100))
+		})
+
+		It("should clamp priorities to the VROOM range", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Visits: []optimization.Visit{
+					{Delivery: deliveryAt(-33.45, -70.66), Priority: 250},
+					{Delivery: deliveryAt(-33.46, -70.67), Priority: -5},
+				},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Jobs[0].Priority).To(Equal(100))
+			Expect(req.Jobs[1].Priority).To(Equal(0))
+		})
+	})
+
+	Describe("route limits", func() {
+		It("should map max tasks, travel time and distance of the vehicle", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{
+					Plate:         "ABCD12",
+					MaxTasks:      40,
+					MaxTravelTime: 8 * 3600,
+					MaxDistance:   150000,
+				}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].MaxTasks).To(Equal(40))
+			Expect(req.Vehicles[0].MaxTravelTime).To(Equal(int64(8 * 3600)))
+			Expect(req.Vehicles[0].MaxDistance).To(Equal(int64(150000)))
+		})
+
+		It("should omit non positive limits", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{Plate: "ABCD12", MaxTasks: -1}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].MaxTasks).To(BeZero())
+			Expect(req.Vehicles[0].MaxTravelTime).To(BeZero())
+			Expect(req.Vehicles[0].MaxDistance).To(BeZero())
+		})
+	})
+
+	Describe("vehicle costs", func() {
+		It("should map fixed, per hour and per km costs", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{
+					Plate: "ABCD12",
+					Costs: optimization.VehicleCosts{Fixed: 20000, PerHour: 5000, PerKm: 300},
+				}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].Costs).To(Equal(&model.VroomCosts{Fixed: 20000, PerHour: 5000, PerKm: 300}))
+		})
+
+		It("should omit costs when none are configured", func() {
+			req, err := MapOptimizationRequest(ctx, optimization.FleetOptimization{
+				Vehicles: []optimization.Vehicle{{Plate: "ABCD12"}},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Vehicles[0].Costs).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,34 @@
+package vroom
+
+import (
+	"context"
+	"math"
+	"transport-app/app/adapter/out/vroom/model"
+	"transport-app/app/adapter/out/vroom/solver"
+)
+
+// newHaversineMatrixProvider estima distancias en línea recta y duraciones a velocidad constante
+func newHaversineMatrixProvider(speedKmh float64) MatrixProvider {
+	metersPerSecond := speedKmh * 1000 / 3600
+	return func(ctx context.Context, locations [][2]float64) (model.VroomProfileMatrices, error) {
+		n := len(locations)
+		distances := make([][]int64, n)
+		durations := make([][]int64, n)
+		for i := 0; i < n; i++ {
+			distances[i] = make([]int64, n)
+			durations[i] = make([]int64, n)
+			for j := 0; j < n; j++ {
+				if i == j {
+					continue
+				}
+				meters := math.Round(solver.HaversineMeters(locations[i], locations[j]))
+				distances[i][j] = int64(meters)
+				durations[i][j] = int64(math.Round(meters / metersPerSecond))
+			}
+		}
+		return model.VroomProfileMatrices{
+			Distances: distances,
+			Durations: durations,
+		}, nil
+	}
+}
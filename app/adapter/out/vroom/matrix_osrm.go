@@ -0,0 +1,139 @@
+package vroom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"transport-app/app/adapter/out/vroom/model"
+	"transport-app/app/shared/infrastructure/observability"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// osrmTableBlockSize limita las coordenadas por llamada para respetar max-table-size de OSRM
+const osrmTableBlockSize = 50
+
+// osrmUnreachable se usa cuando OSRM no encuentra ruta entre dos puntos
+const osrmUnreachable = 1e9
+
+type osrmTableResponse struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Durations [][]*float64 `json:"durations"`
+	Distances [][]*float64 `json:"distances"`
+}
+
+// newOSRMMatrixProvider consulta el servicio /table de OSRM por bloques de orígenes y destinos
+func newOSRMMatrixProvider(
+	obs observability.Observability,
+	restyClient *resty.Client,
+	baseURL string,
+	profile string,
+) MatrixProvider {
+	if profile == "" {
+		profile = "driving"
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return func(ctx context.Context, locations [][2]float64) (model.VroomProfileMatrices, error) {
+		n := len(locations)
+		distances := make([][]int64, n)
+		durations := make([][]int64, n)
+		for i := range distances {
+			distances[i] = make([]int64, n)
+			durations[i] = make([]int64, n)
+		}
+
+		for srcStart := 0; srcStart < n; srcStart += osrmTableBlockSize {
+			srcEnd := min(srcStart+osrmTableBlockSize, n)
+			for dstStart := 0; dstStart < n; dstStart += osrmTableBlockSize {
+				dstEnd := min(dstStart+osrmTableBlockSize, n)
+				table, err := fetchOSRMTable(ctx, restyClient, baseURL, profile,
+					locations[srcStart:srcEnd], locations[dstStart:dstEnd])
+				if err != nil {
+					obs.Logger.ErrorContext(ctx,
+						"OSRM_TABLE_ERROR",
+						"error", err.Error(),
+						"url", baseURL)
+					return model.VroomProfileMatrices{}, err
+				}
+				for i := range table.Durations {
+					for j := range table.Durations[i] {
+						durations[srcStart+i][dstStart+j] = osrmValue(table.Durations[i][j])
+						if i < len(table.Distances) && j < len(table.Distances[i]) {
+							distances[srcStart+i][dstStart+j] = osrmValue(table.Distances[i][j])
+						}
+					}
+				}
+			}
+		}
+
+		return model.VroomProfileMatrices{
+			Distances: distances,
+			Durations: durations,
+		}, nil
+	}
+}
+
+// fetchOSRMTable pide la tabla entre sources y destinations en una sola llamada
+func fetchOSRMTable(
+	ctx context.Context,
+	restyClient *resty.Client,
+	baseURL string,
+	profile string,
+	sources [][2]float64,
+	destinations [][2]float64,
+) (osrmTableResponse, error) {
+	coords := make([]string, 0, len(sources)+len(destinations))
+	sourceIdx := make([]string, 0, len(sources))
+	destinationIdx := make([]string, 0, len(destinations))
+	for _, loc := range sources {
+		sourceIdx = append(sourceIdx, strconv.Itoa(len(coords)))
+		coords = append(coords, formatCoordinate(loc))
+	}
+	for _, loc := range destinations {
+		destinationIdx = append(destinationIdx, strconv.Itoa(len(coords)))
+		coords = append(coords, formatCoordinate(loc))
+	}
+
+	url := fmt.Sprintf("%s/table/v1/%s/%s", baseURL, profile, strings.Join(coords, ";"))
+	res, err := restyClient.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"sources":      strings.Join(sourceIdx, ";"),
+			"destinations": strings.Join(destinationIdx, ";"),
+			"annotations":  "duration,distance",
+		}).
+		Get(url)
+	if err != nil {
+		return osrmTableResponse{}, err
+	}
+	if res.IsError() {
+		return osrmTableResponse{}, fmt.Errorf("OSRM table error (status %d): %s", res.StatusCode(), res.String())
+	}
+
+	var table osrmTableResponse
+	if err := json.Unmarshal(res.Body(), &table); err != nil {
+		return osrmTableResponse{}, fmt.Errorf("failed to deserialize OSRM table response: %w", err)
+	}
+	if table.Code != "Ok" {
+		return osrmTableResponse{}, fmt.Errorf("OSRM table error %s: %s", table.Code, table.Message)
+	}
+	if len(table.Durations) != len(sources) {
+		return osrmTableResponse{}, fmt.Errorf("OSRM table returned %d rows, expected %d", len(table.Durations), len(sources))
+	}
+	return table, nil
+}
+
+func formatCoordinate(loc [2]float64) string {
+	return strconv.FormatFloat(loc[0], 'f', 6, 64) + "," + strconv.FormatFloat(loc[1], 'f', 6, 64)
+}
+
+func osrmValue(v *float64) int64 {
+	if v == nil {
+		return osrmUnreachable
+	}
+	return int64(math.Round(*v))
+}
@@ -0,0 +1,129 @@
+package vroom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"transport-app/app/adapter/out/vroom/model"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// precomputedMatrix es el formato del archivo de matrices precalculadas: las filas y
+// columnas de durations y distances siguen el orden de locations ([lon, lat])
+type precomputedMatrix struct {
+	Locations [][2]float64 `json:"locations"`
+	Durations [][]float64  `json:"durations"`
+	Distances [][]float64  `json:"distances"`
+}
+
+// newPrecomputedMatrixProvider carga una vez la matriz desde un archivo local o una URL
+// http(s) (por ejemplo una URL prefirmada de object storage) y extrae la submatriz pedida
+func newPrecomputedMatrixProvider(restyClient *resty.Client, source string) MatrixProvider {
+	var (
+		mu     sync.Mutex
+		loaded *precomputedMatrix
+		index  map[string]int
+	)
+	return func(ctx context.Context, locations [][2]float64) (model.VroomProfileMatrices, error) {
+		mu.Lock()
+		if loaded == nil {
+			m, err := loadPrecomputedMatrix(ctx, restyClient, source)
+			if err != nil {
+				mu.Unlock()
+				return model.VroomProfileMatrices{}, err
+			}
+			loaded = &m
+			index = make(map[string]int, len(m.Locations))
+			for i, loc := range m.Locations {
+				index[formatCoordinate(loc)] = i
+			}
+		}
+		m := loaded
+		mu.Unlock()
+
+		rows := make([]int, len(locations))
+		for i, loc := range locations {
+			row, ok := index[formatCoordinate(loc)]
+			if !ok {
+				return model.VroomProfileMatrices{}, fmt.Errorf("location %v not found in precomputed matrix", loc)
+			}
+			rows[i] = row
+		}
+
+		out := model.VroomProfileMatrices{
+			Durations: subMatrix(m.Durations, rows),
+		}
+		if len(m.Distances) > 0 {
+			out.Distances = subMatrix(m.Distances, rows)
+		}
+		return out, nil
+	}
+}
+
+func loadPrecomputedMatrix(ctx context.Context, restyClient *resty.Client, source string) (precomputedMatrix, error) {
+	var data []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		res, err := restyClient.R().SetContext(ctx).Get(source)
+		if err != nil {
+			return precomputedMatrix{}, err
+		}
+		if res.IsError() {
+			return precomputedMatrix{}, fmt.Errorf("failed to download precomputed matrix (status %d)", res.StatusCode())
+		}
+		data = res.Body()
+	} else {
+		var err error
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return precomputedMatrix{}, fmt.Errorf("failed to read precomputed matrix: %w", err)
+		}
+	}
+
+	var m precomputedMatrix
+	if err := json.Unmarshal(data, &m); err != nil {
+		return precomputedMatrix{}, fmt.Errorf("failed to deserialize precomputed matrix: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return precomputedMatrix{}, err
+	}
+	return m, nil
+}
+
+func (m precomputedMatrix) validate() error {
+	n := len(m.Locations)
+	square := func(matrix [][]float64) bool {
+		if len(matrix) != n {
+			return false
+		}
+		for _, row := range matrix {
+			if len(row) != n {
+				return false
+			}
+		}
+		return true
+	}
+	if !square(m.Durations) {
+		return fmt.Errorf("precomputed durations must be a %dx%d matrix", n, n)
+	}
+	if len(m.Distances) > 0 && !square(m.Distances) {
+		return fmt.Errorf("precomputed distances must be a %dx%d matrix", n, n)
+	}
+	return nil
+}
+
+// subMatrix extrae las filas y columnas pedidas redondeando a enteros, como espera VROOM
+func subMatrix(matrix [][]float64, rows []int) [][]int64 {
+	out := make([][]int64, len(rows))
+	for i, from := range rows {
+		out[i] = make([]int64, len(rows))
+		for j, to := range rows {
+			out[i][j] = int64(math.Round(matrix[from][to]))
+		}
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package vroom
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"transport-app/app/adapter/out/vroom/model"
+	"transport-app/app/adapter/out/vroom/solver"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/go-resty/resty/v2"
+)
+
+// Backends disponibles para MATRIX_PROVIDER
+const (
+	HaversineMatrixProvider   = "haversine"
+	OSRMMatrixProvider        = "osrm"
+	PrecomputedMatrixProvider = "file"
+)
+
+// MatrixProvider calcula las matrices de distancia (metros) y duración (segundos)
+// entre ubicaciones [lon, lat], en el mismo orden recibido
+type MatrixProvider func(ctx context.Context, locations [][2]float64) (model.VroomProfileMatrices, error)
+
+func init() {
+	ioc.Registry(
+		NewMatrixProvider,
+		observability.NewObservability,
+		NewVroomRestyHeavyClient,
+		configuration.NewConf,
+	)
+}
+
+// NewMatrixProvider selecciona el backend según MATRIX_PROVIDER. Retorna nil cuando no hay
+// proveedor configurado, en cuyo caso VROOM calcula las matrices por su cuenta.
+func NewMatrixProvider(
+	obs observability.Observability,
+	restyClient *resty.Client,
+	conf configuration.Conf,
+) (MatrixProvider, error) {
+	switch conf.MATRIX_PROVIDER {
+	case "":
+		return nil, nil
+	case HaversineMatrixProvider:
+		speedKmh, err := matrixSpeedKmh(conf)
+		if err != nil {
+			return nil, err
+		}
+		return newHaversineMatrixProvider(speedKmh), nil
+	case OSRMMatrixProvider:
+		if conf.OSRM_URL == "" {
+			return nil, fmt.Errorf("OSRM_URL is required for matrix provider %q", OSRMMatrixProvider)
+		}
+		return newOSRMMatrixProvider(obs, restyClient, conf.OSRM_URL, conf.OSRM_PROFILE), nil
+	case PrecomputedMatrixProvider:
+		if conf.MATRIX_FILE_PATH == "" {
+			return nil, fmt.Errorf("MATRIX_FILE_PATH is required for matrix provider %q", PrecomputedMatrixProvider)
+		}
+		return newPrecomputedMatrixProvider(restyClient, conf.MATRIX_FILE_PATH), nil
+	default:
+		return nil, fmt.Errorf("unknown MATRIX_PROVIDER: %s", conf.MATRIX_PROVIDER)
+	}
+}
+
+// speedProfiles son velocidades promedio urbanas en km/h para estimaciones en línea recta
+var speedProfiles = map[string]float64{
+	"car":        solver.DefaultSpeedKmh,
+	"motorcycle": 35,
+	"bicycle":    15,
+	"foot":       5,
+}
+
+// matrixSpeedKmh usa MATRIX_SPEED_KMH si está definido y si no el perfil MATRIX_SPEED_PROFILE
+func matrixSpeedKmh(conf configuration.Conf) (float64, error) {
+	if conf.MATRIX_SPEED_KMH != "" {
+		speed, err := strconv.ParseFloat(conf.MATRIX_SPEED_KMH, 64)
+		if err != nil || speed <= 0 {
+			return 0, fmt.Errorf("invalid MATRIX_SPEED_KMH: %s", conf.MATRIX_SPEED_KMH)
+		}
+		return speed, nil
+	}
+	if conf.MATRIX_SPEED_PROFILE == "" {
+		return solver.DefaultSpeedKmh, nil
+	}
+	speed, ok := speedProfiles[conf.MATRIX_SPEED_PROFILE]
+	if !ok {
+		return 0, fmt.Errorf("unknown MATRIX_SPEED_PROFILE: %s", conf.MATRIX_SPEED_PROFILE)
+	}
+	return speed, nil
+}
+
+// injectMatrices completa la solicitud con las matrices del proveedor e indexa vehículos,
+// jobs y shipments según el orden canónico de solver.Locations. VROOM busca las matrices por
+// el perfil de cada vehículo, por lo que se registran bajo todos los perfiles usados.
+func injectMatrices(ctx context.Context, provider MatrixProvider, req model.VroomOptimizationRequest) (model.VroomOptimizationRequest, error) {
+	if provider == nil {
+		return req, nil
+	}
+	locations := solver.Locations(req)
+	if len(locations) == 0 {
+		return req, nil
+	}
+	matrices, err := provider(ctx, locations)
+	if err != nil {
+		return req, fmt.Errorf("failed to build travel matrix: %w", err)
+	}
+
+	index := make(map[[2]float64]int, len(locations))
+	for i, loc := range locations {
+		index[loc] = i
+	}
+	indexOf := func(loc [2]float64) *int {
+		i := index[loc]
+		return &i
+	}
+
+	vehicles := make([]model.VroomVehicle, len(req.Vehicles))
+	for i, v := range req.Vehicles {
+		if v.Start != nil {
+			v.StartIndex = indexOf(*v.Start)
+		}
+		if v.End != nil {
+			v.EndIndex = indexOf(*v.End)
+		}
+		vehicles[i] = v
+	}
+	jobs := make([]model.VroomJob, len(req.Jobs))
+	for i, j := range req.Jobs {
+		j.LocationIndex = indexOf(j.Location)
+		jobs[i] = j
+	}
+	shipments := make([]model.VroomShipment, len(req.Shipments))
+	for i, s := range req.Shipments {
+		if s.Pickup.Location != nil {
+			s.Pickup.LocationIndex = indexOf(*s.Pickup.Location)
+		}
+		if s.Delivery.Location != nil {
+			s.Delivery.LocationIndex = indexOf(*s.Delivery.Location)
+		}
+		shipments[i] = s
+	}
+
+	req.Vehicles = vehicles
+	req.Jobs = jobs
+	req.Shipments = shipments
+	req.Matrices = model.VroomMatrices{}
+	for _, v := range vehicles {
+		req.Matrices[v.ProfileOrDefault()] = matrices
+	}
+	if len(req.Matrices) == 0 {
+		req.Matrices[model.VroomDefaultProfile] = matrices
+	}
+	return req, nil
+}
@@ -0,0 +1,291 @@
+package vroom
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"transport-app/app/adapter/out/vroom/model"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/observability"
+
+	"github.com/go-resty/resty/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MatrixProvider", func() {
+	var (
+		ctx       context.Context
+		obs       observability.Observability
+		depot     = [2]float64{-70.6500, -33.4400}
+		customer  = [2]float64{-70.6400, -33.4300}
+		customer2 = [2]float64{-70.6300, -33.4200}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obs = observability.Observability{Logger: slog.Default()}
+	})
+
+	Describe("NewMatrixProvider", func() {
+		It("should be disabled when no provider is configured", func() {
+			provider, err := NewMatrixProvider(obs, resty.New(), configuration.Conf{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(provider).To(BeNil())
+		})
+
+		It("should reject unknown providers and profiles", func() {
+			_, err := NewMatrixProvider(obs, resty.New(), configuration.Conf{MATRIX_PROVIDER: "teleport"})
+			Expect(err).To(HaveOccurred())
+
+			_, err = NewMatrixProvider(obs, resty.New(), configuration.Conf{
+				MATRIX_PROVIDER:      HaversineMatrixProvider,
+				MATRIX_SPEED_PROFILE: "rocket",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("haversine", func() {
+		It("should estimate symmetric distances and durations from the speed profile", func() {
+			provider, err := NewMatrixProvider(obs, resty.New(), configuration.Conf{
+				MATRIX_PROVIDER:      HaversineMatrixProvider,
+				MATRIX_SPEED_PROFILE: "bicycle",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			m, err := provider(ctx, [][2]float64{depot, customer})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Distances[0][0]).To(BeZero())
+			Expect(m.Distances[0][1]).To(Equal(m.Distances[1][0]))
+			Expect(m.Distances[0][1]).To(BeNumerically("~", 1440, 20))
+			// 15 km/h ≈ 4.17 m/s
+			Expect(m.Durations[0][1]).To(BeNumerically("~", float64(m.Distances[0][1])/(15000.0/3600), 1))
+		})
+	})
+
+	Describe("OSRM table", func() {
+		It("should query /table and fill unreachable pairs", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(HavePrefix("/table/v1/driving/"))
+				Expect(r.URL.Query().Get("annotations")).To(Equal("duration,distance"))
+				Expect(r.URL.Query().Get("sources")).To(Equal("0;1"))
+				Expect(r.URL.Query().Get("destinations")).To(Equal("2;3"))
+				unreachable := (*float64)(nil)
+				d := func(v float64) *float64 { return &v }
+				json.NewEncoder(w).Encode(osrmTableResponse{
+					Code:      "Ok",
+					Durations: [][]*float64{{d(0), d(120)}, {d(130), unreachable}},
+					Distances: [][]*float64{{d(0), d(900)}, {d(950), unreachable}},
+				})
+			}))
+			defer server.Close()
+
+			provider, err := NewMatrixProvider(obs, resty.New(), configuration.Conf{
+				MATRIX_PROVIDER: OSRMMatrixProvider,
+				OSRM_URL:        server.URL + "/",
+				OSRM_PROFILE:    "driving",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			m, err := provider(ctx, [][2]float64{depot, customer})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Durations).To(Equal([][]int64{{0, 120}, {130, osrmUnreachable}}))
+			Expect(m.Distances).To(Equal([][]int64{{0, 900}, {950, osrmUnreachable}}))
+		})
+
+		It("should fail when OSRM answers with an error code", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(osrmTableResponse{Code: "InvalidQuery", Message: "bad"})
+			}))
+			defer server.Close()
+
+			provider := newOSRMMatrixProvider(obs, resty.New(), server.URL, "")
+			_, err := provider(ctx, [][2]float64{depot, customer})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("InvalidQuery"))
+		})
+	})
+
+	Describe("precomputed file", func() {
+		writeMatrix := func(m precomputedMatrix) string {
+			path := filepath.Join(GinkgoT().TempDir(), "matrix.json")
+			data, err := json.Marshal(m)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.WriteFile(path, data, 0o600)).To(Succeed())
+			return path
+		}
+
+		It("should extract the submatrix for the requested locations", func() {
+			path := writeMatrix(precomputedMatrix{
+				Locations: [][2]float64{depot, customer, customer2},
+				Durations: [][]float64{{0, 10, 20}, {11, 0, 30}, {21, 31, 0}},
+				Distances: [][]float64{{0, 100, 200}, {110, 0, 300}, {210, 310, 0}},
+			})
+			provider, err := NewMatrixProvider(obs, resty.New(), configuration.Conf{
+				MATRIX_PROVIDER:  PrecomputedMatrixProvider,
+				MATRIX_FILE_PATH: path,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			m, err := provider(ctx, [][2]float64{customer2, depot})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Durations).To(Equal([][]int64{{0, 21}, {20, 0}}))
+			Expect(m.Distances).To(Equal([][]int64{{0, 210}, {200, 0}}))
+		})
+
+		It("should download the matrix from an http source", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(precomputedMatrix{
+					Locations: [][2]float64{depot, customer},
+					Durations: [][]float64{{0, 5}, {6, 0}},
+				})
+			}))
+			defer server.Close()
+
+			provider := newPrecomputedMatrixProvider(resty.New(), server.URL+"/matrix.json")
+			m, err := provider(ctx, [][2]float64{depot, customer})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Durations).To(Equal([][]int64{{0, 5}, {6, 0}}))
+			Expect(m.Distances).To(BeNil())
+		})
+
+		It("should fail for locations missing in the matrix", func() {
+			path := writeMatrix(precomputedMatrix{
+				Locations: [][2]float64{depot},
+				Durations: [][]float64{{0}},
+			})
+			provider := newPrecomputedMatrixProvider(resty.New(), path)
+
+			_, err := provider(ctx, [][2]float64{depot, customer})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not found"))
+		})
+
+		It("should reject matrices that do not match the locations", func() {
+			path := writeMatrix(precomputedMatrix{
+				Locations: [][2]float64{depot, customer},
+				Durations: [][]float64{{0, 1}},
+			})
+			provider := newPrecomputedMatrixProvider(resty.New(), path)
+
+			_, err := provider(ctx, [][2]float64{depot})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("2x2"))
+		})
+	})
+
+	Describe("injectMatrices", func() {
+		It("should attach the matrices and the location indexes", func() {
+			start := depot
+			pickup := customer
+			delivery := customer2
+			req := model.VroomOptimizationRequest{
+				Vehicles: []model.VroomVehicle{{ID: 1, Start: &start, End: &start}},
+				Jobs:     []model.VroomJob{{ID: 1, Location: customer2}},
+				Shipments: []model.VroomShipment{{
+					ID:       1,
+					Pickup:   model.VroomStep{ID: 1, Location: &pickup},
+					Delivery: model.VroomStep{ID: 2, Location: &delivery},
+				}},
+			}
+
+			out, err := injectMatrices(ctx, newHaversineMatrixProvider(30), req)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out.Matrices).To(HaveKey(model.VroomDefaultProfile))
+			Expect(out.Matrices[model.VroomDefaultProfile].Durations).To(HaveLen(3))
+			Expect(*out.Vehicles[0].StartIndex).To(Equal(0))
+			Expect(*out.Vehicles[0].EndIndex).To(Equal(0))
+			Expect(*out.Jobs[0].LocationIndex).To(Equal(1))
+			Expect(*out.Shipments[0].Pickup.LocationIndex).To(Equal(2))
+			Expect(*out.Shipments[0].Delivery.LocationIndex).To(Equal(1))
+			Expect(req.Matrices).To(BeNil())
+		})
+
+		It("should serialize the matrices keyed by vehicle profile as VROOM expects", func() {
+			start := depot
+			req := model.VroomOptimizationRequest{
+				Vehicles: []model.VroomVehicle{
+					{ID: 1, Start: &start},
+					{ID: 2, Start: &start, Profile: "truck"},
+				},
+				Jobs: []model.VroomJob{{ID: 1, Location: customer}},
+			}
+			provider := func(ctx context.Context, locations [][2]float64) (model.VroomProfileMatrices, error) {
+				return model.VroomProfileMatrices{
+					Durations: [][]int64{{0, 120}, {130, 0}},
+					Distances: [][]int64{{0, 900}, {950, 0}},
+				}, nil
+			}
+
+			out, err := injectMatrices(ctx, provider, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			body, err := json.Marshal(out)
+			Expect(err).ToNot(HaveOccurred())
+			var sent map[string]json.RawMessage
+			Expect(json.Unmarshal(body, &sent)).To(Succeed())
+			Expect(string(sent["matrices"])).To(MatchJSON(`{
+				"car":   {"durations": [[0, 120], [130, 0]], "distances": [[0, 900], [950, 0]]},
+				"truck": {"durations": [[0, 120], [130, 0]], "distances": [[0, 900], [950, 0]]}
+			}`))
+		})
+
+		It("should read a VROOM request body with custom matrices", func() {
+			// Ejemplo de la documentación de la API de VROOM (docs/API.md, sección matrices)
+			body := `{
+				"vehicles": [
+					{"id": 1, "start_index": 0, "end_index": 0},
+					{"id": 2, "profile": "truck", "start_index": 0, "end_index": 0}
+				],
+				"jobs": [
+					{"id": 1414, "location_index": 1},
+					{"id": 1515, "location_index": 2}
+				],
+				"matrices": {
+					"car": {
+						"durations": [[0, 2104, 197], [2103, 0, 2255], [197, 2256, 0]],
+						"distances": [[0, 21040, 1970], [21030, 0, 22550], [1970, 22560, 0]]
+					},
+					"truck": {
+						"durations": [[0, 2600, 250], [2600, 0, 2800], [250, 2800, 0]]
+					}
+				}
+			}`
+
+			var req model.VroomOptimizationRequest
+			Expect(json.Unmarshal([]byte(body), &req)).To(Succeed())
+
+			Expect(req.Matrices).To(HaveLen(2))
+			Expect(req.Matrices[req.Vehicles[0].ProfileOrDefault()].Distances[0][1]).To(Equal(int64(21040)))
+			Expect(req.Matrices[req.Vehicles[1].ProfileOrDefault()].Durations[2][1]).To(Equal(int64(2800)))
+
+			roundTrip, err := json.Marshal(req.Matrices)
+			Expect(err).ToNot(HaveOccurred())
+			var original map[string]json.RawMessage
+			Expect(json.Unmarshal([]byte(body), &original)).To(Succeed())
+			Expect(string(roundTrip)).To(MatchJSON(original["matrices"]))
+		})
+
+		It("should leave the request untouched without provider", func() {
+			req := model.VroomOptimizationRequest{Jobs: []model.VroomJob{{ID: 1, Location: customer}}}
+
+			out, err := injectMatrices(ctx, nil, req)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(req))
+		})
+	})
+})
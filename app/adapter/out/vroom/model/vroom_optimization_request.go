@@ -5,23 +5,49 @@ type VroomOptimizationRequest struct {
 	Vehicles  []VroomVehicle  `json:"vehicles"`
 	Jobs      []VroomJob      `json:"jobs,omitempty"`
 	Shipments []VroomShipment `json:"shipments,omitempty"`
-	Matrices  *VroomMatrices  `json:"matrices,omitempty"`
+	Matrices  VroomMatrices   `json:"matrices,omitempty"`
 }
 
 // --- Vehicles ---
 type VroomVehicle struct {
-	ID         int         `json:"id"`
-	Start      *[2]float64 `json:"start,omitempty"`       // [lon, lat]
-	End        *[2]float64 `json:"end,omitempty"`         // [lon, lat]
-	Capacity   []int64     `json:"capacity,omitempty"`    // Ej: [peso, volumen]
-	Skills     []int64     `json:"skills,omitempty"`      // Habilidades codificadas como enteros
-	TimeWindow []int       `json:"time_window,omitempty"` // [start, end] en segundos desde medianoche
+	ID            int          `json:"id"`
+	Profile       string       `json:"profile,omitempty"`     // Perfil de ruteo; VROOM usa "car" por defecto
+	Start         *[2]float64  `json:"start,omitempty"`       // [lon, lat]
+	End           *[2]float64  `json:"end,omitempty"`         // [lon, lat]
+	Capacity      []int64      `json:"capacity,omitempty"`    // Ej: [peso, volumen]
+	Skills        []int64      `json:"skills,omitempty"`      // Habilidades codificadas como enteros
+	TimeWindow    []int        `json:"time_window,omitempty"` // [start, end] en segundos desde medianoche
+	Breaks        []VroomBreak `json:"breaks,omitempty"`
+	StartIndex    *int         `json:"start_index,omitempty"`     // Índice de start en las matrices
+	EndIndex      *int         `json:"end_index,omitempty"`       // Índice de end en las matrices
+	MaxTasks      int          `json:"max_tasks,omitempty"`       // Máximo de tareas (jobs, pickups y deliveries)
+	MaxTravelTime int64        `json:"max_travel_time,omitempty"` // Máximo tiempo de viaje en segundos
+	MaxDistance   int64        `json:"max_distance,omitempty"`    // Máxima distancia en metros
+	Costs         *VroomCosts  `json:"costs,omitempty"`
+}
+
+// --- Costos del vehículo ---
+// VROOM usa per_hour=3600 por defecto, es decir, el costo de una ruta es su duración en segundos
+type VroomCosts struct {
+	Fixed   int64 `json:"fixed,omitempty"`    // Costo fijo por usar el vehículo
+	PerHour int64 `json:"per_hour,omitempty"` // Costo por hora de viaje
+	PerKm   int64 `json:"per_km,omitempty"`   // Costo por kilómetro recorrido
+}
+
+// --- Breaks (pausas del conductor) ---
+type VroomBreak struct {
+	ID          int     `json:"id"`
+	TimeWindows [][]int `json:"time_windows,omitempty"` // [[start, end]] ventanas para iniciar la pausa
+	Service     int64   `json:"service,omitempty"`      // Duración en segundos
+	Description string  `json:"description,omitempty"`
+	MaxLoad     []int64 `json:"max_load,omitempty"` // Carga máxima permitida durante la pausa
 }
 
 // --- Jobs (entrega directa sin pickup) ---
 type VroomJob struct {
 	ID             int            `json:"id"`
 	Location       [2]float64     `json:"location"`                   // [lon, lat]
+	LocationIndex  *int           `json:"location_index,omitempty"`   // Índice de location en las matrices
 	Service        int64          `json:"service,omitempty"`          // En segundos
 	Amount         []int64        `json:"amount,omitempty"`           // Ej: [peso, volumen]
 	Skills         []int64        `json:"skills,omitempty"`           // Habilidades requeridas
@@ -39,17 +65,35 @@ type VroomShipment struct {
 	Skills         []int64        `json:"skills,omitempty"`
 	TimeWindows    [][]int        `json:"time_windows,omitempty"`
 	Service        int64          `json:"service,omitempty"`
+	Priority       int            `json:"priority,omitempty"` // 0-100
 	CustomUserData map[string]any `json:"custom_user_data,omitempty"`
 }
 
 type VroomStep struct {
-	ID          int         `json:"id"`                     // Unique identifier for the step
-	Location    *[2]float64 `json:"location,omitempty"`     // [lon, lat]
-	TimeWindows [][]int     `json:"time_windows,omitempty"` // [[start, end]]
+	ID            int         `json:"id"`                       // Unique identifier for the step
+	Location      *[2]float64 `json:"location,omitempty"`       // [lon, lat]
+	LocationIndex *int        `json:"location_index,omitempty"` // Índice de location en las matrices
+	TimeWindows   [][]int     `json:"time_windows,omitempty"`   // [[start, end]]
+	Service       int64       `json:"service,omitempty"`        // Tiempo de servicio del paso en segundos
 }
 
 // --- Matrices ---
-type VroomMatrices struct {
-	DistanceMatrix [][]float64 `json:"distance_matrix,omitempty"`
-	DurationMatrix [][]float64 `json:"duration_matrix,omitempty"`
+// VroomDefaultProfile es el perfil que VROOM asume cuando el vehículo no declara uno
+const VroomDefaultProfile = "car"
+
+// VroomMatrices son matrices personalizadas por perfil de vehículo, con el formato que lee VROOM:
+// {"car": {"durations": [[...]], "distances": [[...]]}}
+type VroomMatrices map[string]VroomProfileMatrices
+
+type VroomProfileMatrices struct {
+	Durations [][]int64 `json:"durations,omitempty"` // En segundos
+	Distances [][]int64 `json:"distances,omitempty"` // En metros
+}
+
+// ProfileOrDefault retorna el perfil con el que VROOM busca las matrices del vehículo
+func (v VroomVehicle) ProfileOrDefault() string {
+	if v.Profile == "" {
+		return VroomDefaultProfile
+	}
+	return v.Profile
 }
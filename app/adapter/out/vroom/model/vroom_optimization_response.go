@@ -20,13 +20,26 @@ import (
 type VroomOptimizationResponse struct {
 	Code       int64           `json:"code"`
 	Error      string          `json:"error,omitempty"`
+	Summary    *Summary        `json:"summary,omitempty"`
 	Unassigned []UnassignedJob `json:"unassigned,omitempty"`
 	Routes     []Route         `json:"routes,omitempty"`
 }
 
+// Summary agrega los indicadores de todas las rutas de la solución
+type Summary struct {
+	Cost        int64 `json:"cost"`
+	Routes      int64 `json:"routes"`
+	Unassigned  int64 `json:"unassigned"`
+	Service     int64 `json:"service"`
+	Duration    int64 `json:"duration"`
+	WaitingTime int64 `json:"waiting_time"`
+	Distance    int64 `json:"distance,omitempty"`
+}
+
 // UnassignedJob represents jobs that couldn't be assigned to any vehicle
 type UnassignedJob struct {
 	ID       int64      `json:"id"`
+	Type     string     `json:"type,omitempty"` // "job", "pickup", "delivery"
 	Location [2]float64 `json:"location,omitempty"`
 	Reason   string     `json:"reason"`
 }
@@ -38,6 +51,7 @@ type Route struct {
 	Service     int64   `json:"service"`
 	Duration    int64   `json:"duration"`
 	WaitingTime int64   `json:"waiting_time"`
+	Distance    int64   `json:"distance,omitempty"`
 	Priority    float64 `json:"priority"`
 	Steps       []Step  `json:"steps"`
 	Geometry    string  `json:"geometry,omitempty"`
@@ -53,6 +67,7 @@ type RouteGeometry struct {
 // Step represents a single step in a route (pickup, delivery, or break)
 type Step struct {
 	Type           string         `json:"type"` // "start", "job", "pickup", "delivery", "break", "end"
+	ID             int64          `json:"id,omitempty"`
 	Arrival        int64          `json:"arrival"`
 	Duration       int64          `json:"duration"`
 	Service        int64          `json:"service"`
@@ -0,0 +1,13 @@
+package vroom
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestVroom(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Vroom Suite")
+}
@@ -0,0 +1,87 @@
+package vroom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"transport-app/app/adapter/out/vroom/model"
+	"transport-app/app/adapter/out/vroom/solver"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/observability"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// BuiltinOptimizationStrategy selecciona el solver embebido en lugar de un servidor VROOM
+const BuiltinOptimizationStrategy = "builtin"
+
+// solveVRP resuelve una solicitud VROOM contra la URL indicada o con el solver embebido
+type solveVRP func(ctx context.Context, url string, req model.VroomOptimizationRequest) (model.VroomOptimizationResponse, error)
+
+func newSolveVRP(
+	obs observability.Observability,
+	restyClient *resty.Client,
+	conf configuration.Conf,
+) solveVRP {
+	builtin := newBuiltinSolveVRP()
+	remote := newRemoteSolveVRP(obs, restyClient)
+	return func(ctx context.Context, url string, req model.VroomOptimizationRequest) (model.VroomOptimizationResponse, error) {
+		if conf.OPTIMIZATION_STRATEGY == BuiltinOptimizationStrategy || url == "" {
+			return builtin(ctx, url, req)
+		}
+		return remote(ctx, url, req)
+	}
+}
+
+func newBuiltinSolveVRP() solveVRP {
+	return func(ctx context.Context, _ string, req model.VroomOptimizationRequest) (model.VroomOptimizationResponse, error) {
+		return solver.Solve(ctx, req, solver.Options{}), nil
+	}
+}
+
+func newRemoteSolveVRP(
+	obs observability.Observability,
+	restyClient *resty.Client,
+) solveVRP {
+	return func(ctx context.Context, url string, req model.VroomOptimizationRequest) (model.VroomOptimizationResponse, error) {
+		res, err := restyClient.R().
+			SetContext(ctx).
+			SetHeader("Content-Type", "application/json").
+			SetBody(req). // Resty hace el marshal automáticamente
+			Post(url)
+
+		if err != nil {
+			obs.Logger.ErrorContext(ctx,
+				"VROOM_REQUEST_ERROR",
+				"error", err.Error(),
+				"url", url,
+			)
+			return model.VroomOptimizationResponse{}, err
+		}
+
+		if res.IsError() {
+			obs.Logger.ErrorContext(ctx,
+				"VROOM_API_ERROR",
+				"status", res.StatusCode(),
+				"body", res.String(),
+				"request", req,
+			)
+			return model.VroomOptimizationResponse{}, fmt.Errorf("VROOM API error (status %d): %s\nRequest payload: %+v",
+				res.StatusCode(),
+				res.String(),
+				req)
+		}
+
+		// Deserializar la respuesta de VROOM
+		var vroomResponse model.VroomOptimizationResponse
+		if err := json.Unmarshal(res.Body(), &vroomResponse); err != nil {
+			obs.Logger.ErrorContext(ctx,
+				"VROOM_RESPONSE_DESERIALIZATION_ERROR",
+				"error", err.Error(),
+				"body", res.String(),
+			)
+			return model.VroomOptimizationResponse{}, fmt.Errorf("failed to deserialize VROOM response: %w", err)
+		}
+		return vroomResponse, nil
+	}
+}
@@ -0,0 +1,148 @@
+package solver
+
+import (
+	"math"
+	"transport-app/app/adapter/out/vroom/model"
+)
+
+const (
+	earthRadiusMeters = 6371000.0
+	// DefaultSpeedKmh es la velocidad promedio usada para estimar duraciones en línea recta
+	DefaultSpeedKmh = 30.0
+)
+
+// travelMatrix entrega distancia (metros) y duración (segundos) entre índices de ubicación
+type travelMatrix struct {
+	distances [][]int64
+	durations [][]int64
+}
+
+func (m travelMatrix) distance(from, to int) int64 {
+	if from < 0 || to < 0 {
+		return 0
+	}
+	return m.distances[from][to]
+}
+
+func (m travelMatrix) duration(from, to int) int64 {
+	if from < 0 || to < 0 {
+		return 0
+	}
+	return m.durations[from][to]
+}
+
+// Locations retorna las ubicaciones distintas de la solicitud en el orden canónico usado
+// para indexar las matrices: inicio y fin de cada vehículo, jobs y luego pickup/delivery de shipments.
+func Locations(req model.VroomOptimizationRequest) [][2]float64 {
+	var locations [][2]float64
+	seen := make(map[[2]float64]int)
+	add := func(loc [2]float64) {
+		if _, ok := seen[loc]; ok {
+			return
+		}
+		seen[loc] = len(locations)
+		locations = append(locations, loc)
+	}
+	for _, v := range req.Vehicles {
+		if v.Start != nil {
+			add(*v.Start)
+		}
+		if v.End != nil {
+			add(*v.End)
+		}
+	}
+	for _, j := range req.Jobs {
+		add(j.Location)
+	}
+	for _, s := range req.Shipments {
+		if s.Pickup.Location != nil {
+			add(*s.Pickup.Location)
+		}
+		if s.Delivery.Location != nil {
+			add(*s.Delivery.Location)
+		}
+	}
+	return locations
+}
+
+// buildTravelMatrix usa las matrices de la solicitud cuando calzan con las ubicaciones
+// y en caso contrario estima con haversine a la velocidad indicada.
+func buildTravelMatrix(locations [][2]float64, matrices *model.VroomProfileMatrices, speedKmh float64) travelMatrix {
+	n := len(locations)
+	if matrices != nil && isSquare(matrices.Durations, n) {
+		m := travelMatrix{
+			durations: matrices.Durations,
+		}
+		if isSquare(matrices.Distances, n) {
+			m.distances = matrices.Distances
+		} else {
+			m.distances = haversineMatrix(locations)
+		}
+		return m
+	}
+
+	if speedKmh <= 0 {
+		speedKmh = DefaultSpeedKmh
+	}
+	metersPerSecond := speedKmh * 1000 / 3600
+	distances := haversineMatrix(locations)
+	durations := make([][]int64, n)
+	for i := range distances {
+		durations[i] = make([]int64, n)
+		for j := range distances[i] {
+			durations[i][j] = int64(math.Round(float64(distances[i][j]) / metersPerSecond))
+		}
+	}
+	return travelMatrix{distances: distances, durations: durations}
+}
+
+func haversineMatrix(locations [][2]float64) [][]int64 {
+	n := len(locations)
+	out := make([][]int64, n)
+	for i := 0; i < n; i++ {
+		out[i] = make([]int64, n)
+		for j := 0; j < n; j++ {
+			if i != j {
+				out[i][j] = int64(math.Round(HaversineMeters(locations[i], locations[j])))
+			}
+		}
+	}
+	return out
+}
+
+// HaversineMeters calcula la distancia en línea recta entre dos puntos [lon, lat]
+func HaversineMeters(a, b [2]float64) float64 {
+	lat1 := a[1] * math.Pi / 180
+	lat2 := b[1] * math.Pi / 180
+	dLat := (b[1] - a[1]) * math.Pi / 180
+	dLon := (b[0] - a[0]) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// profileMatrices retorna las matrices del perfil del primer vehículo; el solver usa una sola
+// matriz para toda la flota
+func profileMatrices(req model.VroomOptimizationRequest) *model.VroomProfileMatrices {
+	profile := model.VroomDefaultProfile
+	if len(req.Vehicles) > 0 {
+		profile = req.Vehicles[0].ProfileOrDefault()
+	}
+	matrices, ok := req.Matrices[profile]
+	if !ok {
+		return nil
+	}
+	return &matrices
+}
+
+func isSquare(matrix [][]int64, n int) bool {
+	if len(matrix) != n || n == 0 {
+		return false
+	}
+	for _, row := range matrix {
+		if len(row) != n {
+			return false
+		}
+	}
+	return true
+}
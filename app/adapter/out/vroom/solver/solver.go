@@ -0,0 +1,1013 @@
+package solver
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+	"transport-app/app/adapter/out/vroom/model"
+
+	"github.com/twpayne/go-polyline"
+)
+
+// Motivos de no asignación reportados en UnassignedJob.Reason
+const (
+	ReasonSkills     = "no vehicle with required skills"
+	ReasonCapacity   = "exceeds vehicle capacity"
+	ReasonTimeWindow = "no feasible time window"
+	ReasonNoRoute    = "no feasible insertion in any route"
+	// Límites de ruta del vehículo
+	ReasonMaxTasks      = "exceeds vehicle max tasks"
+	ReasonMaxTravelTime = "exceeds vehicle max travel time"
+	ReasonMaxDistance   = "exceeds vehicle max distance"
+)
+
+const (
+	stepJob      = "job"
+	stepPickup   = "pickup"
+	stepDelivery = "delivery"
+)
+
+// DefaultMaxDuration limita el tiempo de búsqueda local del solver
+const DefaultMaxDuration = 5 * time.Second
+
+// Options parametriza el solver embebido
+type Options struct {
+	// SpeedKmh se usa para estimar duraciones cuando no se entrega matriz
+	SpeedKmh float64
+	// MaxDuration limita la fase de búsqueda local
+	MaxDuration time.Duration
+}
+
+type stop struct {
+	kind     string
+	unit     int
+	stepID   int
+	loc      int
+	location [2]float64
+	service  int64
+	windows  [][]int
+}
+
+type unit struct {
+	kind     string
+	id       int
+	stops    []stop
+	amount   []int64
+	skills   []int64
+	priority int
+	urgency  int
+}
+
+type vehicle struct {
+	id        int
+	start     int
+	end       int
+	startLoc  *[2]float64
+	endLoc    *[2]float64
+	capacity  []int64
+	skills    map[int64]struct{}
+	window    []int
+	hasWindow bool
+	breaks    []vehicleBreak
+	// Límites de la ruta; cero significa sin límite
+	maxTasks      int
+	maxTravelTime int64
+	maxDistance   int64
+	costs         vehicleCosts
+}
+
+// vehicleCosts replica el modelo de costos de VROOM: fijo + por hora + por kilómetro
+type vehicleCosts struct {
+	fixed   int64
+	perHour int64
+	perKm   int64
+}
+
+// DefaultCostPerHour hace que el costo de viaje equivalga a la duración en segundos, como en VROOM
+const DefaultCostPerHour = 3600
+
+// travel retorna el costo de recorrer la duración (segundos) y distancia (metros) indicadas
+func (c vehicleCosts) travel(duration, distance int64) int64 {
+	return (c.perHour*duration+1800)/3600 + (c.perKm*distance+500)/1000
+}
+
+type vehicleBreak struct {
+	id      int
+	windows [][]int
+	service int64
+	maxLoad []int64
+}
+
+// plannedBreak es una pausa tomada antes de la parada before (len(stops) = antes del fin)
+type plannedBreak struct {
+	index   int
+	before  int
+	arrival int64
+	waiting int64
+}
+
+type route struct {
+	vehicle int
+	stops   []stop
+}
+
+type schedule struct {
+	departure int64
+	arrivals  []int64
+	waiting   []int64
+	loads     [][]int64
+	duration  int64
+	distance  int64
+	service   int64
+	waitTotal int64
+	arrivalAt int64
+	breaks    []plannedBreak
+}
+
+type problem struct {
+	units    []unit
+	vehicles []vehicle
+	matrix   travelMatrix
+	// ignoreLimits desactiva los límites de ruta para diagnosticar no asignaciones
+	ignoreLimits bool
+	// closed es la ruta que se intenta vaciar y no acepta inserciones (-1 si ninguna)
+	closed int
+}
+
+// Solve resuelve un VRP con capacidades, skills, ventanas horarias y pares pickup/delivery.
+// Construye una solución por inserción de menor costo y luego la mejora con búsqueda local
+// (relocate y 2-opt) hasta agotar el presupuesto de tiempo. La respuesta imita el formato de VROOM.
+func Solve(ctx context.Context, req model.VroomOptimizationRequest, opts Options) model.VroomOptimizationResponse {
+	if opts.MaxDuration <= 0 {
+		opts.MaxDuration = DefaultMaxDuration
+	}
+	deadline := time.Now().Add(opts.MaxDuration)
+
+	p := newProblem(req, opts)
+	routes := make([]route, len(p.vehicles))
+	for i := range routes {
+		routes[i] = route{vehicle: i}
+	}
+
+	order := make([]int, len(p.units))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ua, ub := p.units[order[a]], p.units[order[b]]
+		if ua.priority != ub.priority {
+			return ua.priority > ub.priority
+		}
+		return ua.urgency < ub.urgency
+	})
+
+	var unassigned []int
+	for _, u := range order {
+		if !p.insertBest(routes, u) {
+			unassigned = append(unassigned, u)
+		}
+	}
+
+	unassigned = p.improve(ctx, routes, unassigned, deadline)
+
+	return p.buildResponse(routes, unassigned)
+}
+
+func newProblem(req model.VroomOptimizationRequest, opts Options) *problem {
+	locations := Locations(req)
+	index := make(map[[2]float64]int, len(locations))
+	for i, loc := range locations {
+		index[loc] = i
+	}
+
+	p := &problem{
+		matrix: buildTravelMatrix(locations, profileMatrices(req), opts.SpeedKmh),
+		closed: -1,
+	}
+
+	for _, v := range req.Vehicles {
+		veh := vehicle{
+			id:       v.ID,
+			start:    -1,
+			end:      -1,
+			startLoc: v.Start,
+			endLoc:   v.End,
+			capacity: v.Capacity,
+			skills:   make(map[int64]struct{}, len(v.Skills)),
+
+			maxTasks:      v.MaxTasks,
+			maxTravelTime: v.MaxTravelTime,
+			maxDistance:   v.MaxDistance,
+			costs:         vehicleCosts{perHour: DefaultCostPerHour},
+		}
+		if v.Costs != nil {
+			veh.costs.fixed = v.Costs.Fixed
+			veh.costs.perKm = v.Costs.PerKm
+			if v.Costs.PerHour > 0 {
+				veh.costs.perHour = v.Costs.PerHour
+			}
+		}
+		if v.Start != nil {
+			veh.start = index[*v.Start]
+		}
+		if v.End != nil {
+			veh.end = index[*v.End]
+		}
+		for _, s := range v.Skills {
+			veh.skills[s] = struct{}{}
+		}
+		if len(v.TimeWindow) == 2 {
+			veh.window = v.TimeWindow
+			veh.hasWindow = true
+		}
+		for _, b := range v.Breaks {
+			veh.breaks = append(veh.breaks, vehicleBreak{
+				id:      b.ID,
+				windows: b.TimeWindows,
+				service: b.Service,
+				maxLoad: b.MaxLoad,
+			})
+		}
+		sort.SliceStable(veh.breaks, func(a, b int) bool {
+			return earliestOpen(veh.breaks[a].windows) < earliestOpen(veh.breaks[b].windows)
+		})
+		p.vehicles = append(p.vehicles, veh)
+	}
+
+	for _, j := range req.Jobs {
+		u := unit{
+			kind:     stepJob,
+			id:       j.ID,
+			amount:   j.Amount,
+			skills:   j.Skills,
+			priority: j.Priority,
+			urgency:  latestEnd(j.TimeWindows),
+		}
+		u.stops = []stop{{
+			kind:     stepJob,
+			unit:     len(p.units),
+			stepID:   j.ID,
+			loc:      index[j.Location],
+			location: j.Location,
+			service:  j.Service,
+			windows:  j.TimeWindows,
+		}}
+		p.units = append(p.units, u)
+	}
+
+	for _, s := range req.Shipments {
+		if s.Pickup.Location == nil || s.Delivery.Location == nil {
+			continue
+		}
+		pickupWindows := s.Pickup.TimeWindows
+		deliveryWindows := s.Delivery.TimeWindows
+		if len(deliveryWindows) == 0 {
+			deliveryWindows = s.TimeWindows
+		}
+		// El servicio de cada paso se cumple en su parada; sin servicio por paso, el del
+		// shipment se cumple en la entrega
+		pickupService, deliveryService := s.Pickup.Service, s.Delivery.Service
+		if pickupService == 0 && deliveryService == 0 {
+			deliveryService = s.Service
+		}
+		u := unit{
+			kind:     "shipment",
+			id:       s.ID,
+			amount:   s.Amount,
+			skills:   s.Skills,
+			priority: s.Priority,
+			urgency:  latestEnd(deliveryWindows),
+		}
+		u.stops = []stop{
+			{
+				kind:     stepPickup,
+				unit:     len(p.units),
+				stepID:   s.Pickup.ID,
+				loc:      index[*s.Pickup.Location],
+				location: *s.Pickup.Location,
+				service:  pickupService,
+				windows:  pickupWindows,
+			},
+			{
+				kind:     stepDelivery,
+				unit:     len(p.units),
+				stepID:   s.Delivery.ID,
+				loc:      index[*s.Delivery.Location],
+				location: *s.Delivery.Location,
+				service:  deliveryService,
+				windows:  deliveryWindows,
+			},
+		}
+		p.units = append(p.units, u)
+	}
+
+	return p
+}
+
+func earliestOpen(windows [][]int) int {
+	if len(windows) == 0 {
+		return 0
+	}
+	start := math.MaxInt32
+	for _, w := range windows {
+		if len(w) == 2 && w[0] < start {
+			start = w[0]
+		}
+	}
+	return start
+}
+
+func latestEnd(windows [][]int) int {
+	if len(windows) == 0 {
+		return math.MaxInt32
+	}
+	end := 0
+	for _, w := range windows {
+		if len(w) == 2 && w[1] > end {
+			end = w[1]
+		}
+	}
+	return end
+}
+
+// compatible indica si el vehículo tiene todas las skills requeridas por la unidad
+func (p *problem) compatible(v int, u int) bool {
+	for _, s := range p.units[u].skills {
+		if _, ok := p.vehicles[v].skills[s]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate calcula la planificación de una secuencia y verifica su factibilidad
+func (p *problem) evaluate(v int, stops []stop) (schedule, bool) {
+	veh := p.vehicles[v]
+	sched := schedule{
+		arrivals: make([]int64, len(stops)),
+		waiting:  make([]int64, len(stops)),
+		loads:    make([][]int64, len(stops)),
+	}
+
+	if !p.ignoreLimits && veh.maxTasks > 0 && len(stops) > veh.maxTasks {
+		return sched, false
+	}
+
+	dims := len(veh.capacity)
+	load := make([]int64, dims)
+	for _, s := range stops {
+		if s.kind == stepJob {
+			addAmount(load, p.units[s.unit].amount, 1)
+		}
+	}
+	if !fits(load, veh.capacity) {
+		return sched, false
+	}
+
+	var clock int64
+	if veh.hasWindow {
+		clock = int64(veh.window[0])
+	}
+	sched.departure = clock
+
+	// takeBreaks programa las pausas pendientes en la ubicación actual. Una pausa se toma
+	// si su ventana abre antes de poder iniciar el servicio de la siguiente parada o si es el fin de la ruta.
+	pending := 0
+	takeBreaks := func(before int, travel int64, windows [][]int, final bool) bool {
+		for pending < len(veh.breaks) {
+			b := veh.breaks[pending]
+			start, ok := earliestStart(clock, b.windows)
+			if !ok {
+				return false
+			}
+			nextBegin, ok := earliestStart(clock+travel, windows)
+			if !ok {
+				nextBegin = clock + travel
+			}
+			if !final && start > nextBegin {
+				return true
+			}
+			if !fitsMaxLoad(load, b.maxLoad) {
+				return !final
+			}
+			sched.breaks = append(sched.breaks, plannedBreak{
+				index:   pending,
+				before:  before,
+				arrival: clock,
+				waiting: start - clock,
+			})
+			sched.waitTotal += start - clock
+			sched.service += b.service
+			clock = start + b.service
+			pending++
+		}
+		return true
+	}
+
+	picked := make(map[int]bool)
+	prev := veh.start
+	for i, s := range stops {
+		if !takeBreaks(i, p.matrix.duration(prev, s.loc), s.windows, false) {
+			return sched, false
+		}
+
+		switch s.kind {
+		case stepPickup:
+			picked[s.unit] = true
+			addAmount(load, p.units[s.unit].amount, 1)
+		case stepDelivery:
+			if !picked[s.unit] {
+				return sched, false
+			}
+			addAmount(load, p.units[s.unit].amount, -1)
+		case stepJob:
+			addAmount(load, p.units[s.unit].amount, -1)
+		}
+		if !fits(load, veh.capacity) {
+			return sched, false
+		}
+
+		travel := p.matrix.duration(prev, s.loc)
+		sched.duration += travel
+		sched.distance += p.matrix.distance(prev, s.loc)
+		arrival := clock + travel
+		begin, ok := earliestStart(arrival, s.windows)
+		if !ok {
+			return sched, false
+		}
+		sched.arrivals[i] = arrival
+		sched.waiting[i] = begin - arrival
+		sched.waitTotal += begin - arrival
+		sched.service += s.service
+		sched.loads[i] = append([]int64(nil), load...)
+		clock = begin + s.service
+		prev = s.loc
+	}
+
+	if !takeBreaks(len(stops), 0, nil, true) {
+		return sched, false
+	}
+
+	travel := p.matrix.duration(prev, veh.end)
+	sched.duration += travel
+	sched.distance += p.matrix.distance(prev, veh.end)
+	clock += travel
+	if veh.hasWindow && clock > int64(veh.window[1]) {
+		return sched, false
+	}
+	if !p.ignoreLimits && p.exceededLimit(v, len(stops), sched) != "" {
+		return sched, false
+	}
+	sched.arrivalAt = clock
+	return sched, true
+}
+
+// exceededLimit retorna el motivo del primer límite de ruta excedido o vacío si no hay ninguno
+func (p *problem) exceededLimit(v int, tasks int, sched schedule) string {
+	veh := p.vehicles[v]
+	switch {
+	case veh.maxTasks > 0 && tasks > veh.maxTasks:
+		return ReasonMaxTasks
+	case veh.maxTravelTime > 0 && sched.duration > veh.maxTravelTime:
+		return ReasonMaxTravelTime
+	case veh.maxDistance > 0 && sched.distance > veh.maxDistance:
+		return ReasonMaxDistance
+	}
+	return ""
+}
+
+// earliestStart retorna el inicio más temprano dentro de alguna ventana que termine después de la llegada
+func earliestStart(arrival int64, windows [][]int) (int64, bool) {
+	if len(windows) == 0 {
+		return arrival, true
+	}
+	best := int64(-1)
+	for _, w := range windows {
+		if len(w) != 2 || int64(w[1]) < arrival {
+			continue
+		}
+		start := arrival
+		if int64(w[0]) > start {
+			start = int64(w[0])
+		}
+		if best < 0 || start < best {
+			best = start
+		}
+	}
+	return best, best >= 0
+}
+
+func addAmount(load []int64, amount []int64, sign int64) {
+	for k := 0; k < len(load) && k < len(amount); k++ {
+		load[k] += sign * amount[k]
+	}
+}
+
+func fitsMaxLoad(load []int64, maxLoad []int64) bool {
+	for k := 0; k < len(load) && k < len(maxLoad); k++ {
+		if load[k] > maxLoad[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func fits(load []int64, capacity []int64) bool {
+	for k := range load {
+		if load[k] > capacity[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// routeCost es el costo de una ruta factible: costo fijo del vehículo más el costo de viaje.
+// Una ruta vacía no usa el vehículo y no tiene costo.
+func (p *problem) routeCost(v int, stops []stop) (int64, bool) {
+	if len(stops) == 0 {
+		return 0, true
+	}
+	sched, ok := p.evaluate(v, stops)
+	if !ok {
+		return 0, false
+	}
+	costs := p.vehicles[v].costs
+	return costs.fixed + costs.travel(sched.duration, sched.distance), true
+}
+
+// totalCost suma el costo de todas las rutas
+func (p *problem) totalCost(routes []route) int64 {
+	var total int64
+	for _, r := range routes {
+		cost, _ := p.routeCost(r.vehicle, r.stops)
+		total += cost
+	}
+	return total
+}
+
+type candidate struct {
+	route int
+	first int
+	last  int
+	delta int64
+}
+
+// insertBest inserta la unidad en la posición factible de menor costo incremental
+func (p *problem) insertBest(routes []route, u int) bool {
+	best, ok := p.bestInsertion(routes, u)
+	if !ok {
+		return false
+	}
+	routes[best.route].stops = p.insertAt(routes[best.route].stops, u, best.first, best.last)
+	return true
+}
+
+func (p *problem) bestInsertion(routes []route, u int) (candidate, bool) {
+	var candidates []candidate
+	for r := range routes {
+		if r == p.closed || !p.compatible(routes[r].vehicle, u) {
+			continue
+		}
+		candidates = append(candidates, p.insertionCandidates(routes[r], r, u)...)
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].delta < candidates[b].delta
+	})
+
+	for _, c := range candidates {
+		stops := p.insertAt(routes[c.route].stops, u, c.first, c.last)
+		if _, ok := p.evaluate(routes[c.route].vehicle, stops); ok {
+			return c, true
+		}
+	}
+	return candidate{}, false
+}
+
+// insertionCandidates estima el costo incremental de cada posición posible, incluyendo
+// el costo fijo cuando la inserción activa un vehículo sin uso
+func (p *problem) insertionCandidates(r route, routeIndex int, u int) []candidate {
+	veh := p.vehicles[r.vehicle]
+	arc := func(from, to int) int64 {
+		return veh.costs.travel(p.matrix.duration(from, to), p.matrix.distance(from, to))
+	}
+	var activation int64
+	if len(r.stops) == 0 {
+		activation = veh.costs.fixed
+	}
+	locAt := func(i int) int {
+		if i < 0 {
+			return veh.start
+		}
+		if i >= len(r.stops) {
+			return veh.end
+		}
+		return r.stops[i].loc
+	}
+	detour := func(i int, loc int) int64 {
+		prev, next := locAt(i-1), locAt(i)
+		return arc(prev, loc) + arc(loc, next) - arc(prev, next)
+	}
+
+	n := len(r.stops)
+	un := p.units[u]
+	var out []candidate
+	if len(un.stops) == 1 {
+		for i := 0; i <= n; i++ {
+			out = append(out, candidate{route: routeIndex, first: i, last: -1, delta: activation + detour(i, un.stops[0].loc)})
+		}
+		return out
+	}
+
+	pickup, delivery := un.stops[0].loc, un.stops[1].loc
+	for i := 0; i <= n; i++ {
+		for j := i; j <= n; j++ {
+			var delta int64
+			if i == j {
+				prev, next := locAt(i-1), locAt(i)
+				delta = arc(prev, pickup) + arc(pickup, delivery) + arc(delivery, next) - arc(prev, next)
+			} else {
+				delta = detour(i, pickup) + detour(j, delivery)
+			}
+			out = append(out, candidate{route: routeIndex, first: i, last: j, delta: activation + delta})
+		}
+	}
+	return out
+}
+
+// insertAt retorna una copia de la secuencia con la unidad insertada. Para shipments,
+// first y last son posiciones en la secuencia original (pickup antes de delivery).
+func (p *problem) insertAt(stops []stop, u int, first, last int) []stop {
+	un := p.units[u]
+	out := make([]stop, 0, len(stops)+len(un.stops))
+	if len(un.stops) == 1 {
+		out = append(out, stops[:first]...)
+		out = append(out, un.stops[0])
+		return append(out, stops[first:]...)
+	}
+	out = append(out, stops[:first]...)
+	out = append(out, un.stops[0])
+	out = append(out, stops[first:last]...)
+	out = append(out, un.stops[1])
+	return append(out, stops[last:]...)
+}
+
+func removeUnit(stops []stop, u int) []stop {
+	out := make([]stop, 0, len(stops))
+	for _, s := range stops {
+		if s.unit != u {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// improve aplica relocate y 2-opt mientras haya mejoras y quede presupuesto de tiempo
+func (p *problem) improve(ctx context.Context, routes []route, unassigned []int, deadline time.Time) []int {
+	expired := func() bool {
+		return time.Now().After(deadline) || ctx.Err() != nil
+	}
+
+	for improved := true; improved && !expired(); {
+		improved = false
+
+		for u := range p.units {
+			if expired() {
+				break
+			}
+			from := routeOf(routes, u)
+			if from < 0 {
+				continue
+			}
+			before, _ := p.routeCost(routes[from].vehicle, routes[from].stops)
+			reduced := removeUnit(routes[from].stops, u)
+			after, ok := p.routeCost(routes[from].vehicle, reduced)
+			if !ok {
+				continue
+			}
+			original := routes[from].stops
+			routes[from].stops = reduced
+			best, found := p.bestInsertion(routes, u)
+			if !found {
+				routes[from].stops = original
+				continue
+			}
+			target := routes[best.route]
+			targetBefore, _ := p.routeCost(target.vehicle, target.stops)
+			inserted := p.insertAt(target.stops, u, best.first, best.last)
+			targetAfter, _ := p.routeCost(target.vehicle, inserted)
+			if after+targetAfter-targetBefore < before {
+				routes[best.route].stops = inserted
+				improved = true
+				continue
+			}
+			routes[from].stops = original
+		}
+
+		for r := range routes {
+			if expired() {
+				break
+			}
+			if p.twoOpt(&routes[r]) {
+				improved = true
+			}
+		}
+
+		for r := range routes {
+			if expired() {
+				break
+			}
+			if p.eliminateRoute(routes, r) {
+				improved = true
+			}
+		}
+
+		var pending []int
+		for _, u := range unassigned {
+			if p.insertBest(routes, u) {
+				improved = true
+				continue
+			}
+			pending = append(pending, u)
+		}
+		unassigned = pending
+	}
+	return unassigned
+}
+
+// eliminateRoute intenta repartir todas las unidades de la ruta en las demás. Se acepta
+// si el costo total baja, típicamente por ahorrar el costo fijo del vehículo.
+func (p *problem) eliminateRoute(routes []route, r int) bool {
+	if len(routes[r].stops) == 0 {
+		return false
+	}
+	before := p.totalCost(routes)
+	snapshot := make([][]stop, len(routes))
+	for i := range routes {
+		snapshot[i] = routes[i].stops
+	}
+	restore := func() {
+		for i := range routes {
+			routes[i].stops = snapshot[i]
+		}
+	}
+
+	var units []int
+	seen := make(map[int]bool)
+	for _, s := range routes[r].stops {
+		if !seen[s.unit] {
+			seen[s.unit] = true
+			units = append(units, s.unit)
+		}
+	}
+
+	p.closed = r
+	defer func() { p.closed = -1 }()
+	routes[r].stops = nil
+	for _, u := range units {
+		if !p.insertBest(routes, u) {
+			restore()
+			return false
+		}
+	}
+	if p.totalCost(routes) >= before {
+		restore()
+		return false
+	}
+	return true
+}
+
+// twoOpt invierte segmentos de la ruta cuando reduce el costo y mantiene la factibilidad
+func (p *problem) twoOpt(r *route) bool {
+	improved := false
+	current, ok := p.routeCost(r.vehicle, r.stops)
+	if !ok {
+		return false
+	}
+	n := len(r.stops)
+	for i := 0; i < n-1; i++ {
+		for j := i + 1; j < n; j++ {
+			candidate := make([]stop, n)
+			copy(candidate, r.stops)
+			for a, b := i, j; a < b; a, b = a+1, b-1 {
+				candidate[a], candidate[b] = candidate[b], candidate[a]
+			}
+			cost, ok := p.routeCost(r.vehicle, candidate)
+			if ok && cost < current {
+				r.stops = candidate
+				current = cost
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+func routeOf(routes []route, u int) int {
+	for r := range routes {
+		for _, s := range routes[r].stops {
+			if s.unit == u {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+// unassignedReason explica por qué una unidad no pudo asignarse
+func (p *problem) unassignedReason(routes []route, u int) string {
+	compatible := false
+	fitsSomeVehicle := false
+	for v := range p.vehicles {
+		if !p.compatible(v, u) {
+			continue
+		}
+		compatible = true
+		load := make([]int64, len(p.vehicles[v].capacity))
+		addAmount(load, p.units[u].amount, 1)
+		if fits(load, p.vehicles[v].capacity) {
+			fitsSomeVehicle = true
+		}
+	}
+	switch {
+	case !compatible:
+		return ReasonSkills
+	case !fitsSomeVehicle:
+		return ReasonCapacity
+	}
+	if reason := p.limitReason(routes, u); reason != "" {
+		return reason
+	}
+	for _, s := range p.units[u].stops {
+		if len(s.windows) > 0 {
+			return ReasonTimeWindow
+		}
+	}
+	return ReasonNoRoute
+}
+
+// limitReason indica qué límite de ruta impidió la asignación: si la unidad cabe en alguna
+// ruta ignorando los límites, el motivo es el límite que esa inserción excede.
+func (p *problem) limitReason(routes []route, u int) string {
+	p.ignoreLimits = true
+	best, ok := p.bestInsertion(routes, u)
+	p.ignoreLimits = false
+	if !ok {
+		return ""
+	}
+	r := routes[best.route]
+	stops := p.insertAt(r.stops, u, best.first, best.last)
+	p.ignoreLimits = true
+	sched, _ := p.evaluate(r.vehicle, stops)
+	p.ignoreLimits = false
+	return p.exceededLimit(r.vehicle, len(stops), sched)
+}
+
+func (p *problem) buildResponse(routes []route, unassigned []int) model.VroomOptimizationResponse {
+	resp := model.VroomOptimizationResponse{}
+	summary := model.Summary{}
+
+	for _, r := range routes {
+		if len(r.stops) == 0 {
+			continue
+		}
+		built := p.buildRoute(r)
+		summary.Cost += built.Cost
+		summary.Service += built.Service
+		summary.Duration += built.Duration
+		summary.WaitingTime += built.WaitingTime
+		summary.Distance += built.Distance
+		resp.Routes = append(resp.Routes, built)
+	}
+	summary.Routes = int64(len(resp.Routes))
+
+	summary.Unassigned = int64(len(unassigned))
+	resp.Summary = &summary
+
+	sort.Ints(unassigned)
+	for _, u := range unassigned {
+		reason := p.unassignedReason(routes, u)
+		for _, s := range p.units[u].stops {
+			id := p.units[u].id
+			if s.kind != stepJob {
+				id = s.stepID
+			}
+			resp.Unassigned = append(resp.Unassigned, model.UnassignedJob{
+				ID:       int64(id),
+				Type:     s.kind,
+				Location: s.location,
+				Reason:   reason,
+			})
+		}
+	}
+	return resp
+}
+
+func (p *problem) buildRoute(r route) model.Route {
+	veh := p.vehicles[r.vehicle]
+	sched, _ := p.evaluate(r.vehicle, r.stops)
+	cost, _ := p.routeCost(r.vehicle, r.stops)
+
+	out := model.Route{
+		Vehicle:     int64(veh.id),
+		Cost:        cost,
+		Service:     sched.service,
+		Duration:    sched.duration,
+		WaitingTime: sched.waitTotal,
+		Distance:    sched.distance,
+	}
+
+	var coords [][]float64
+	initialLoad := make([]int64, len(veh.capacity))
+	for _, s := range r.stops {
+		if s.kind == stepJob {
+			addAmount(initialLoad, p.units[s.unit].amount, 1)
+		}
+	}
+
+	if veh.startLoc != nil {
+		out.Steps = append(out.Steps, model.Step{
+			Type:     "start",
+			Arrival:  sched.departure,
+			Location: *veh.startLoc,
+			Load:     initialLoad,
+		})
+		coords = append(coords, []float64{veh.startLoc[1], veh.startLoc[0]})
+	}
+
+	var elapsed, distance int64
+	prev := veh.start
+	lastLocation := [2]float64{}
+	if veh.startLoc != nil {
+		lastLocation = *veh.startLoc
+	}
+	appendBreaks := func(before int) {
+		for _, pb := range sched.breaks {
+			if pb.before != before {
+				continue
+			}
+			b := veh.breaks[pb.index]
+			out.Steps = append(out.Steps, model.Step{
+				Type:        "break",
+				ID:          int64(b.id),
+				Arrival:     pb.arrival,
+				Duration:    elapsed,
+				Service:     b.service,
+				WaitingTime: pb.waiting,
+				Location:    lastLocation,
+				Distance:    distance,
+			})
+		}
+	}
+
+	for i, s := range r.stops {
+		appendBreaks(i)
+		elapsed += p.matrix.duration(prev, s.loc)
+		distance += p.matrix.distance(prev, s.loc)
+		prev = s.loc
+		un := p.units[s.unit]
+		step := model.Step{
+			Type:        s.kind,
+			Arrival:     sched.arrivals[i],
+			Duration:    elapsed,
+			Service:     s.service,
+			WaitingTime: sched.waiting[i],
+			Location:    s.location,
+			Load:        sched.loads[i],
+			Distance:    distance,
+		}
+		switch s.kind {
+		case stepJob:
+			step.Job = int64(un.id)
+		case stepPickup:
+			step.Shipment = int64(un.id)
+			step.Pickup = int64(s.stepID)
+		case stepDelivery:
+			step.Shipment = int64(un.id)
+			step.Delivery = int64(s.stepID)
+		}
+		out.Priority += float64(un.priority)
+		out.Steps = append(out.Steps, step)
+		coords = append(coords, []float64{s.location[1], s.location[0]})
+		lastLocation = s.location
+	}
+	appendBreaks(len(r.stops))
+
+	if veh.endLoc != nil {
+		elapsed += p.matrix.duration(prev, veh.end)
+		distance += p.matrix.distance(prev, veh.end)
+		out.Steps = append(out.Steps, model.Step{
+			Type:     "end",
+			Arrival:  sched.arrivalAt,
+			Duration: elapsed,
+			Location: *veh.endLoc,
+			Distance: distance,
+		})
+		coords = append(coords, []float64{veh.endLoc[1], veh.endLoc[0]})
+	}
+
+	if len(coords) > 1 {
+		out.Geometry = string(polyline.EncodeCoords(coords))
+	}
+	return out
+}
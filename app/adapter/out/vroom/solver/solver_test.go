@@ -0,0 +1,450 @@
+package solver
+
+import (
+	"context"
+	"transport-app/app/adapter/out/vroom/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Solve", func() {
+	var (
+		ctx   context.Context
+		depot = [2]float64{-70.6500, -33.4400}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	newVehicle := func(id int, capacity []int64, skills ...int64) model.VroomVehicle {
+		return model.VroomVehicle{
+			ID:       id,
+			Start:    &depot,
+			End:      &depot,
+			Capacity: capacity,
+			Skills:   skills,
+		}
+	}
+
+	newJob := func(id int, lon, lat float64, amount []int64) model.VroomJob {
+		return model.VroomJob{
+			ID:       id,
+			Location: [2]float64{lon, lat},
+			Amount:   amount,
+			Service:  60,
+		}
+	}
+
+	assignedJobs := func(resp model.VroomOptimizationResponse) []int64 {
+		var ids []int64
+		for _, r := range resp.Routes {
+			for _, s := range r.Steps {
+				if s.Type == "job" {
+					ids = append(ids, s.Job)
+				}
+			}
+		}
+		return ids
+	}
+
+	It("should assign every job when capacity is enough", func() {
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{newVehicle(1, []int64{10})},
+			Jobs: []model.VroomJob{
+				newJob(1, -70.6400, -33.4300, []int64{1}),
+				newJob(2, -70.6300, -33.4200, []int64{1}),
+				newJob(3, -70.6600, -33.4500, []int64{1}),
+			},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Unassigned).To(BeEmpty())
+		Expect(resp.Routes).To(HaveLen(1))
+		Expect(assignedJobs(resp)).To(ConsistOf(int64(1), int64(2), int64(3)))
+		Expect(resp.Routes[0].Steps[0].Type).To(Equal("start"))
+		Expect(resp.Routes[0].Steps[len(resp.Routes[0].Steps)-1].Type).To(Equal("end"))
+		Expect(resp.Routes[0].Geometry).ToNot(BeEmpty())
+	})
+
+	It("should split jobs across vehicles when capacity is exceeded", func() {
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{
+				newVehicle(1, []int64{2}),
+				newVehicle(2, []int64{2}),
+			},
+			Jobs: []model.VroomJob{
+				newJob(1, -70.6400, -33.4300, []int64{1}),
+				newJob(2, -70.6300, -33.4200, []int64{1}),
+				newJob(3, -70.6600, -33.4500, []int64{1}),
+				newJob(4, -70.6700, -33.4600, []int64{1}),
+			},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Unassigned).To(BeEmpty())
+		Expect(resp.Routes).To(HaveLen(2))
+		for _, r := range resp.Routes {
+			for _, s := range r.Steps {
+				for _, l := range s.Load {
+					Expect(l).To(BeNumerically("<=", 2))
+				}
+			}
+		}
+	})
+
+	It("should leave a job unassigned when no vehicle has its skills", func() {
+		job := newJob(1, -70.6400, -33.4300, []int64{1})
+		job.Skills = []int64{7}
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{newVehicle(1, []int64{10}, 3)},
+			Jobs:     []model.VroomJob{job},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Routes).To(BeEmpty())
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonSkills))
+	})
+
+	It("should report capacity as the reason when a job exceeds every vehicle", func() {
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{newVehicle(1, []int64{1})},
+			Jobs:     []model.VroomJob{newJob(1, -70.6400, -33.4300, []int64{5})},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonCapacity))
+	})
+
+	It("should respect job time windows", func() {
+		early := newJob(1, -70.6400, -33.4300, nil)
+		early.TimeWindows = [][]int{{8 * 3600, 9 * 3600}}
+		late := newJob(2, -70.6300, -33.4200, nil)
+		late.TimeWindows = [][]int{{15 * 3600, 16 * 3600}}
+		vehicle := newVehicle(1, nil)
+		vehicle.TimeWindow = []int{7 * 3600, 18 * 3600}
+
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs:     []model.VroomJob{late, early},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Unassigned).To(BeEmpty())
+		Expect(assignedJobs(resp)).To(Equal([]int64{1, 2}))
+		for _, s := range resp.Routes[0].Steps {
+			if s.Job == 2 {
+				Expect(s.Arrival + s.WaitingTime).To(BeNumerically(">=", 15*3600))
+			}
+		}
+	})
+
+	It("should leave a job unassigned when its time window cannot be met", func() {
+		job := newJob(1, -70.6400, -33.4300, nil)
+		job.TimeWindows = [][]int{{6 * 3600, 6*3600 + 60}}
+		vehicle := newVehicle(1, nil)
+		vehicle.TimeWindow = []int{9 * 3600, 18 * 3600}
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs:     []model.VroomJob{job},
+		}, Options{})
+
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonTimeWindow))
+	})
+
+	It("should visit the pickup before the delivery of a shipment on the same route", func() {
+		pickup := [2]float64{-70.6000, -33.4000}
+		delivery := [2]float64{-70.6800, -33.4800}
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{newVehicle(1, []int64{1})},
+			Shipments: []model.VroomShipment{{
+				ID:       1,
+				Amount:   []int64{1},
+				Pickup:   model.VroomStep{ID: 10, Location: &pickup},
+				Delivery: model.VroomStep{ID: 11, Location: &delivery},
+			}},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Unassigned).To(BeEmpty())
+		var kinds []string
+		for _, s := range resp.Routes[0].Steps {
+			kinds = append(kinds, s.Type)
+		}
+		Expect(kinds).To(Equal([]string{"start", "pickup", "delivery", "end"}))
+	})
+
+	It("should use the supplied duration matrix when it matches the locations", func() {
+		req := model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{newVehicle(1, nil)},
+			Jobs:     []model.VroomJob{newJob(1, -70.6400, -33.4300, nil)},
+		}
+		req.Matrices = model.VroomMatrices{
+			model.VroomDefaultProfile: {Durations: [][]int64{{0, 100}, {100, 0}}},
+		}
+
+		resp := Solve(ctx, req, Options{})
+
+		Expect(resp.Routes).To(HaveLen(1))
+		Expect(resp.Routes[0].Duration).To(Equal(int64(200)))
+	})
+
+	It("should schedule a driver break inside its time window", func() {
+		vehicle := newVehicle(1, nil)
+		vehicle.TimeWindow = []int{8 * 3600, 18 * 3600}
+		vehicle.Breaks = []model.VroomBreak{{
+			ID:          1,
+			TimeWindows: [][]int{{12 * 3600, 14 * 3600}},
+			Service:     1800,
+		}}
+		morning := newJob(1, -70.6400, -33.4300, nil)
+		morning.TimeWindows = [][]int{{9 * 3600, 10 * 3600}}
+		afternoon := newJob(2, -70.6300, -33.4200, nil)
+		afternoon.TimeWindows = [][]int{{15 * 3600, 16 * 3600}}
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs:     []model.VroomJob{morning, afternoon},
+		}, Options{})
+
+		Expect(resp.Unassigned).To(BeEmpty())
+		var kinds []string
+		for _, s := range resp.Routes[0].Steps {
+			kinds = append(kinds, s.Type)
+			if s.Type == "break" {
+				Expect(s.ID).To(Equal(int64(1)))
+				Expect(s.Arrival + s.WaitingTime).To(BeNumerically(">=", 12*3600))
+				Expect(s.Arrival + s.WaitingTime).To(BeNumerically("<=", 14*3600))
+			}
+		}
+		Expect(kinds).To(Equal([]string{"start", "job", "break", "job", "end"}))
+	})
+
+	It("should leave the route unused when its break cannot be honored", func() {
+		vehicle := newVehicle(1, []int64{10})
+		vehicle.Breaks = []model.VroomBreak{{
+			ID:          1,
+			TimeWindows: [][]int{{0, 60}},
+			Service:     600,
+			MaxLoad:     []int64{0},
+		}}
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs:     []model.VroomJob{newJob(1, -70.6400, -33.4300, []int64{1})},
+		}, Options{})
+
+		Expect(resp.Routes).To(BeEmpty())
+		Expect(resp.Unassigned).To(HaveLen(1))
+	})
+
+	It("should leave lower priority jobs unassigned first when capacity is short", func() {
+		regular := newJob(1, -70.6400, -33.4300, []int64{1})
+		express := newJob(2, -70.6000, -33.4000, []int64{1})
+		express.Priority = 100
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{newVehicle(1, []int64{1})},
+			Jobs:     []model.VroomJob{regular, express},
+		}, Options{})
+
+		Expect(assignedJobs(resp)).To(Equal([]int64{2}))
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].ID).To(Equal(int64(1)))
+	})
+
+	It("should respect the vehicle max tasks and report it as the reason", func() {
+		vehicle := newVehicle(1, nil)
+		vehicle.MaxTasks = 2
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs: []model.VroomJob{
+				newJob(1, -70.6400, -33.4300, nil),
+				newJob(2, -70.6300, -33.4200, nil),
+				newJob(3, -70.6600, -33.4500, nil),
+			},
+		}, Options{})
+
+		Expect(assignedJobs(resp)).To(HaveLen(2))
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonMaxTasks))
+	})
+
+	It("should count pickup and delivery as two tasks", func() {
+		pickup := [2]float64{-70.6000, -33.4000}
+		delivery := [2]float64{-70.6800, -33.4800}
+		vehicle := newVehicle(1, []int64{1})
+		vehicle.MaxTasks = 1
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Shipments: []model.VroomShipment{{
+				ID:       1,
+				Amount:   []int64{1},
+				Pickup:   model.VroomStep{ID: 10, Location: &pickup},
+				Delivery: model.VroomStep{ID: 11, Location: &delivery},
+			}},
+		}, Options{})
+
+		Expect(resp.Routes).To(BeEmpty())
+		Expect(resp.Unassigned).To(HaveLen(2))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonMaxTasks))
+	})
+
+	It("should respect the vehicle max travel time", func() {
+		vehicle := newVehicle(1, nil)
+		vehicle.MaxTravelTime = 600
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs: []model.VroomJob{
+				newJob(1, -70.6490, -33.4390, nil),
+				newJob(2, -70.3000, -33.1000, nil),
+			},
+		}, Options{})
+
+		Expect(assignedJobs(resp)).To(Equal([]int64{1}))
+		Expect(resp.Routes[0].Duration).To(BeNumerically("<=", 600))
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonMaxTravelTime))
+	})
+
+	It("should respect the vehicle max distance", func() {
+		vehicle := newVehicle(1, nil)
+		vehicle.MaxDistance = 5000
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs: []model.VroomJob{
+				newJob(1, -70.6490, -33.4390, nil),
+				newJob(2, -70.3000, -33.1000, nil),
+			},
+		}, Options{})
+
+		Expect(assignedJobs(resp)).To(Equal([]int64{1}))
+		Expect(resp.Unassigned).To(HaveLen(1))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonMaxDistance))
+	})
+
+	It("should use a single vehicle when the fixed cost outweighs the detour", func() {
+		expensive := func(id int) model.VroomVehicle {
+			v := newVehicle(id, nil)
+			v.Costs = &model.VroomCosts{Fixed: 100000}
+			return v
+		}
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{expensive(1), expensive(2)},
+			Jobs: []model.VroomJob{
+				newJob(1, -70.6000, -33.4400, nil),
+				newJob(2, -70.7000, -33.4400, nil),
+			},
+		}, Options{})
+
+		Expect(resp.Unassigned).To(BeEmpty())
+		Expect(resp.Routes).To(HaveLen(1))
+		Expect(resp.Routes[0].Cost).To(BeNumerically(">", 100000))
+	})
+
+	It("should report route costs and the summary", func() {
+		vehicle := newVehicle(1, nil)
+		vehicle.Costs = &model.VroomCosts{Fixed: 500, PerKm: 1000}
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Jobs:     []model.VroomJob{newJob(1, -70.6400, -33.4300, nil)},
+		}, Options{})
+
+		Expect(resp.Routes).To(HaveLen(1))
+		r := resp.Routes[0]
+		Expect(r.Distance).To(BeNumerically(">", 0))
+		Expect(r.Cost).To(Equal(500 + r.Duration + (1000*r.Distance+500)/1000))
+		Expect(resp.Summary).ToNot(BeNil())
+		Expect(resp.Summary.Cost).To(Equal(r.Cost))
+		Expect(resp.Summary.Routes).To(Equal(int64(1)))
+		Expect(resp.Summary.Distance).To(Equal(r.Distance))
+	})
+
+	It("should spend the pickup service time at the pickup", func() {
+		pickup := [2]float64{-70.6000, -33.4000}
+		delivery := [2]float64{-70.6800, -33.4800}
+		vehicle := newVehicle(1, []int64{2})
+		vehicle.TimeWindow = []int{8 * 3600, 18 * 3600}
+		shipment := func(id int, deliveryWindows [][]int) model.VroomShipment {
+			return model.VroomShipment{
+				ID:       id,
+				Amount:   []int64{1},
+				Pickup:   model.VroomStep{ID: 10 * id, Location: &pickup, Service: 3600},
+				Delivery: model.VroomStep{ID: 10*id + 1, Location: &delivery, Service: 60, TimeWindows: deliveryWindows},
+			}
+		}
+
+		resp := Solve(ctx, model.VroomOptimizationRequest{
+			Vehicles: []model.VroomVehicle{vehicle},
+			Shipments: []model.VroomShipment{
+				shipment(1, nil),
+				shipment(2, [][]int{{8 * 3600, 8*3600 + 1800}}),
+			},
+		}, Options{})
+
+		Expect(resp.Routes).To(HaveLen(1))
+		Expect(resp.Routes[0].Service).To(Equal(int64(3660)))
+		steps := resp.Routes[0].Steps
+		Expect(steps[2].Type).To(Equal("delivery"))
+		Expect(steps[2].Arrival).To(BeNumerically(">=", steps[1].Arrival+3600))
+		Expect(resp.Unassigned).To(HaveLen(2))
+		Expect(resp.Unassigned[0].Reason).To(Equal(ReasonTimeWindow))
+	})
+
+	Describe("time window deadlines", func() {
+		// Matriz asimétrica: ir de B al depósito es caro, por lo que visitar B antes que A
+		// es más barato pero llega a A después de su ventana
+		var p *problem
+
+		BeforeEach(func() {
+			a := newJob(1, -70.6400, -33.4300, nil)
+			a.TimeWindows = [][]int{{0, 150}}
+			b := newJob(2, -70.6300, -33.4200, nil)
+			req := model.VroomOptimizationRequest{
+				Vehicles: []model.VroomVehicle{newVehicle(1, nil)},
+				Jobs:     []model.VroomJob{a, b},
+			}
+			req.Matrices = model.VroomMatrices{
+				model.VroomDefaultProfile: {Durations: [][]int64{
+					{0, 100, 100},
+					{100, 0, 100},
+					{1000, 100, 0},
+				}},
+			}
+			p = newProblem(req, Options{})
+		})
+
+		It("should not reverse a segment when it misses a deadline", func() {
+			r := route{vehicle: 0, stops: []stop{p.units[0].stops[0], p.units[1].stops[0]}}
+
+			Expect(p.twoOpt(&r)).To(BeFalse())
+			Expect(r.stops[0].unit).To(Equal(0))
+		})
+
+		It("should skip the cheapest insertion when it misses a deadline", func() {
+			routes := []route{{vehicle: 0, stops: []stop{p.units[0].stops[0]}}}
+
+			best, ok := p.bestInsertion(routes, 1)
+
+			Expect(ok).To(BeTrue())
+			Expect(best.first).To(Equal(1))
+		})
+	})
+})
@@ -2,7 +2,6 @@ package vroom
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
 	"sort"
@@ -30,6 +29,7 @@ func init() {
 		observability.NewObservability,
 		NewVroomRestyHeavyClient,
 		configuration.NewConf,
+		NewMatrixProvider,
 	)
 }
 
@@ -37,7 +37,9 @@ func NewOptimize(
 	obs observability.Observability,
 	restyClient *resty.Client,
 	conf configuration.Conf,
+	matrixProvider MatrixProvider,
 ) Optimize {
+	solve := newSolveVRP(obs, restyClient, conf)
 	return func(ctx context.Context, fleetOptimization optimization.FleetOptimization) ([]request.UpsertRouteRequest, error) {
 
 		// Agrupar visitas por coordenadas antes de optimizar para mejorar la eficiencia
@@ -64,44 +66,15 @@ func NewOptimize(
 			return nil, err
 		}
 
-		res, err := restyClient.R().
-			SetContext(ctx).
-			SetHeader("Content-Type", "application/json").
-			SetBody(vroomRequest). // Resty hace el marshal automáticamente
-			Post(conf.VROOM_PLANNER_URL)
-
+		// Inyectar matrices de distancia/duración cuando hay un proveedor configurado
+		vroomRequest, err = injectMatrices(ctx, matrixProvider, vroomRequest)
 		if err != nil {
-			obs.Logger.ErrorContext(ctx,
-				"VROOM_REQUEST_ERROR",
-				"error", err.Error(),
-				"url", conf.VROOM_PLANNER_URL,
-			)
 			return nil, err
 		}
 
-		if res.IsError() {
-			obs.Logger.ErrorContext(ctx,
-				"VROOM_API_ERROR",
-				"status", res.StatusCode(),
-				"body", res.String(),
-				"request", vroomRequest,
-			)
-
-			return nil, fmt.Errorf("VROOM API error (status %d): %s\nRequest payload: %+v",
-				res.StatusCode(),
-				res.String(),
-				vroomRequest)
-		}
-
-		// Deserializar la respuesta de VROOM
-		var vroomResponse model.VroomOptimizationResponse
-		if err := json.Unmarshal(res.Body(), &vroomResponse); err != nil {
-			obs.Logger.ErrorContext(ctx,
-				"VROOM_RESPONSE_DESERIALIZATION_ERROR",
-				"error", err.Error(),
-				"body", res.String(),
-			)
-			return nil, fmt.Errorf("failed to deserialize VROOM response: %w", err)
+		vroomResponse, err := solve(ctx, conf.VROOM_PLANNER_URL, vroomRequest)
+		if err != nil {
+			return nil, err
 		}
 
 		planReferenceID := uuid.New().String()
@@ -191,38 +164,18 @@ func NewOptimize(
 				continue
 			}
 
-			res, err := restyClient.R().
-				SetContext(ctx).
-				SetHeader("Content-Type", "application/json").
-				SetBody(individualVroomRequest).
-				Post(conf.VROOM_OPTIMIZER_URL)
-
+			individualVroomRequest, err = injectMatrices(ctx, matrixProvider, individualVroomRequest)
 			if err != nil {
-				obs.Logger.ErrorContext(ctx,
-					"INDIVIDUAL_VROOM_REQUEST_ERROR",
-					"error", err.Error(),
-					"url", conf.VROOM_OPTIMIZER_URL,
-				)
-				continue
-			}
-
-			if res.IsError() {
-				obs.Logger.ErrorContext(ctx,
-					"INDIVIDUAL_VROOM_API_ERROR",
-					"status", res.StatusCode(),
-					"body", res.String(),
-					"request", individualVroomRequest,
-				)
+				obs.Logger.ErrorContext(ctx, "Failed to build individual travel matrix", "error", err)
 				continue
 			}
 
-			// Procesar respuesta individual
-			var individualVroomResponse model.VroomOptimizationResponse
-			if err := json.Unmarshal(res.Body(), &individualVroomResponse); err != nil {
+			individualVroomResponse, err := solve(ctx, conf.VROOM_OPTIMIZER_URL, individualVroomRequest)
+			if err != nil {
 				obs.Logger.ErrorContext(ctx,
-					"INDIVIDUAL_VROOM_RESPONSE_DESERIALIZATION_ERROR",
+					"INDIVIDUAL_VROOM_REQUEST_ERROR",
 					"error", err.Error(),
-					"body", res.String(),
+					"url", conf.VROOM_OPTIMIZER_URL,
 				)
 				continue
 			}
@@ -306,13 +259,34 @@ func NewOptimize(
 
 				// Mapear órdenes basadas en los jobs y shipments de los steps
 				var routeOrders []domain.Order
+				// Inicio de servicio (segundos desde medianoche) por orden, para informar la ventana usada
+				serviceStarts := make(map[string]int64)
+				// Pausas del conductor con la cantidad de visitas distintas previas a cada una
+				var routeBreaks []plannedRouteBreak
+				seenDestinations := make(map[string]bool)
 				for _, step := range vroomRoute.Steps {
+					if step.Type == "break" && len(individualFleetOptimization.Vehicles) > 0 {
+						routeBreaks = append(routeBreaks, plannedRouteBreak{
+							afterVisits: len(seenDestinations),
+							visit:       mapBreakStepToVisit(step, individualFleetOptimization.Vehicles[0]),
+						})
+						continue
+					}
+					if step.Type == "job" || step.Type == "delivery" {
+						seenDestinations[createDestinationKey(domain.AddressInfo{
+							Coordinates: domain.Coordinates{Point: orb.Point{step.Location[0], step.Location[1]}},
+						})] = true
+					}
+
 					// Manejar jobs (solo delivery)
 					if step.Job > 0 {
 						visit := findVisitByJobID(step.Job, individualFleetOptimization.Visits)
 						if visit != nil {
 							orders := createOrdersFromVisit(visit, false)
 							routeOrders = append(routeOrders, orders...)
+							for _, order := range visit.Orders {
+								serviceStarts[order.ReferenceID] = step.Arrival + step.WaitingTime
+							}
 						}
 					}
 
@@ -322,6 +296,11 @@ func NewOptimize(
 						if visit != nil {
 							orders := createOrdersFromVisit(visit, true)
 							routeOrders = append(routeOrders, orders...)
+							if step.Type == "delivery" {
+								for _, order := range visit.Orders {
+									serviceStarts[order.ReferenceID] = step.Arrival + step.WaitingTime
+								}
+							}
 						}
 					}
 				}
@@ -330,9 +309,10 @@ func NewOptimize(
 				// Mapear TimeWindow si está disponible en el vehículo
 				if len(individualFleetOptimization.Vehicles) > 0 {
 					vehicle := individualFleetOptimization.Vehicles[0]
+					vehicleWindow := usedTimeWindow(vehicle.Windows(), 0, false)
 					route.TimeWindow = domain.TimeWindow{
-						Start: vehicle.TimeWindow.Start,
-						End:   vehicle.TimeWindow.End,
+						Start: vehicleWindow.Start,
+						End:   vehicleWindow.End,
 					}
 				}
 
@@ -352,7 +332,8 @@ func NewOptimize(
 					originalVehicle = individualFleetOptimization.Vehicles[0]
 				}
 				// Usar las visitas originales sin agrupar para preservar contactos individuales
-				routeRequest := createUpsertRouteRequest(route, planReferenceID, originalVehicle, fleetOptimization.Visits)
+				routeRequest := createUpsertRouteRequest(route, planReferenceID, originalVehicle, fleetOptimization.Visits, serviceStarts, routeBreaks)
+				routeRequest.Cost = vroomRoute.Cost
 				routeRequests = append(routeRequests, routeRequest)
 
 				// Consolidar polylines de esta optimización individual
@@ -396,14 +377,14 @@ func NewOptimize(
 }
 
 // createUpsertRouteRequest convierte una ruta del dominio a UpsertRouteRequest
-func createUpsertRouteRequest(route domain.Route, planReferenceID string, originalVehicle optimization.Vehicle, originalVisits []optimization.Visit) request.UpsertRouteRequest {
+func createUpsertRouteRequest(route domain.Route, planReferenceID string, originalVehicle optimization.Vehicle, originalVisits []optimization.Visit, serviceStarts map[string]int64, routeBreaks []plannedRouteBreak) request.UpsertRouteRequest {
 	// Agrupar órdenes por secuencia, dirección y contacto
 	visitGroups := groupOrdersByVisit(route.Orders)
 
 	// Convertir grupos a visitas usando información de las visitas originales
 	visits := make([]request.UpsertRouteVisit, 0, len(visitGroups))
 	for _, group := range visitGroups {
-		visit := mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group, originalVisits)
+		visit := mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group, originalVisits, serviceStarts)
 		visits = append(visits, visit)
 	}
 
@@ -412,6 +393,9 @@ func createUpsertRouteRequest(route domain.Route, planReferenceID string, origin
 		return visits[i].SequenceNumber < visits[j].SequenceNumber
 	})
 
+	// Intercalar las pausas del conductor en su posición planificada
+	visits = insertBreakVisits(visits, routeBreaks)
+
 	return request.UpsertRouteRequest{
 		ReferenceID:     route.ReferenceID,
 		PlanReferenceID: planReferenceID,
@@ -426,6 +410,65 @@ func createUpsertRouteRequest(route domain.Route, planReferenceID string, origin
 	}
 }
 
+// plannedRouteBreak es una pausa del conductor planificada después de afterVisits visitas
+type plannedRouteBreak struct {
+	afterVisits int
+	visit       request.UpsertRouteVisit
+}
+
+// mapBreakStepToVisit convierte un step "break" de VROOM en una visita de tipo break
+func mapBreakStepToVisit(step model.Step, vehicle optimization.Vehicle) request.UpsertRouteVisit {
+	plannedStart := step.Arrival + step.WaitingTime
+	visit := request.UpsertRouteVisit{
+		Type: "break",
+		AddressInfo: request.UpsertRouteAddressInfo{
+			Coordinates: request.UpsertRouteCoordinates{
+				Latitude:  step.Location[1],
+				Longitude: step.Location[0],
+			},
+		},
+		ServiceTime: step.Service,
+		PlannedTime: mapper.FormatSeconds(plannedStart),
+		TimeWindow: request.UpsertRouteTimeWindow{
+			Start: mapper.FormatSeconds(plannedStart),
+			End:   mapper.FormatSeconds(plannedStart + step.Service),
+		},
+		Description: mapper.UnavailableBreakDescription,
+	}
+	// Los IDs de break corresponden a la posición en vehicle.Breaks; los siguientes son huecos de disponibilidad
+	if step.ID > 0 && int(step.ID) <= len(vehicle.Breaks) {
+		vehicleBreak := vehicle.Breaks[step.ID-1]
+		visit.Description = vehicleBreak.Description
+		visit.TimeWindows = mapTimeWindowsToRequest(vehicleBreak.TimeWindows)
+		visit.TimeWindow = usedTimeWindow(vehicleBreak.TimeWindows, plannedStart, true)
+	}
+	return visit
+}
+
+// insertBreakVisits intercala las pausas entre las visitas y renumera la secuencia
+func insertBreakVisits(visits []request.UpsertRouteVisit, routeBreaks []plannedRouteBreak) []request.UpsertRouteVisit {
+	if len(routeBreaks) == 0 {
+		return visits
+	}
+	out := make([]request.UpsertRouteVisit, 0, len(visits)+len(routeBreaks))
+	appendBreaks := func(afterVisits int) {
+		for _, b := range routeBreaks {
+			if b.afterVisits == afterVisits {
+				out = append(out, b.visit)
+			}
+		}
+	}
+	for i, visit := range visits {
+		appendBreaks(i)
+		out = append(out, visit)
+	}
+	appendBreaks(len(visits))
+	for i := range out {
+		out[i].SequenceNumber = i + 1
+	}
+	return out
+}
+
 // OrderGroup representa un grupo de órdenes que se pueden agrupar en una visita
 type OrderGroup struct {
 	SequenceNumber       int
@@ -520,9 +563,10 @@ func mapOrderGroupToVisit(group OrderGroup) request.UpsertRouteVisit {
 
 // mapOrderGroupToVisitFromOptimizationWithOriginalVisits convierte un grupo de órdenes de optimización a una visita
 // buscando el contacto correcto para cada orden individual
-func mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group OrderGroup, originalVisits []optimization.Visit) request.UpsertRouteVisit {
+func mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group OrderGroup, originalVisits []optimization.Visit, serviceStarts map[string]int64) request.UpsertRouteVisit {
 	// Mapear órdenes del grupo usando la información de optimización
 	orders := make([]request.UpsertRouteOrder, 0, len(group.Orders))
+	var firstOriginalVisit *optimization.Visit
 	for _, order := range group.Orders {
 		// Buscar la visita original que contiene esta orden específica
 		var originalVisit *optimization.Visit
@@ -546,6 +590,9 @@ func mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group OrderGroup, or
 			// Mapear contacto desde la visita original específica
 			if originalVisit != nil {
 				modelOrder.Contact = mapContactToRequestFromOptimization(originalVisit.Delivery.AddressInfo.Contact)
+				if firstOriginalVisit == nil {
+					firstOriginalVisit = originalVisit
+				}
 			}
 			orders = append(orders, modelOrder)
 		} else {
@@ -578,6 +625,23 @@ func mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group OrderGroup, or
 		}
 	}
 
+	// Informar las ventanas ofrecidas y la ventana en que se planificó el servicio
+	var timeWindows []request.UpsertRouteTimeWindow
+	var plannedTime string
+	if firstOriginalVisit != nil {
+		serviceTime = firstOriginalVisit.Delivery.ServiceTime
+		windows := firstOriginalVisit.Delivery.Windows()
+		timeWindows = mapTimeWindowsToRequest(windows)
+		serviceStart, planned := int64(0), false
+		if firstOrder != nil {
+			serviceStart, planned = serviceStarts[firstOrder.ReferenceID.String()]
+		}
+		timeWindow = usedTimeWindow(windows, serviceStart, planned)
+		if planned {
+			plannedTime = mapper.FormatSeconds(serviceStart)
+		}
+	}
+
 	return request.UpsertRouteVisit{
 		Type:           "delivery",
 		AddressInfo:    mapAddressInfoToRequest(group.AddressInfo),
@@ -585,10 +649,48 @@ func mapOrderGroupToVisitFromOptimizationWithOriginalVisits(group OrderGroup, or
 		SequenceNumber: group.SequenceNumber,
 		ServiceTime:    serviceTime,
 		TimeWindow:     timeWindow,
+		TimeWindows:    timeWindows,
+		PlannedTime:    plannedTime,
 		Orders:         orders,
 	}
 }
 
+// usedTimeWindow retorna la ventana que contiene el inicio de servicio planificado.
+// Sin planificación conocida retorna la primera ventana.
+func usedTimeWindow(windows []optimization.TimeWindow, serviceStart int64, planned bool) request.UpsertRouteTimeWindow {
+	if len(windows) == 0 {
+		return request.UpsertRouteTimeWindow{}
+	}
+	used := windows[0]
+	if planned {
+		for _, tw := range windows {
+			if serviceStart >= int64(mapper.ToSeconds(tw.Start)) && serviceStart <= int64(mapper.ToSeconds(tw.End)) {
+				used = tw
+				break
+			}
+		}
+	}
+	return request.UpsertRouteTimeWindow{
+		Start: used.Start,
+		End:   used.End,
+	}
+}
+
+// mapTimeWindowsToRequest convierte las ventanas de optimización al request
+func mapTimeWindowsToRequest(windows []optimization.TimeWindow) []request.UpsertRouteTimeWindow {
+	if len(windows) == 0 {
+		return nil
+	}
+	out := make([]request.UpsertRouteTimeWindow, 0, len(windows))
+	for _, tw := range windows {
+		out = append(out, request.UpsertRouteTimeWindow{
+			Start: tw.Start,
+			End:   tw.End,
+		})
+	}
+	return out
+}
+
 // mapOrderGroupToVisitFromOptimization convierte un grupo de órdenes de optimización a una visita
 func mapOrderGroupToVisitFromOptimization(group OrderGroup, originalVisit *optimization.Visit) request.UpsertRouteVisit {
 	// Mapear órdenes del grupo usando la información de optimización
@@ -632,10 +734,7 @@ func mapOrderGroupToVisitFromOptimization(group OrderGroup, originalVisit *optim
 	// Usar información de la visita original si está disponible
 	if originalVisit != nil {
 		serviceTime = originalVisit.Delivery.ServiceTime
-		timeWindow = request.UpsertRouteTimeWindow{
-			Start: originalVisit.Delivery.TimeWindow.Start,
-			End:   originalVisit.Delivery.TimeWindow.End,
-		}
+		timeWindow = usedTimeWindow(originalVisit.Delivery.Windows(), 0, false)
 		nodeInfo = request.UpsertRouteNodeInfo{
 			ReferenceID: originalVisit.Delivery.NodeInfo.ReferenceID,
 		}
@@ -764,6 +863,9 @@ func mapVehicleToRequestFromOptimization(vehicle optimization.Vehicle) request.U
 		Start: vehicle.TimeWindow.Start,
 		End:   vehicle.TimeWindow.End,
 	}
+	if windows := vehicle.Windows(); len(windows) > 0 && !vehicle.TimeWindow.IsValid() {
+		timeWindow = usedTimeWindow(windows, 0, false)
+	}
 
 	return request.UpsertRouteVehicle{
 		Plate:         vehicle.Plate,
@@ -771,6 +873,7 @@ func mapVehicleToRequestFromOptimization(vehicle optimization.Vehicle) request.U
 		EndLocation:   endLocation,
 		Skills:        vehicle.Skills,
 		TimeWindow:    timeWindow,
+		TimeWindows:   mapTimeWindowsToRequest(vehicle.Windows()),
 		Capacity: request.UpsertRouteVehicleCapacity{
 			Volume:    vehicle.Capacity.Volume,
 			Weight:    vehicle.Capacity.Weight,
@@ -816,6 +919,7 @@ func mapPoliticalAreaToRequestFromOptimization(pa optimization.PoliticalArea) re
 		AdminAreaLevel2: pa.AdminAreaLevel2,
 		AdminAreaLevel3: pa.AdminAreaLevel3,
 		AdminAreaLevel4: pa.AdminAreaLevel4,
+		TimeZone:        pa.TimeZone,
 	}
 }
 
@@ -890,6 +994,7 @@ func mapPoliticalAreaToRequest(pa domain.PoliticalArea) request.UpsertRoutePolit
 		AdminAreaLevel2: pa.AdminAreaLevel2,
 		AdminAreaLevel3: pa.AdminAreaLevel3,
 		AdminAreaLevel4: pa.AdminAreaLevel4,
+		TimeZone:        pa.TimeZone,
 	}
 }
 
@@ -960,10 +1065,8 @@ func createUnassignedRouteRequest(unassignedOrders []optimization.Order, planRef
 				},
 				SequenceNumber: 1,
 				ServiceTime:    originalVisit.Delivery.ServiceTime,
-				TimeWindow: request.UpsertRouteTimeWindow{
-					Start: originalVisit.Delivery.TimeWindow.Start,
-					End:   originalVisit.Delivery.TimeWindow.End,
-				},
+				TimeWindow:     usedTimeWindow(originalVisit.Delivery.Windows(), 0, false),
+				TimeWindows:    mapTimeWindowsToRequest(originalVisit.Delivery.Windows()),
 				Orders: []request.UpsertRouteOrder{
 					{
 						ReferenceID:          order.ReferenceID,
@@ -1073,6 +1176,7 @@ func createOrdersFromVisit(visit *optimization.Visit, hasPickup bool) []domain.O
 					AdminAreaLevel2: visit.Delivery.AddressInfo.PoliticalArea.AdminAreaLevel2,
 					AdminAreaLevel3: visit.Delivery.AddressInfo.PoliticalArea.AdminAreaLevel3,
 					AdminAreaLevel4: visit.Delivery.AddressInfo.PoliticalArea.AdminAreaLevel4,
+					TimeZone:        visit.Delivery.AddressInfo.PoliticalArea.TimeZone,
 				},
 				// Información de dirección
 				AddressLine1: visit.Delivery.AddressInfo.AddressLine1,
@@ -1107,6 +1211,7 @@ func createOrdersFromVisit(visit *optimization.Visit, hasPickup bool) []domain.O
 						AdminAreaLevel2: visit.Pickup.AddressInfo.PoliticalArea.AdminAreaLevel2,
 						AdminAreaLevel3: visit.Pickup.AddressInfo.PoliticalArea.AdminAreaLevel3,
 						AdminAreaLevel4: visit.Pickup.AddressInfo.PoliticalArea.AdminAreaLevel4,
+						TimeZone:        visit.Pickup.AddressInfo.PoliticalArea.TimeZone,
 					},
 					// Información de dirección
 					AddressLine1: visit.Pickup.AddressInfo.AddressLine1,
@@ -1153,21 +1258,21 @@ func createOrdersFromVisit(visit *optimization.Visit, hasPickup bool) []domain.O
 		order.DeliveryUnits = deliveryUnits
 
 		// Mapear fechas de disponibilidad de recolección si está disponible
-		if hasPickup && visit.Pickup.TimeWindow.Start != "" {
+		if pickupWindows := visit.Pickup.Windows(); hasPickup && len(pickupWindows) > 0 {
 			order.CollectAvailabilityDate = domain.CollectAvailabilityDate{
 				TimeRange: domain.TimeRange{
-					StartTime: visit.Pickup.TimeWindow.Start,
-					EndTime:   visit.Pickup.TimeWindow.End,
+					StartTime: pickupWindows[0].Start,
+					EndTime:   pickupWindows[0].End,
 				},
 			}
 		}
 
 		// Mapear fechas prometidas si está disponible
-		if visit.Delivery.TimeWindow.Start != "" {
+		if deliveryWindows := visit.Delivery.Windows(); len(deliveryWindows) > 0 {
 			order.PromisedDate = domain.PromisedDate{
 				TimeRange: domain.TimeRange{
-					StartTime: visit.Delivery.TimeWindow.Start,
-					EndTime:   visit.Delivery.TimeWindow.End,
+					StartTime: deliveryWindows[0].Start,
+					EndTime:   deliveryWindows[0].End,
 				},
 			}
 		}
@@ -1302,6 +1407,10 @@ func groupVisitsByCoordinates(fleetOptimization optimization.FleetOptimization)
 		// Consolidar todas las órdenes de las visitas del grupo
 		var allOrders []optimization.Order
 		for _, visit := range visitGroup {
+			// La visita consolidada conserva la mayor prioridad del grupo
+			if visit.Priority > consolidatedVisit.Priority {
+				consolidatedVisit.Priority = visit.Priority
+			}
 			// Crear órdenes con información de contacto preservada
 			for _, order := range visit.Orders {
 				// Crear una copia de la orden
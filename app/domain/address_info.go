@@ -43,6 +43,11 @@ func (a *AddressInfo) UpdatePoint(point orb.Point) {
 	a.Coordinates.Point = point
 }
 
+// HasCoordinates indica si la dirección ya trae un punto geográfico
+func (a AddressInfo) HasCoordinates() bool {
+	return a.Coordinates.Point[0] != 0 || a.Coordinates.Point[1] != 0
+}
+
 func (a *AddressInfo) NormalizeAndGeocode(
 	ctx context.Context,
 	geocodeFn func(context.Context, AddressInfo) (orb.Point, error),
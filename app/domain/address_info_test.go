@@ -297,6 +297,17 @@ var _ = Describe("AddressInfo", func() {
 		})
 	})
 
+	Describe("HasCoordinates", func() {
+		It("should be false when the point is empty", func() {
+			Expect(AddressInfo{}.HasCoordinates()).To(BeFalse())
+		})
+
+		It("should be true when latitude or longitude is informed", func() {
+			addr := AddressInfo{Coordinates: Coordinates{Point: orb.Point{-70.6130425, -33.5147889}}}
+			Expect(addr.HasCoordinates()).To(BeTrue())
+		})
+	})
+
 	Describe("FullAddress", func() {
 		It("should concatenate address fields correctly", func() {
 			addr := AddressInfo{
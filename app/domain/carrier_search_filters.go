@@ -2,4 +2,7 @@ package domain
 
 type CarrierSearchFilters struct {
 	Pagination
+	RequestedFields map[string]any
+	NationalIDs     []string
+	Name            *string
 }
@@ -11,6 +11,14 @@ type ConfirmDelivery struct {
 	Latitude          float64
 	Longitude         float64
 	NonDeliveryReason NonDeliveryReason
+	// Items detalla lo entregado y rechazado por SKU en entregas parciales
+	Items ItemConfirmations
+	// DeliveryPin es el PIN informado por el conductor; nunca se persiste en claro
+	DeliveryPin     string
+	PinVerification DeliveryPinVerification
+	// Distancia entre el punto de confirmación y el destino, y si excede el geocerco del tenant
+	DistanceToDestinationMeters *float64
+	SuspiciousLocation          bool
 }
 
 type Recipient struct {
@@ -21,4 +29,6 @@ type Recipient struct {
 type ManualChange struct {
 	PerformedBy string
 	Reason      string
+	// Override fuerza transiciones de estado fuera de la tabla; exige Reason
+	Override bool
 }
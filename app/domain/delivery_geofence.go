@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+)
+
+// DeliveryGeofence define el radio alrededor del destino dentro del cual se espera que el
+// conductor confirme la entrega
+type DeliveryGeofence struct {
+	RadiusMeters float64
+}
+
+// MeasureDistanceTo registra la distancia entre el punto de confirmación y el destino, y marca
+// la confirmación como sospechosa si queda fuera del geocerco. Sin coordenadas en ambos
+// extremos no hay nada que medir
+func (c *ConfirmDelivery) MeasureDistanceTo(destination AddressInfo, geofence DeliveryGeofence) {
+	c.DistanceToDestinationMeters = nil
+	c.SuspiciousLocation = false
+	if (c.Latitude == 0 && c.Longitude == 0) || !destination.HasCoordinates() {
+		return
+	}
+	distance := geo.Distance(orb.Point{c.Longitude, c.Latitude}, destination.Coordinates.Point)
+	c.DistanceToDestinationMeters = &distance
+	c.SuspiciousLocation = geofence.RadiusMeters > 0 && distance > geofence.RadiusMeters
+}
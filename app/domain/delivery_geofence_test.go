@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"github.com/paulmach/orb"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfirmDelivery.MeasureDistanceTo", func() {
+	destination := AddressInfo{Coordinates: Coordinates{Point: orb.Point{-70.6130425, -33.5147889}}}
+	geofence := DeliveryGeofence{RadiusMeters: 200}
+
+	It("should accept a confirmation inside the geofence", func() {
+		confirm := ConfirmDelivery{Latitude: -33.5150, Longitude: -70.6132}
+		confirm.MeasureDistanceTo(destination, geofence)
+		Expect(confirm.DistanceToDestinationMeters).ToNot(BeNil())
+		Expect(*confirm.DistanceToDestinationMeters).To(BeNumerically("<", 50))
+		Expect(confirm.SuspiciousLocation).To(BeFalse())
+	})
+
+	It("should flag a confirmation beyond the geofence", func() {
+		confirm := ConfirmDelivery{Latitude: -33.4489, Longitude: -70.6693}
+		confirm.MeasureDistanceTo(destination, geofence)
+		Expect(*confirm.DistanceToDestinationMeters).To(BeNumerically(">", 8000))
+		Expect(confirm.SuspiciousLocation).To(BeTrue())
+	})
+
+	It("should not measure without coordinates on both sides", func() {
+		confirm := ConfirmDelivery{}
+		confirm.MeasureDistanceTo(destination, geofence)
+		Expect(confirm.DistanceToDestinationMeters).To(BeNil())
+
+		confirm = ConfirmDelivery{Latitude: -33.4489, Longitude: -70.6693}
+		confirm.MeasureDistanceTo(AddressInfo{}, geofence)
+		Expect(confirm.DistanceToDestinationMeters).To(BeNil())
+		Expect(confirm.SuspiciousLocation).To(BeFalse())
+	})
+})
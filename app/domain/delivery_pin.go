@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Políticas de PIN de entrega configurables por tenant y por tipo de orden
+const (
+	// DeliveryPinPolicyDisabled no genera ni exige PIN. Una política vacía hereda la del
+	// tenant, por lo que un tipo de orden usa disabled para desactivar el PIN explícitamente
+	DeliveryPinPolicyDisabled = "disabled"
+	// DeliveryPinPolicyFlag acepta la entrega sin el PIN correcto pero deja el resultado registrado
+	DeliveryPinPolicyFlag = "flag"
+	// DeliveryPinPolicyReject rechaza la confirmación de entrega sin el PIN correcto
+	DeliveryPinPolicyReject = "reject"
+)
+
+const deliveryPinDigits = 6
+
+// Límite de intentos fallidos por orden antes de bloquear la verificación del PIN. Tras el
+// bloqueo el contador no se reinicia, por lo que cada nuevo fallo vuelve a bloquear la orden
+// y solo se permite un intento por ventana hasta acertar el PIN
+const (
+	MaxDeliveryPinAttempts     = 5
+	DeliveryPinLockoutDuration = 15 * time.Minute
+)
+
+// DeliveryPinAttempts registra los intentos fallidos de PIN de una orden
+type DeliveryPinAttempts struct {
+	Failed      int
+	LockedUntil *time.Time
+}
+
+// IsLocked indica si la orden tiene la verificación de PIN bloqueada en el instante indicado
+func (a DeliveryPinAttempts) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// IsValidDeliveryPinPolicy indica si la política es una de las soportadas
+func IsValidDeliveryPinPolicy(policy string) bool {
+	switch policy {
+	case "", DeliveryPinPolicyDisabled, DeliveryPinPolicyFlag, DeliveryPinPolicyReject:
+		return true
+	}
+	return false
+}
+
+// WithDefaultDeliveryPinPolicy aplica la política por defecto del tenant cuando el tipo de orden
+// no define una propia
+func (ot OrderType) WithDefaultDeliveryPinPolicy(tenantPolicy string) OrderType {
+	if ot.DeliveryPinPolicy == "" {
+		ot.DeliveryPinPolicy = tenantPolicy
+	}
+	return ot
+}
+
+// RequiresDeliveryPin indica si las órdenes del tipo deben generar y verificar PIN
+func (ot OrderType) RequiresDeliveryPin() bool {
+	return ot.DeliveryPinPolicy == DeliveryPinPolicyFlag || ot.DeliveryPinPolicy == DeliveryPinPolicyReject
+}
+
+// GenerateDeliveryPin genera un PIN numérico aleatorio para que el destinatario lo entregue al conductor
+func GenerateDeliveryPin() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < deliveryPinDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", deliveryPinDigits, n.Int64()), nil
+}
+
+// DeliveryPinHash calcula el HMAC-SHA256 con el que se persiste el PIN de la orden. La clave es
+// un secreto del servidor para que quien lea la fila no pueda probar offline los 10^6 PINs
+// posibles; la orden se incluye para que un mismo PIN no produzca el mismo hash en órdenes distintas
+func (o Order) DeliveryPinHash(ctx context.Context, key []byte, pin string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(o.DocID(ctx).String() + "|" + pin))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliveryPinVerification es el resultado de validar el PIN informado al confirmar la entrega
+type DeliveryPinVerification struct {
+	Policy   string
+	Required bool
+	Verified bool
+	// Locked indica que el PIN no se comparó porque la orden superó el límite de intentos
+	Locked bool
+}
+
+// VerifyDeliveryPin compara el PIN informado con el hash persistido de la orden. Una orden
+// sin política activa en su tipo o sin PIN generado no exige verificación
+func (o Order) VerifyDeliveryPin(ctx context.Context, key []byte, storedHash, pin string) DeliveryPinVerification {
+	policy := o.OrderType.DeliveryPinPolicy
+	if !o.OrderType.RequiresDeliveryPin() || storedHash == "" {
+		return DeliveryPinVerification{Policy: policy}
+	}
+	verified := pin != "" &&
+		subtle.ConstantTimeCompare([]byte(o.DeliveryPinHash(ctx, key, pin)), []byte(storedHash)) == 1
+	return DeliveryPinVerification{
+		Policy:   policy,
+		Required: true,
+		Verified: verified,
+	}
+}
+
+// LockedOut marca la verificación como bloqueada sin comparar el PIN. Solo aplica a la política
+// de rechazo, que es la única en la que la respuesta revela si el PIN era correcto
+func (v DeliveryPinVerification) LockedOut() DeliveryPinVerification {
+	if v.Required && v.Policy == DeliveryPinPolicyReject {
+		v.Verified = false
+		v.Locked = true
+	}
+	return v
+}
+
+// CountsAsAttempt indica si el resultado debe registrarse en el contador de intentos de la orden
+func (v DeliveryPinVerification) CountsAsAttempt() bool {
+	return v.Required && !v.Locked && v.Policy == DeliveryPinPolicyReject
+}
+
+// Rejected indica si la confirmación debe rechazarse por no presentar el PIN correcto
+func (v DeliveryPinVerification) Rejected() bool {
+	return v.Required && !v.Verified && v.Policy == DeliveryPinPolicyReject
+}
+
+// Flagged indica si la entrega se acepta pero queda marcada por no presentar el PIN correcto
+func (v DeliveryPinVerification) Flagged() bool {
+	return v.Required && !v.Verified && v.Policy != DeliveryPinPolicyReject
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryPin", func() {
+	ctx := buildCtx("org1", "CL")
+	key := []byte("test-delivery-pin-key")
+
+	It("should generate six digit pins", func() {
+		pin, err := GenerateDeliveryPin()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pin).To(MatchRegexp(`^[0-9]{6}$`))
+	})
+
+	It("should hash the same pin differently for different orders", func() {
+		order1 := Order{ReferenceID: "ORD-1"}
+		order2 := Order{ReferenceID: "ORD-2"}
+		Expect(order1.DeliveryPinHash(ctx, key, "123456")).ToNot(Equal(order2.DeliveryPinHash(ctx, key, "123456")))
+	})
+
+	It("should depend on the server key so the pin cannot be brute forced from the stored hash", func() {
+		order := Order{ReferenceID: "ORD-1"}
+		hash := order.DeliveryPinHash(ctx, key, "123456")
+		Expect(hash).To(HaveLen(64))
+		Expect(hash).ToNot(Equal(order.DeliveryPinHash(ctx, []byte("other-key"), "123456")))
+		Expect(hash).ToNot(Equal(HashByTenant(ctx, "delivery_pin", order.DocID(ctx).String(), "123456").String()))
+	})
+
+	Describe("VerifyDeliveryPin", func() {
+		order := Order{
+			ReferenceID: "ORD-1",
+			OrderType:   OrderType{Type: "retail", DeliveryPinPolicy: DeliveryPinPolicyReject},
+		}
+		storedHash := order.DeliveryPinHash(ctx, key, "123456")
+
+		It("should verify the correct pin", func() {
+			result := order.VerifyDeliveryPin(ctx, key, storedHash, "123456")
+			Expect(result.Required).To(BeTrue())
+			Expect(result.Verified).To(BeTrue())
+			Expect(result.Rejected()).To(BeFalse())
+		})
+
+		It("should reject a wrong or missing pin under the reject policy", func() {
+			Expect(order.VerifyDeliveryPin(ctx, key, storedHash, "654321").Rejected()).To(BeTrue())
+			Expect(order.VerifyDeliveryPin(ctx, key, storedHash, "").Rejected()).To(BeTrue())
+		})
+
+		It("should flag a wrong pin under the flag policy", func() {
+			flagged := order
+			flagged.OrderType.DeliveryPinPolicy = DeliveryPinPolicyFlag
+			result := flagged.VerifyDeliveryPin(ctx, key, storedHash, "654321")
+			Expect(result.Rejected()).To(BeFalse())
+			Expect(result.Flagged()).To(BeTrue())
+		})
+
+		It("should not require a pin when the order has none or the policy is disabled", func() {
+			Expect(order.VerifyDeliveryPin(ctx, key, "", "").Required).To(BeFalse())
+
+			disabled := order
+			disabled.OrderType.DeliveryPinPolicy = DeliveryPinPolicyDisabled
+			Expect(disabled.VerifyDeliveryPin(ctx, key, storedHash, "").Required).To(BeFalse())
+		})
+
+		It("should reject even the correct pin while the order is locked out", func() {
+			result := order.VerifyDeliveryPin(ctx, key, storedHash, "123456").LockedOut()
+			Expect(result.Locked).To(BeTrue())
+			Expect(result.Rejected()).To(BeTrue())
+			Expect(result.CountsAsAttempt()).To(BeFalse())
+		})
+
+		It("should count only reject policy verifications as attempts", func() {
+			Expect(order.VerifyDeliveryPin(ctx, key, storedHash, "654321").CountsAsAttempt()).To(BeTrue())
+
+			flagged := order
+			flagged.OrderType.DeliveryPinPolicy = DeliveryPinPolicyFlag
+			Expect(flagged.VerifyDeliveryPin(ctx, key, storedHash, "654321").CountsAsAttempt()).To(BeFalse())
+			Expect(flagged.VerifyDeliveryPin(ctx, key, storedHash, "654321").LockedOut().Locked).To(BeFalse())
+		})
+	})
+
+	Describe("WithDefaultDeliveryPinPolicy", func() {
+		It("should inherit the tenant policy only when the order type has none", func() {
+			Expect(OrderType{Type: "retail"}.WithDefaultDeliveryPinPolicy(DeliveryPinPolicyReject).DeliveryPinPolicy).
+				To(Equal(DeliveryPinPolicyReject))
+			Expect(OrderType{Type: "retail", DeliveryPinPolicy: DeliveryPinPolicyDisabled}.WithDefaultDeliveryPinPolicy(DeliveryPinPolicyReject).DeliveryPinPolicy).
+				To(Equal(DeliveryPinPolicyDisabled))
+		})
+	})
+
+	Describe("DeliveryPinAttempts", func() {
+		It("should be locked only until the lockout expires", func() {
+			now := time.Now()
+			lockedUntil := now.Add(DeliveryPinLockoutDuration)
+			attempts := DeliveryPinAttempts{Failed: MaxDeliveryPinAttempts, LockedUntil: &lockedUntil}
+			Expect(attempts.IsLocked(now)).To(BeTrue())
+			Expect(attempts.IsLocked(lockedUntil)).To(BeFalse())
+			Expect(DeliveryPinAttempts{Failed: 2}.IsLocked(now)).To(BeFalse())
+		})
+	})
+})
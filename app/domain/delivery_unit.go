@@ -84,15 +84,27 @@ func (p DeliveryUnit) UpdateIfChanged(newPackage DeliveryUnit) (DeliveryUnit, bo
 }
 
 func (p *DeliveryUnit) UpdateStatusBasedOnNonDelivery() {
-	if p.ConfirmDelivery.NonDeliveryReason.IsEmpty() {
-		p.Status = Status{
-			Status: StatusFinished,
-		}
-	} else {
-		p.Status = Status{
-			Status: StatusPending,
+	p.Status = Status{
+		Status: p.DeliveryOutcome(),
+	}
+}
+
+// DeliveryOutcome retorna el estado que deja el intento de entrega: finalizada, pendiente
+// cuando se informó un motivo de no entrega, o parcialmente entregada cuando el detalle
+// por SKU trae unidades entregadas y rechazadas
+func (p DeliveryUnit) DeliveryOutcome() string {
+	if items := p.ConfirmDelivery.Items; len(items) > 0 {
+		switch {
+		case items.Delivered() == 0 && items.Rejected() > 0:
+			return StatusPending
+		case items.Delivered() > 0 && items.Rejected() > 0:
+			return StatusPartiallyDelivered
 		}
 	}
+	if p.ConfirmDelivery.NonDeliveryReason.IsEmpty() {
+		return StatusFinished
+	}
+	return StatusPending
 }
 
 // SetValues sets the simplified values directly
@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+// DeliveryUnitStatusChange notifica a los suscriptores en vivo el estado con el que quedó una
+// unidad de entrega tras procesar un evento
+type DeliveryUnitStatusChange struct {
+	OrderReferenceID string
+	Lpn              string
+	RouteReferenceID string
+	VehiclePlate     string
+	Status           string
+	OccurredAt       time.Time
+}
+
+// DeliveryUnitStatusChangesFromRoute lista el estado de cada unidad de la ruta ya procesada
+func DeliveryUnitStatusChangesFromRoute(route Route, occurredAt time.Time) []DeliveryUnitStatusChange {
+	var changes []DeliveryUnitStatusChange
+	for _, order := range route.Orders {
+		for _, du := range order.DeliveryUnits {
+			if du.Status.Status == "" {
+				continue
+			}
+			changes = append(changes, DeliveryUnitStatusChange{
+				OrderReferenceID: order.ReferenceID.String(),
+				Lpn:              du.Lpn,
+				RouteReferenceID: route.ReferenceID,
+				VehiclePlate:     route.Vehicle.Plate,
+				Status:           du.Status.Status,
+				OccurredAt:       occurredAt,
+			})
+		}
+	}
+	return changes
+}
+
+// DeliveryUnitStatusChangeFilter selecciona los cambios de interés de un suscriptor. Un
+// criterio vacío no restringe
+type DeliveryUnitStatusChangeFilter struct {
+	OrderReferenceIDs []string
+	RouteReferenceIDs []string
+	Statuses          []string
+}
+
+func (f DeliveryUnitStatusChangeFilter) Matches(change DeliveryUnitStatusChange) bool {
+	return matchesAny(f.OrderReferenceIDs, change.OrderReferenceID) &&
+		matchesAny(f.RouteReferenceIDs, change.RouteReferenceID) &&
+		matchesAny(f.Statuses, change.Status)
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteProgress resume el último estado de las unidades de entrega de una ruta
+type RouteProgress struct {
+	RouteReferenceID   string
+	Total              int
+	InTransit          int
+	Delivered          int
+	PartiallyDelivered int
+	NotDelivered       int
+	Cancelled          int
+}
+
+// NewRouteProgress cuenta los estados de las unidades. Dentro de una ruta una unidad pendiente
+// es una entrega fallida, ya que el inicio de ruta las deja en tránsito
+func NewRouteProgress(routeReferenceID string, statuses []string) RouteProgress {
+	progress := RouteProgress{
+		RouteReferenceID: routeReferenceID,
+		Total:            len(statuses),
+	}
+	for _, status := range statuses {
+		switch status {
+		case StatusInTransit:
+			progress.InTransit++
+		case StatusFinished:
+			progress.Delivered++
+		case StatusPartiallyDelivered:
+			progress.PartiallyDelivered++
+		case StatusPending:
+			progress.NotDelivered++
+		case StatusCancelled:
+			progress.Cancelled++
+		}
+	}
+	return progress
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryUnitStatusChange", func() {
+	occurredAt := time.Date(2025, 6, 6, 14, 30, 0, 0, time.UTC)
+	route := Route{
+		ReferenceID: "ROUTE-001",
+		Vehicle:     Vehicle{Plate: "ABC123"},
+		Orders: []Order{
+			{
+				ReferenceID: "ORD-1",
+				DeliveryUnits: []DeliveryUnit{
+					{Lpn: "LPN-1", Status: Status{Status: StatusFinished}},
+					{Lpn: "LPN-2", Status: Status{Status: StatusPending}},
+				},
+			},
+			{
+				ReferenceID:   "ORD-2",
+				DeliveryUnits: []DeliveryUnit{{Lpn: "LPN-3"}},
+			},
+		},
+	}
+
+	It("should list the status of every processed unit of the route", func() {
+		changes := DeliveryUnitStatusChangesFromRoute(route, occurredAt)
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0]).To(Equal(DeliveryUnitStatusChange{
+			OrderReferenceID: "ORD-1",
+			Lpn:              "LPN-1",
+			RouteReferenceID: "ROUTE-001",
+			VehiclePlate:     "ABC123",
+			Status:           StatusFinished,
+			OccurredAt:       occurredAt,
+		}))
+		Expect(changes[1].Status).To(Equal(StatusPending))
+	})
+
+	It("should match only the changes selected by the filter", func() {
+		change := DeliveryUnitStatusChangesFromRoute(route, occurredAt)[0]
+		Expect(DeliveryUnitStatusChangeFilter{}.Matches(change)).To(BeTrue())
+		Expect(DeliveryUnitStatusChangeFilter{RouteReferenceIDs: []string{"ROUTE-001"}}.Matches(change)).To(BeTrue())
+		Expect(DeliveryUnitStatusChangeFilter{
+			OrderReferenceIDs: []string{"ORD-1"},
+			Statuses:          []string{StatusCancelled},
+		}.Matches(change)).To(BeFalse())
+	})
+
+	It("should summarize the progress of a route", func() {
+		progress := NewRouteProgress("ROUTE-001", []string{
+			StatusInTransit, StatusFinished, StatusFinished, StatusPending, StatusPartiallyDelivered, StatusCancelled,
+		})
+		Expect(progress).To(Equal(RouteProgress{
+			RouteReferenceID:   "ROUTE-001",
+			Total:              6,
+			InTransit:          1,
+			Delivered:          2,
+			PartiallyDelivered: 1,
+			NotDelivered:       1,
+			Cancelled:          1,
+		}))
+	})
+})
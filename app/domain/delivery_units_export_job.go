@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Formatos soportados por la exportación de unidades de entrega
+const (
+	DeliveryUnitsExportCSV     DeliveryUnitsExportFormat = "csv"
+	DeliveryUnitsExportXLSX    DeliveryUnitsExportFormat = "xlsx"
+	DeliveryUnitsExportGeoJSON DeliveryUnitsExportFormat = "geojson"
+)
+
+var ErrInvalidDeliveryUnitsExportFormat = errors.New("invalid export format: expected csv, xlsx or geojson")
+
+type DeliveryUnitsExportFormat string
+
+func (f DeliveryUnitsExportFormat) Validate() error {
+	switch f {
+	case DeliveryUnitsExportCSV, DeliveryUnitsExportXLSX, DeliveryUnitsExportGeoJSON:
+		return nil
+	}
+	return ErrInvalidDeliveryUnitsExportFormat
+}
+
+// Extension retorna la extensión del archivo generado, sin punto
+func (f DeliveryUnitsExportFormat) Extension() string {
+	return string(f)
+}
+
+func (f DeliveryUnitsExportFormat) ContentType() string {
+	switch f {
+	case DeliveryUnitsExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case DeliveryUnitsExportGeoJSON:
+		return "application/geo+json"
+	}
+	return "text/csv"
+}
+
+// DeliveryUnitsExportRequest es el payload del evento que dispara la exportación
+type DeliveryUnitsExportRequest struct {
+	JobID  string                    `json:"jobId"`
+	Format DeliveryUnitsExportFormat `json:"format"`
+	Filter DeliveryUnitsFilter       `json:"filter"`
+}
+
+// DeliveryUnitsExportJob registra el avance de una exportación asíncrona. Usa los mismos
+// estados que OptimizationJob.
+type DeliveryUnitsExportJob struct {
+	ID          string                    `json:"id"`
+	TenantID    uuid.UUID                 `json:"tenantId"`
+	Status      string                    `json:"status"`
+	Format      DeliveryUnitsExportFormat `json:"format"`
+	QueuedAt    time.Time                 `json:"queuedAt"`
+	StartedAt   time.Time                 `json:"startedAt"`
+	FinishedAt  time.Time                 `json:"finishedAt"`
+	Error       string                    `json:"error,omitempty"`
+	Rows        int                       `json:"rows"`
+	ObjectKey   string                    `json:"objectKey,omitempty"`
+	DownloadURL string                    `json:"downloadUrl,omitempty"`
+	ExpiresAt   time.Time                 `json:"expiresAt"`
+}
+
+// NewDeliveryUnitsExportJob crea un job en cola
+func NewDeliveryUnitsExportJob(id string, format DeliveryUnitsExportFormat, now time.Time) DeliveryUnitsExportJob {
+	return DeliveryUnitsExportJob{
+		ID:       id,
+		Status:   OptimizationJobQueued,
+		Format:   format,
+		QueuedAt: now,
+	}
+}
+
+// Start marca el inicio del procesamiento, limpiando el resultado de un intento anterior
+func (j DeliveryUnitsExportJob) Start(now time.Time) DeliveryUnitsExportJob {
+	if j.QueuedAt.IsZero() {
+		j.QueuedAt = now
+	}
+	j.Status = OptimizationJobRunning
+	j.StartedAt = now
+	j.FinishedAt = time.Time{}
+	j.Error = ""
+	j.Rows = 0
+	j.ObjectKey = ""
+	j.DownloadURL = ""
+	j.ExpiresAt = time.Time{}
+	return j
+}
+
+// Succeed registra el archivo generado y su URL de descarga
+func (j DeliveryUnitsExportJob) Succeed(now time.Time, rows int, objectKey, downloadURL string, expiresAt time.Time) DeliveryUnitsExportJob {
+	j.Status = OptimizationJobSucceeded
+	j.FinishedAt = now
+	j.Error = ""
+	j.Rows = rows
+	j.ObjectKey = objectKey
+	j.DownloadURL = downloadURL
+	j.ExpiresAt = expiresAt
+	return j
+}
+
+// Fail registra el error que detuvo la exportación
+func (j DeliveryUnitsExportJob) Fail(now time.Time, err error) DeliveryUnitsExportJob {
+	j.Status = OptimizationJobFailed
+	j.FinishedAt = now
+	if err != nil {
+		j.Error = err.Error()
+	}
+	return j
+}
+
+// IsFinished indica si el job terminó, con o sin éxito
+func (j DeliveryUnitsExportJob) IsFinished() bool {
+	return j.Status == OptimizationJobSucceeded || j.Status == OptimizationJobFailed
+}
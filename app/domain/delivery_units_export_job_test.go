@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryUnitsExportJob", func() {
+	queuedAt := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	It("should go from queued to succeeded with its download url", func() {
+		job := NewDeliveryUnitsExportJob("job-1", DeliveryUnitsExportXLSX, queuedAt)
+		Expect(job.Status).To(Equal(OptimizationJobQueued))
+		Expect(job.Format).To(Equal(DeliveryUnitsExportXLSX))
+
+		job = job.Start(queuedAt.Add(time.Second))
+		Expect(job.Status).To(Equal(OptimizationJobRunning))
+		Expect(job.IsFinished()).To(BeFalse())
+
+		expiresAt := queuedAt.Add(24 * time.Hour)
+		job = job.Succeed(queuedAt.Add(time.Minute), 250, "delivery-units/exports/job-1.xlsx", "https://link/job-1.xlsx", expiresAt)
+		Expect(job.Status).To(Equal(OptimizationJobSucceeded))
+		Expect(job.IsFinished()).To(BeTrue())
+		Expect(job.Rows).To(Equal(250))
+		Expect(job.DownloadURL).To(Equal("https://link/job-1.xlsx"))
+		Expect(job.ExpiresAt).To(Equal(expiresAt))
+	})
+
+	It("should clear a previous result when it is retried", func() {
+		job := NewDeliveryUnitsExportJob("job-1", DeliveryUnitsExportCSV, queuedAt).
+			Start(queuedAt).
+			Fail(queuedAt.Add(time.Second), errors.New("bucket unavailable")).
+			Start(queuedAt.Add(time.Minute))
+
+		Expect(job.Status).To(Equal(OptimizationJobRunning))
+		Expect(job.Error).To(BeEmpty())
+		Expect(job.FinishedAt.IsZero()).To(BeTrue())
+	})
+
+	It("should validate the format", func() {
+		Expect(DeliveryUnitsExportGeoJSON.Validate()).To(Succeed())
+		Expect(DeliveryUnitsExportFormat("pdf").Validate()).To(MatchError(ErrInvalidDeliveryUnitsExportFormat))
+		Expect(DeliveryUnitsExportGeoJSON.ContentType()).To(Equal("application/geo+json"))
+	})
+})
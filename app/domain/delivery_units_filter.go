@@ -37,6 +37,10 @@ type GroupByFilter struct {
 	Value string
 }
 
+type RouteFilter struct {
+	ReferenceIds []string
+}
+
 type DeliveryUnitFilter struct {
 	Lpns           []string
 	SizeCategories []string
@@ -52,6 +56,7 @@ type LocationFilter struct {
 	AdminAreaLevel4       []string
 	ZipCodes              []string
 	CoordinatesConfidence *CoordinatesConfidenceLevelFilter
+	GeoFilter
 }
 
 type PromisedDateFilter struct {
@@ -74,6 +79,16 @@ type CollectAvailabilityFilter struct {
 	TimeRange *TimeRangeFilter
 }
 
+type DistanceRangeFilter struct {
+	Min *float64
+	Max *float64
+}
+
+type DeliveryFilter struct {
+	SuspiciousLocation          *bool
+	DistanceToDestinationMeters *DistanceRangeFilter
+}
+
 type DeliveryUnitsFilter struct {
 	Pagination          Pagination
 	RequestedFields     map[string]any
@@ -84,4 +99,6 @@ type DeliveryUnitsFilter struct {
 	Destination         *LocationFilter
 	PromisedDate        *PromisedDateFilter
 	CollectAvailability *CollectAvailabilityFilter
+	Delivery            *DeliveryFilter
+	Route               *RouteFilter
 }
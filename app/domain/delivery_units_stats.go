@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Dimensiones por las que se pueden agrupar las estadísticas de unidades de entrega.
+// Los niveles de área administrativa corresponden a la dirección de destino.
+const (
+	DeliveryUnitsStatsGroupByStatus          DeliveryUnitsStatsGroupBy = "status"
+	DeliveryUnitsStatsGroupByAdminAreaLevel1 DeliveryUnitsStatsGroupBy = "adminAreaLevel1"
+	DeliveryUnitsStatsGroupByAdminAreaLevel2 DeliveryUnitsStatsGroupBy = "adminAreaLevel2"
+	DeliveryUnitsStatsGroupByAdminAreaLevel3 DeliveryUnitsStatsGroupBy = "adminAreaLevel3"
+	DeliveryUnitsStatsGroupByAdminAreaLevel4 DeliveryUnitsStatsGroupBy = "adminAreaLevel4"
+	DeliveryUnitsStatsGroupByPromisedDate    DeliveryUnitsStatsGroupBy = "promisedDate"
+	DeliveryUnitsStatsGroupByOrderType       DeliveryUnitsStatsGroupBy = "orderType"
+)
+
+var ErrInvalidDeliveryUnitsStatsGroupBy = errors.New("invalid delivery units stats groupBy")
+
+type DeliveryUnitsStatsGroupBy string
+
+func (g DeliveryUnitsStatsGroupBy) Validate() error {
+	switch g {
+	case DeliveryUnitsStatsGroupByStatus,
+		DeliveryUnitsStatsGroupByAdminAreaLevel1,
+		DeliveryUnitsStatsGroupByAdminAreaLevel2,
+		DeliveryUnitsStatsGroupByAdminAreaLevel3,
+		DeliveryUnitsStatsGroupByAdminAreaLevel4,
+		DeliveryUnitsStatsGroupByPromisedDate,
+		DeliveryUnitsStatsGroupByOrderType:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidDeliveryUnitsStatsGroupBy, string(g))
+}
+
+// DeliveryUnitsStatsGrouping es la combinación de dimensiones solicitada. Valida cada
+// dimensión y descarta duplicados conservando el orden recibido.
+type DeliveryUnitsStatsGrouping []DeliveryUnitsStatsGroupBy
+
+func (g DeliveryUnitsStatsGrouping) Normalize() (DeliveryUnitsStatsGrouping, error) {
+	normalized := make(DeliveryUnitsStatsGrouping, 0, len(g))
+	seen := make(map[DeliveryUnitsStatsGroupBy]bool, len(g))
+	for _, groupBy := range g {
+		if err := groupBy.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[groupBy] {
+			continue
+		}
+		seen[groupBy] = true
+		normalized = append(normalized, groupBy)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryUnitsStatsGrouping", func() {
+	It("should keep the requested order and drop duplicates", func() {
+		grouping, err := DeliveryUnitsStatsGrouping{
+			DeliveryUnitsStatsGroupByOrderType,
+			DeliveryUnitsStatsGroupByStatus,
+			DeliveryUnitsStatsGroupByOrderType,
+		}.Normalize()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(grouping).To(Equal(DeliveryUnitsStatsGrouping{
+			DeliveryUnitsStatsGroupByOrderType,
+			DeliveryUnitsStatsGroupByStatus,
+		}))
+	})
+
+	It("should allow an empty grouping for global totals", func() {
+		grouping, err := DeliveryUnitsStatsGrouping(nil).Normalize()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(grouping).To(BeEmpty())
+	})
+
+	It("should reject unknown dimensions", func() {
+		_, err := DeliveryUnitsStatsGrouping{"carrier"}.Normalize()
+		Expect(err).To(MatchError(ErrInvalidDeliveryUnitsStatsGroupBy))
+	})
+})
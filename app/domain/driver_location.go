@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/paulmach/orb"
+)
+
+// DriverLocation representa un ping GPS reportado por el vehículo mientras ejecuta una ruta
+type DriverLocation struct {
+	RouteReferenceID string
+	VehiclePlate     string
+	Driver           Driver
+	Point            orb.Point
+	RecordedAt       time.Time
+	SpeedKmh         float64
+	AccuracyMeters   float64
+}
+
+func (l DriverLocation) Validate() error {
+	if l.RouteReferenceID == "" {
+		return ErrInvalidReferenceID
+	}
+	if l.RecordedAt.IsZero() {
+		return ErrInvalidDriverLocation
+	}
+	if l.Point.Lat() < -90 || l.Point.Lat() > 90 || l.Point.Lon() < -180 || l.Point.Lon() > 180 {
+		return ErrInvalidDriverLocation
+	}
+	return nil
+}
+
+// IsNewerThan indica si el ping debe reemplazar a la última posición conocida de la ruta
+func (l DriverLocation) IsNewerThan(other DriverLocation) bool {
+	return l.RecordedAt.After(other.RecordedAt)
+}
+
+// LatestDriverLocations retorna el ping más reciente de cada ruta
+func LatestDriverLocations(locations []DriverLocation) []DriverLocation {
+	latest := make(map[string]DriverLocation)
+	var order []string
+	for _, location := range locations {
+		current, exists := latest[location.RouteReferenceID]
+		if !exists {
+			order = append(order, location.RouteReferenceID)
+		}
+		if !exists || location.IsNewerThan(current) {
+			latest[location.RouteReferenceID] = location
+		}
+	}
+	result := make([]DriverLocation, 0, len(order))
+	for _, routeReferenceID := range order {
+		result = append(result, latest[routeReferenceID])
+	}
+	return result
+}
+
+// RouteBreadcrumb es un punto del recorrido submuestreado de una ruta. Cada ruta conserva a lo
+// sumo un punto por ventana de tiempo, de modo que reprocesar un lote no duplica el recorrido
+type RouteBreadcrumb struct {
+	DriverLocation
+	WindowStart time.Time
+}
+
+func (b RouteBreadcrumb) DocID(ctx context.Context) DocumentID {
+	return HashByTenant(ctx, "route_breadcrumb", b.RouteReferenceID, b.WindowStart.UTC().Format(time.RFC3339))
+}
+
+// DownsampleDriverLocations conserva el primer ping de cada ventana de tiempo por ruta
+func DownsampleDriverLocations(locations []DriverLocation, window time.Duration) []RouteBreadcrumb {
+	sorted := make([]DriverLocation, len(locations))
+	copy(sorted, locations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RecordedAt.Before(sorted[j].RecordedAt)
+	})
+
+	seen := make(map[string]bool)
+	var breadcrumbs []RouteBreadcrumb
+	for _, location := range sorted {
+		windowStart := location.RecordedAt.UTC().Truncate(window)
+		key := location.RouteReferenceID + "|" + windowStart.Format(time.RFC3339)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		breadcrumbs = append(breadcrumbs, RouteBreadcrumb{
+			DriverLocation: location,
+			WindowStart:    windowStart,
+		})
+	}
+	return breadcrumbs
+}
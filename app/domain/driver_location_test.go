@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/paulmach/orb"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DriverLocation", func() {
+	start := time.Date(2025, 6, 6, 14, 30, 0, 0, time.UTC)
+	ping := func(route string, offset time.Duration) DriverLocation {
+		return DriverLocation{
+			RouteReferenceID: route,
+			Point:            orb.Point{-70.6130425, -33.5147889},
+			RecordedAt:       start.Add(offset),
+		}
+	}
+
+	It("should reject pings without timestamp or with invalid coordinates", func() {
+		Expect(ping("route-1", 0).Validate()).To(Succeed())
+		Expect(DriverLocation{RecordedAt: start}.Validate()).To(MatchError(ErrInvalidReferenceID))
+		Expect(DriverLocation{RouteReferenceID: "route-1"}.Validate()).To(MatchError(ErrInvalidDriverLocation))
+
+		invalid := ping("route-1", 0)
+		invalid.Point = orb.Point{-70.61, -95}
+		Expect(invalid.Validate()).To(MatchError(ErrInvalidDriverLocation))
+	})
+
+	It("should keep the most recent ping of each route", func() {
+		latest := LatestDriverLocations([]DriverLocation{
+			ping("route-1", 20*time.Second),
+			ping("route-2", 5*time.Second),
+			ping("route-1", 40*time.Second),
+			ping("route-1", 10*time.Second),
+		})
+		Expect(latest).To(HaveLen(2))
+		Expect(latest[0].RouteReferenceID).To(Equal("route-1"))
+		Expect(latest[0].RecordedAt).To(Equal(start.Add(40 * time.Second)))
+		Expect(latest[1].RouteReferenceID).To(Equal("route-2"))
+	})
+
+	It("should keep the first ping of each window per route", func() {
+		breadcrumbs := DownsampleDriverLocations([]DriverLocation{
+			ping("route-1", 45*time.Second),
+			ping("route-1", 10*time.Second),
+			ping("route-1", 25*time.Second),
+			ping("route-2", 15*time.Second),
+		}, 30*time.Second)
+		Expect(breadcrumbs).To(HaveLen(3))
+		Expect(breadcrumbs[0].RecordedAt).To(Equal(start.Add(10 * time.Second)))
+		Expect(breadcrumbs[0].WindowStart).To(Equal(start))
+		Expect(breadcrumbs[1].RouteReferenceID).To(Equal("route-2"))
+		Expect(breadcrumbs[2].RecordedAt).To(Equal(start.Add(45 * time.Second)))
+		Expect(breadcrumbs[2].WindowStart).To(Equal(start.Add(30 * time.Second)))
+	})
+})
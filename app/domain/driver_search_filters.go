@@ -0,0 +1,9 @@
+package domain
+
+type DriverSearchFilters struct {
+	Pagination      Pagination
+	RequestedFields map[string]any
+	NationalIDs     []string
+	Emails          []string
+	Name            *string
+}
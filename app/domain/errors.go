@@ -7,4 +7,14 @@ var (
 	ErrInvalidTimeFormat    = errors.New("invalid time format")
 	ErrInvalidPackageFormat = errors.New("invalid package format")
 	ErrInvalidReferenceID   = errors.New("invalid reference ID: cannot be empty")
+
+	ErrInvalidStatusTransition    = errors.New("invalid delivery unit status transition")
+	ErrManualChangeReasonRequired = errors.New("manual change reason is required to override a status transition")
+
+	ErrInvalidDeliveryPinPolicy = errors.New("invalid delivery pin policy")
+	ErrDeliveryPinRejected      = errors.New("delivery pin is missing or does not match")
+	ErrDeliveryPinKeyMissing    = errors.New("DELIVERY_PIN_HMAC_KEY is not configured")
+	ErrDeliveryPinLocked        = errors.New("too many failed delivery pin attempts, try again later")
+
+	ErrInvalidDriverLocation = errors.New("invalid driver location: timestamp and valid coordinates are required")
 )
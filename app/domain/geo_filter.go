@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/paulmach/orb"
+)
+
+// maxGeoFilterPolygonVertices limita el tamaño del polígono, que se traduce a una expresión SQL por arista
+const maxGeoFilterPolygonVertices = 1000
+
+var ErrInvalidGeoFilter = errors.New("invalid geo filter")
+
+// GeoFilter restringe resultados según las coordenadas almacenadas de una dirección.
+// Los filtros informados se combinan con AND.
+type GeoFilter struct {
+	WithinPolygon orb.Polygon
+	WithinRadius  *RadiusFilter
+	BoundingBox   *orb.Bound
+}
+
+type RadiusFilter struct {
+	Center orb.Point
+	Meters float64
+}
+
+func (f GeoFilter) IsEmpty() bool {
+	return len(f.WithinPolygon) == 0 && f.WithinRadius == nil && f.BoundingBox == nil
+}
+
+func (f GeoFilter) Validate() error {
+	if len(f.WithinPolygon) > 0 {
+		vertices := 0
+		for _, ring := range f.WithinPolygon {
+			if err := validateRing(ring); err != nil {
+				return err
+			}
+			vertices += len(ring)
+		}
+		if vertices > maxGeoFilterPolygonVertices {
+			return fmt.Errorf("%w: withinPolygon exceeds %d vertices", ErrInvalidGeoFilter, maxGeoFilterPolygonVertices)
+		}
+	}
+	if f.WithinRadius != nil {
+		if f.WithinRadius.Meters <= 0 {
+			return fmt.Errorf("%w: withinRadius meters must be greater than zero", ErrInvalidGeoFilter)
+		}
+		if err := validatePoint(f.WithinRadius.Center); err != nil {
+			return err
+		}
+	}
+	if f.BoundingBox != nil {
+		if err := validatePoint(f.BoundingBox.Min); err != nil {
+			return err
+		}
+		if err := validatePoint(f.BoundingBox.Max); err != nil {
+			return err
+		}
+		if f.BoundingBox.Min.Lat() > f.BoundingBox.Max.Lat() || f.BoundingBox.Min.Lon() > f.BoundingBox.Max.Lon() {
+			return fmt.Errorf("%w: boundingBox southWest must be below and left of northEast", ErrInvalidGeoFilter)
+		}
+	}
+	return nil
+}
+
+// validateRing acepta anillos abiertos o cerrados con al menos tres vértices distintos
+// y área distinta de cero; un anillo degenerado no cruza ningún rayo y no filtra nada.
+func validateRing(ring orb.Ring) error {
+	vertices := make(map[orb.Point]struct{}, len(ring))
+	for _, point := range ring {
+		if err := validatePoint(point); err != nil {
+			return err
+		}
+		vertices[point] = struct{}{}
+	}
+	if len(vertices) < 3 {
+		return fmt.Errorf("%w: withinPolygon rings need at least 3 distinct vertices", ErrInvalidGeoFilter)
+	}
+	if ringArea(ring) == 0 {
+		return fmt.Errorf("%w: withinPolygon rings must enclose a non-zero area", ErrInvalidGeoFilter)
+	}
+	return nil
+}
+
+// ringArea calcula el doble del área con signo (fórmula del zapato), suficiente para detectar anillos colineales.
+func ringArea(ring orb.Ring) float64 {
+	var area float64
+	for i := range ring {
+		from, to := ring[i], ring[(i+1)%len(ring)]
+		area += from.Lon()*to.Lat() - to.Lon()*from.Lat()
+	}
+	return area
+}
+
+func validatePoint(point orb.Point) error {
+	if point.Lat() < -90 || point.Lat() > 90 || point.Lon() < -180 || point.Lon() > 180 {
+		return fmt.Errorf("%w: coordinates out of range (%v, %v)", ErrInvalidGeoFilter, point.Lat(), point.Lon())
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/paulmach/orb"
+)
+
+var _ = Describe("GeoFilter", func() {
+	square := orb.Polygon{{{-70.7, -33.5}, {-70.5, -33.5}, {-70.5, -33.3}, {-70.7, -33.3}}}
+
+	It("should be empty when no geometry is set", func() {
+		Expect(GeoFilter{}.IsEmpty()).To(BeTrue())
+		Expect(GeoFilter{WithinPolygon: square}.IsEmpty()).To(BeFalse())
+	})
+
+	It("should accept open and closed rings", func() {
+		closed := orb.Polygon{append(orb.Ring{}, append(square[0], square[0][0])...)}
+		Expect(GeoFilter{WithinPolygon: square}.Validate()).To(Succeed())
+		Expect(GeoFilter{WithinPolygon: closed}.Validate()).To(Succeed())
+	})
+
+	DescribeTable("should reject invalid geometries",
+		func(filter GeoFilter) {
+			Expect(filter.Validate()).To(MatchError(ErrInvalidGeoFilter))
+		},
+		Entry("degenerate ring", GeoFilter{WithinPolygon: orb.Polygon{{{0, 0}, {1, 1}, {0, 0}}}}),
+		Entry("repeated vertex", GeoFilter{WithinPolygon: orb.Polygon{{{1, 1}, {1, 1}, {1, 1}}}}),
+		Entry("collinear ring", GeoFilter{WithinPolygon: orb.Polygon{{{-70.7, -33.5}, {-70.6, -33.5}, {-70.5, -33.5}}}}),
+		Entry("zero radius", GeoFilter{WithinRadius: &RadiusFilter{Center: orb.Point{-70.6, -33.4}}}),
+		Entry("latitude out of range", GeoFilter{WithinRadius: &RadiusFilter{Center: orb.Point{-70.6, -95}, Meters: 100}}),
+		Entry("inverted bounding box", GeoFilter{BoundingBox: &orb.Bound{Min: orb.Point{-70.5, -33.3}, Max: orb.Point{-70.7, -33.5}}}),
+	)
+})
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ItemConfirmation registra cuántas unidades de un SKU se entregaron y cuántas se
+// rechazaron al confirmar la entrega, con el código de motivo informado en ruta
+type ItemConfirmation struct {
+	Sku               string
+	DeliveredQuantity int
+	RejectedQuantity  int
+	ReasonCode        string
+}
+
+type ItemConfirmations []ItemConfirmation
+
+func (c ItemConfirmations) DocID(ctx context.Context) DocumentID {
+	inputs := make([]string, len(c))
+	for i, item := range c {
+		inputs[i] = fmt.Sprintf("%s:%d:%d:%s", item.Sku, item.DeliveredQuantity, item.RejectedQuantity, item.ReasonCode)
+	}
+	sort.Strings(inputs)
+	return HashByTenant(ctx, inputs...)
+}
+
+// Delivered retorna el total de unidades entregadas
+func (c ItemConfirmations) Delivered() int {
+	total := 0
+	for _, item := range c {
+		total += item.DeliveredQuantity
+	}
+	return total
+}
+
+// Rejected retorna el total de unidades rechazadas
+func (c ItemConfirmations) Rejected() int {
+	total := 0
+	for _, item := range c {
+		total += item.RejectedQuantity
+	}
+	return total
+}
@@ -7,6 +7,7 @@ type NodesFilter struct {
 	Name            *string
 	NodeType        *NodeTypeFilter
 	References      []ReferenceFilter
+	GeoFilter
 }
 
 type NodeTypeFilter struct {
@@ -1,5 +1,7 @@
 package optimization
 
+import "time"
+
 // Coordinates representa coordenadas geográficas
 type Coordinates struct {
 	Latitude  float64
@@ -24,6 +26,26 @@ type TimeWindow struct {
 	End   string
 }
 
+// IsValid indica si la ventana tiene inicio y fin
+func (tw TimeWindow) IsValid() bool {
+	return tw.Start != "" && tw.End != ""
+}
+
+// mergeTimeWindows combina la ventana única heredada con la lista de ventanas,
+// descartando las incompletas
+func mergeTimeWindows(single TimeWindow, windows []TimeWindow) []TimeWindow {
+	var out []TimeWindow
+	for _, tw := range windows {
+		if tw.IsValid() {
+			out = append(out, tw)
+		}
+	}
+	if len(out) == 0 && single.IsValid() {
+		out = append(out, single)
+	}
+	return out
+}
+
 // Capacity representa la capacidad de un vehículo
 type Capacity struct {
 	Insurance             int64
@@ -47,6 +69,8 @@ type PoliticalArea struct {
 	AdminAreaLevel2 string
 	AdminAreaLevel3 string
 	AdminAreaLevel4 string
+	// TimeZone es la zona horaria IANA de la dirección, por ejemplo America/Santiago
+	TimeZone string
 }
 
 // AddressInfo representa información completa de dirección
@@ -66,6 +90,12 @@ type VisitLocation struct {
 	NodeInfo     NodeInfo
 	ServiceTime  int64
 	TimeWindow   TimeWindow
+	TimeWindows  []TimeWindow
+}
+
+// Windows retorna todas las ventanas válidas de la visita
+func (vl VisitLocation) Windows() []TimeWindow {
+	return mergeTimeWindows(vl.TimeWindow, vl.TimeWindows)
 }
 
 // Item representa un artículo
@@ -85,10 +115,26 @@ type DeliveryUnit struct {
 	Skills []string
 }
 
+// PromisedDate representa la fecha comprometida y la categoría de servicio de una orden
+type PromisedDate struct {
+	StartDate       time.Time
+	EndDate         time.Time
+	ServiceCategory string
+}
+
+// Deadline retorna el último día comprometido para la orden
+func (pd PromisedDate) Deadline() time.Time {
+	if !pd.EndDate.IsZero() {
+		return pd.EndDate
+	}
+	return pd.StartDate
+}
+
 // Order representa una orden
 type Order struct {
 	DeliveryUnits []DeliveryUnit
 	ReferenceID   string
+	PromisedDate  PromisedDate
 }
 
 // Vehicle representa un vehículo
@@ -98,7 +144,38 @@ type Vehicle struct {
 	EndLocation   VehicleLocation
 	Skills        []string
 	TimeWindow    TimeWindow
+	TimeWindows   []TimeWindow
 	Capacity      Capacity
+	Breaks        []Break
+	MaxTasks      int
+	MaxTravelTime int64
+	MaxDistance   int64
+	Costs         VehicleCosts
+}
+
+// VehicleCosts representa el modelo de costos de un vehículo
+type VehicleCosts struct {
+	Fixed   int64
+	PerHour int64
+	PerKm   int64
+}
+
+// IsZero indica si no se configuró ningún costo
+func (c VehicleCosts) IsZero() bool {
+	return c.Fixed == 0 && c.PerHour == 0 && c.PerKm == 0
+}
+
+// Break representa una pausa obligatoria del conductor
+type Break struct {
+	TimeWindows []TimeWindow
+	Duration    int64
+	Description string
+	MaxLoad     *Capacity
+}
+
+// Windows retorna todas las ventanas válidas del vehículo
+func (v Vehicle) Windows() []TimeWindow {
+	return mergeTimeWindows(v.TimeWindow, v.TimeWindows)
 }
 
 // VehicleLocation representa una ubicación del vehículo
@@ -112,11 +189,14 @@ type Visit struct {
 	Pickup   VisitLocation
 	Delivery VisitLocation
 	Orders   []Order
+	Priority int
 }
 
 // FleetOptimization representa la estructura principal de optimización
 type FleetOptimization struct {
 	PlanReferenceID string
-	Vehicles        []Vehicle
-	Visits          []Visit
+	// PlannedDate es el día en que se ejecuta el plan; vacío equivale al día de la optimización
+	PlannedDate time.Time
+	Vehicles    []Vehicle
+	Visits      []Visit
 }
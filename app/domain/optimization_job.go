@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+const (
+	OptimizationJobQueued    = "queued"
+	OptimizationJobRunning   = "running"
+	OptimizationJobSucceeded = "succeeded"
+	OptimizationJobFailed    = "failed"
+)
+
+// OptimizationJob registra el avance de una optimización asíncrona. Su ID es la llave de
+// idempotencia con la que se procesa la solicitud.
+type OptimizationJob struct {
+	ID              string    `json:"id"`
+	Status          string    `json:"status"`
+	QueuedAt        time.Time `json:"queuedAt"`
+	StartedAt       time.Time `json:"startedAt"`
+	FinishedAt      time.Time `json:"finishedAt"`
+	Error           string    `json:"error,omitempty"`
+	PlanReferenceID string    `json:"planReferenceID,omitempty"`
+	Routes          []string  `json:"routes,omitempty"`
+	// ForwardedTo apunta al job que efectivamente ejecuta la optimización, cuando la
+	// solicitud original se transforma antes de optimizarse (por ejemplo la del agente)
+	ForwardedTo string `json:"forwardedTo,omitempty"`
+}
+
+// NewOptimizationJob crea un job en cola
+func NewOptimizationJob(id string, now time.Time) OptimizationJob {
+	return OptimizationJob{
+		ID:       id,
+		Status:   OptimizationJobQueued,
+		QueuedAt: now,
+	}
+}
+
+// Start marca el inicio del procesamiento, limpiando el resultado de un intento anterior
+func (j OptimizationJob) Start(now time.Time) OptimizationJob {
+	if j.QueuedAt.IsZero() {
+		j.QueuedAt = now
+	}
+	j.Status = OptimizationJobRunning
+	j.StartedAt = now
+	j.FinishedAt = time.Time{}
+	j.Error = ""
+	j.PlanReferenceID = ""
+	j.Routes = nil
+	return j
+}
+
+// Succeed registra las rutas resultantes de la optimización
+func (j OptimizationJob) Succeed(now time.Time, planReferenceID string, routes []string) OptimizationJob {
+	j.Status = OptimizationJobSucceeded
+	j.FinishedAt = now
+	j.Error = ""
+	j.PlanReferenceID = planReferenceID
+	j.Routes = routes
+	return j
+}
+
+// Fail registra el error que detuvo la optimización
+func (j OptimizationJob) Fail(now time.Time, err error) OptimizationJob {
+	j.Status = OptimizationJobFailed
+	j.FinishedAt = now
+	if err != nil {
+		j.Error = err.Error()
+	}
+	return j
+}
+
+// IsFinished indica si el job terminó, con o sin éxito
+func (j OptimizationJob) IsFinished() bool {
+	return j.Status == OptimizationJobSucceeded || j.Status == OptimizationJobFailed
+}
+
+// Duration retorna el tiempo de procesamiento, o el transcurrido si aún está corriendo
+func (j OptimizationJob) Duration(now time.Time) time.Duration {
+	if j.StartedAt.IsZero() {
+		return 0
+	}
+	if j.FinishedAt.IsZero() {
+		return now.Sub(j.StartedAt)
+	}
+	return j.FinishedAt.Sub(j.StartedAt)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OptimizationJob", func() {
+	queuedAt := time.Date(2025, 3, 28, 10, 0, 0, 0, time.UTC)
+
+	It("should go from queued to succeeded with its routes", func() {
+		job := NewOptimizationJob("optimize_fleet:abc", queuedAt)
+		Expect(job.Status).To(Equal(OptimizationJobQueued))
+
+		job = job.Start(queuedAt.Add(time.Second))
+		Expect(job.Status).To(Equal(OptimizationJobRunning))
+		Expect(job.IsFinished()).To(BeFalse())
+		Expect(job.Duration(queuedAt.Add(5 * time.Second))).To(Equal(4 * time.Second))
+
+		job = job.Succeed(queuedAt.Add(10*time.Second), "PLAN-1", []string{"R1", "R2"})
+		Expect(job.Status).To(Equal(OptimizationJobSucceeded))
+		Expect(job.IsFinished()).To(BeTrue())
+		Expect(job.Routes).To(Equal([]string{"R1", "R2"}))
+		Expect(job.Duration(queuedAt.Add(time.Hour))).To(Equal(9 * time.Second))
+	})
+
+	It("should record the error when it fails", func() {
+		job := NewOptimizationJob("optimize_fleet:abc", queuedAt).
+			Start(queuedAt).
+			Fail(queuedAt.Add(time.Second), errors.New("vroom unavailable"))
+
+		Expect(job.Status).To(Equal(OptimizationJobFailed))
+		Expect(job.Error).To(Equal("vroom unavailable"))
+	})
+
+	It("should clear a previous result when it is retried", func() {
+		job := NewOptimizationJob("optimize_fleet:abc", queuedAt).
+			Start(queuedAt).
+			Fail(queuedAt.Add(time.Second), errors.New("timeout")).
+			Start(queuedAt.Add(time.Minute))
+
+		Expect(job.Status).To(Equal(OptimizationJobRunning))
+		Expect(job.Error).To(BeEmpty())
+		Expect(job.FinishedAt.IsZero()).To(BeTrue())
+		Expect(job.QueuedAt).To(Equal(queuedAt))
+	})
+})
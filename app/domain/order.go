@@ -164,6 +164,15 @@ func (o Order) Validate() error {
 		return apperrors.MarkAsAlertable(errors.Wrap(err, "validation failed for PromisedDate"))
 	}
 
+	if !IsValidDeliveryPinPolicy(o.OrderType.DeliveryPinPolicy) {
+		return apperrors.MarkAsAlertable(errors.Wrapf(
+			ErrInvalidDeliveryPinPolicy,
+			"deliveryPinPolicy: %s, expected one of %s, %s or %s",
+			o.OrderType.DeliveryPinPolicy,
+			DeliveryPinPolicyDisabled, DeliveryPinPolicyFlag, DeliveryPinPolicyReject,
+		))
+	}
+
 	// Validar duplicados en unidades de entrega
 	if err := o.ValidateDeliveryUnits(); err != nil {
 		return err
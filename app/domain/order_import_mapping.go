@@ -0,0 +1,32 @@
+package domain
+
+import "strings"
+
+// OrderImportMapping asocia cada clave canónica de importación de órdenes con la
+// cabecera del archivo del tenant que la contiene
+type OrderImportMapping struct {
+	Columns map[string]string
+}
+
+// IsEmpty indica que el tenant no tiene un mapeo guardado
+func (m OrderImportMapping) IsEmpty() bool {
+	return len(m.Columns) == 0
+}
+
+// AppliesTo indica si todas las cabeceras del mapeo existen en el archivo recibido;
+// la comparación ignora mayúsculas y espacios en los extremos
+func (m OrderImportMapping) AppliesTo(headers []string) bool {
+	if m.IsEmpty() {
+		return false
+	}
+	present := make(map[string]struct{}, len(headers))
+	for _, h := range headers {
+		present[strings.ToLower(strings.TrimSpace(h))] = struct{}{}
+	}
+	for _, header := range m.Columns {
+		if _, ok := present[strings.ToLower(strings.TrimSpace(header))]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrderImportMapping", func() {
+	mapping := OrderImportMapping{Columns: map[string]string{
+		"referenceID":       "Folio",
+		"deliveryUnits.lpn": "Bulto",
+	}}
+
+	It("should apply when every mapped header is present ignoring case", func() {
+		Expect(mapping.AppliesTo([]string{"folio", " BULTO ", "Comuna"})).To(BeTrue())
+	})
+
+	It("should not apply when a mapped header is missing", func() {
+		Expect(mapping.AppliesTo([]string{"Folio", "Comuna"})).To(BeFalse())
+	})
+
+	It("should not apply an empty mapping", func() {
+		Expect(OrderImportMapping{}.AppliesTo([]string{"Folio"})).To(BeFalse())
+	})
+})
@@ -5,6 +5,8 @@ import "context"
 type OrderType struct {
 	Type        string
 	Description string
+	// DeliveryPinPolicy indica si las órdenes de este tipo exigen PIN de entrega
+	DeliveryPinPolicy string
 }
 
 func (ot OrderType) DocID(ctx context.Context) DocumentID {
@@ -18,5 +20,10 @@ func (ot OrderType) UpdateIfChanged(newOrderType OrderType) (OrderType, bool) {
 		ot.Description = newOrderType.Description
 		changed = true
 	}
+
+	if newOrderType.DeliveryPinPolicy != "" && newOrderType.DeliveryPinPolicy != ot.DeliveryPinPolicy {
+		ot.DeliveryPinPolicy = newOrderType.DeliveryPinPolicy
+		changed = true
+	}
 	return ot, changed
 }
@@ -72,6 +72,18 @@ var _ = Describe("OrderType", func() {
 			Expect(updated).To(Equal(original))
 		})
 
+		It("should update the delivery pin policy when informed", func() {
+			original := OrderType{Type: "retail", DeliveryPinPolicy: DeliveryPinPolicyFlag}
+
+			updated, changed := original.UpdateIfChanged(OrderType{Type: "retail", DeliveryPinPolicy: DeliveryPinPolicyReject})
+			Expect(changed).To(BeTrue())
+			Expect(updated.DeliveryPinPolicy).To(Equal(DeliveryPinPolicyReject))
+
+			unchanged, changed := updated.UpdateIfChanged(OrderType{Type: "retail"})
+			Expect(changed).To(BeFalse())
+			Expect(unchanged.DeliveryPinPolicy).To(Equal(DeliveryPinPolicyReject))
+		})
+
 		It("should ignore empty fields in input", func() {
 			original := OrderType{
 				Type:        "retail",
@@ -6,6 +6,9 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
+// DefaultPageSize is the page size used when neither 'first' nor 'last' is given.
+const DefaultPageSize = 20
+
 // Pagination defines Relay-style pagination parameters.
 //
 // Fields:
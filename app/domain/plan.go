@@ -28,6 +28,11 @@ func (p Plan) UpdateIfChanged(newPlan Plan) (Plan, bool) {
 		changed = true
 	}
 
+	if len(newPlan.UnassignedOrders) > 0 && !sameUnassignedOrders(p.UnassignedOrders, newPlan.UnassignedOrders) {
+		p.UnassignedOrders = newPlan.UnassignedOrders
+		changed = true
+	}
+
 	return p, changed
 }
 
@@ -50,3 +55,16 @@ func (p *Plan) AddUnassignedOrders(orders []Order, reason string) {
 		p.UnassignedOrders = append(p.UnassignedOrders, order)
 	}
 }
+
+// sameUnassignedOrders compara órdenes sin asignar por referencia y motivo
+func sameUnassignedOrders(a, b []Order) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ReferenceID != b[i].ReferenceID || a[i].UnassignedReason != b[i].UnassignedReason {
+			return false
+		}
+	}
+	return true
+}
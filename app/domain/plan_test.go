@@ -100,6 +100,24 @@ var _ = Describe("Plan", func() {
 			Expect(updated).To(Equal(original))
 		})
 
+		It("should update unassigned orders and their reasons", func() {
+			original := Plan{
+				ReferenceID:      "PLAN-001",
+				PlannedDate:      now,
+				UnassignedOrders: []Order{{ReferenceID: "ORDER-1", UnassignedReason: "capacity"}},
+			}
+			newPlan := Plan{
+				UnassignedOrders: []Order{{ReferenceID: "ORDER-1", UnassignedReason: "time window"}},
+			}
+
+			updated, changed := original.UpdateIfChanged(newPlan)
+			Expect(changed).To(BeTrue())
+			Expect(updated.UnassignedOrders[0].UnassignedReason).To(Equal("time window"))
+
+			_, changed = updated.UpdateIfChanged(newPlan)
+			Expect(changed).To(BeFalse())
+		})
+
 		It("should ignore ReferenceID even if provided in newPlan", func() {
 			original := Plan{
 				ReferenceID: "PLAN-001",
@@ -0,0 +1,8 @@
+package domain
+
+type PlansFilter struct {
+	Pagination      Pagination
+	RequestedFields map[string]any
+	ReferenceIds    []string
+	PlannedDate     *DateRangeFilter
+}
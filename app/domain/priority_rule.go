@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MinVisitPriority = 0
+	MaxVisitPriority = 100
+)
+
+// PriorityRule deriva la prioridad de una visita a partir de la categoría de servicio
+// y la cercanía de la fecha prometida. Una categoría vacía aplica a cualquier orden y
+// MaxDaysToPromisedDate nil aplica sin importar la fecha.
+type PriorityRule struct {
+	ServiceCategory       string `json:"serviceCategory"`
+	MaxDaysToPromisedDate *int   `json:"maxDaysToPromisedDate,omitempty"`
+	Priority              int    `json:"priority"`
+}
+
+// DefaultPriorityRules se usan cuando el tenant no ha configurado reglas propias
+var DefaultPriorityRules = PriorityRules{
+	{ServiceCategory: "express", Priority: 100},
+	{ServiceCategory: "same day", Priority: 80},
+}
+
+func (r PriorityRule) DocID(ctx context.Context) DocumentID {
+	maxDays := ""
+	if r.MaxDaysToPromisedDate != nil {
+		maxDays = strconv.Itoa(*r.MaxDaysToPromisedDate)
+	}
+	return HashByTenant(ctx, normalizeServiceCategory(r.ServiceCategory), maxDays)
+}
+
+func (r PriorityRule) Validate() error {
+	if r.Priority < MinVisitPriority || r.Priority > MaxVisitPriority {
+		return fmt.Errorf("priority must be between %d and %d", MinVisitPriority, MaxVisitPriority)
+	}
+	if r.MaxDaysToPromisedDate != nil && *r.MaxDaysToPromisedDate < 0 {
+		return fmt.Errorf("maxDaysToPromisedDate must not be negative")
+	}
+	if r.ServiceCategory == "" && r.MaxDaysToPromisedDate == nil {
+		return fmt.Errorf("serviceCategory or maxDaysToPromisedDate is required")
+	}
+	return nil
+}
+
+// UpdateIfChanged solo la prioridad puede cambiar: categoría y días identifican la regla
+func (r PriorityRule) UpdateIfChanged(newRule PriorityRule) (PriorityRule, bool) {
+	if newRule.Priority == r.Priority {
+		return r, false
+	}
+	updated := r
+	updated.Priority = newRule.Priority
+	return updated, true
+}
+
+// Matches indica si la regla aplica a una orden con la categoría y fecha prometida indicadas
+func (r PriorityRule) Matches(serviceCategory string, promisedDate, now time.Time) bool {
+	if r.ServiceCategory != "" &&
+		normalizeServiceCategory(r.ServiceCategory) != normalizeServiceCategory(serviceCategory) {
+		return false
+	}
+	if r.MaxDaysToPromisedDate == nil {
+		return true
+	}
+	if promisedDate.IsZero() {
+		return false
+	}
+	return daysUntil(now, promisedDate) <= *r.MaxDaysToPromisedDate
+}
+
+type PriorityRules []PriorityRule
+
+// Resolve retorna la mayor prioridad entre las reglas que aplican y si alguna aplicó
+func (rules PriorityRules) Resolve(serviceCategory string, promisedDate, now time.Time) (int, bool) {
+	priority, matched := MinVisitPriority, false
+	for _, r := range rules {
+		if !r.Matches(serviceCategory, promisedDate, now) {
+			continue
+		}
+		if !matched || r.Priority > priority {
+			priority = r.Priority
+		}
+		matched = true
+	}
+	return priority, matched
+}
+
+// normalizeServiceCategory iguala variantes como "SAME DAY", "same-day" y "same_day"
+func normalizeServiceCategory(category string) string {
+	category = strings.ToLower(strings.TrimSpace(category))
+	return strings.NewReplacer("-", " ", "_", " ").Replace(category)
+}
+
+// daysUntil cuenta días calendario entre now y date; negativo si la fecha ya pasó
+func daysUntil(now, date time.Time) int {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
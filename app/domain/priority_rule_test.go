@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PriorityRules", func() {
+	now := time.Date(2025, 3, 28, 10, 0, 0, 0, time.UTC)
+	days := func(n int) *int { return &n }
+
+	It("should match service categories regardless of case and separators", func() {
+		rule := PriorityRule{ServiceCategory: "SAME DAY", Priority: 80}
+
+		Expect(rule.Matches("same-day", time.Time{}, now)).To(BeTrue())
+		Expect(rule.Matches("same_day", time.Time{}, now)).To(BeTrue())
+		Expect(rule.Matches("next day", time.Time{}, now)).To(BeFalse())
+	})
+
+	It("should match promised dates within the configured days", func() {
+		rule := PriorityRule{MaxDaysToPromisedDate: days(1), Priority: 60}
+
+		Expect(rule.Matches("", now, now)).To(BeTrue())
+		Expect(rule.Matches("", now.AddDate(0, 0, 1), now)).To(BeTrue())
+		Expect(rule.Matches("", now.AddDate(0, 0, 2), now)).To(BeFalse())
+		Expect(rule.Matches("", time.Time{}, now)).To(BeFalse())
+	})
+
+	It("should resolve the highest matching priority", func() {
+		rules := PriorityRules{
+			{ServiceCategory: "express", Priority: 100},
+			{MaxDaysToPromisedDate: days(0), Priority: 70},
+			{ServiceCategory: "regular", Priority: 10},
+		}
+
+		priority, ok := rules.Resolve("regular", now, now)
+		Expect(ok).To(BeTrue())
+		Expect(priority).To(Equal(70))
+
+		priority, ok = rules.Resolve("EXPRESS", now.AddDate(0, 0, 3), now)
+		Expect(ok).To(BeTrue())
+		Expect(priority).To(Equal(100))
+
+		_, ok = rules.Resolve("next day", now.AddDate(0, 0, 3), now)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should validate the priority range", func() {
+		Expect(PriorityRule{ServiceCategory: "express", Priority: 101}.Validate()).To(HaveOccurred())
+		Expect(PriorityRule{Priority: 50}.Validate()).To(HaveOccurred())
+		Expect(PriorityRule{ServiceCategory: "express", Priority: 100}.Validate()).To(Succeed())
+	})
+
+	It("should share the document id for equivalent categories", func() {
+		ctx := buildCtx("tenant-1", "CL")
+		a := PriorityRule{ServiceCategory: "Same Day", Priority: 80}
+		b := PriorityRule{ServiceCategory: "same-day", Priority: 90}
+
+		Expect(a.DocID(ctx)).To(Equal(b.DocID(ctx)))
+	})
+})
@@ -0,0 +1,9 @@
+package domain
+
+type RoutesFilter struct {
+	Pagination       Pagination
+	RequestedFields  map[string]any
+	ReferenceIds     []string
+	PlanReferenceIds []string
+	VehiclePlates    []string
+}
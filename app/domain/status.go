@@ -13,6 +13,8 @@ const (
 	StatusInTransit = "in_transit"
 	StatusCancelled = "cancelled"
 	StatusFinished  = "finished"
+
+	StatusPartiallyDelivered = "partially_delivered"
 )
 
 type Status struct {
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"strings"
+	"transport-app/app/shared/apperrors"
+
+	"github.com/cockroachdb/errors"
+)
+
+// statusTransitions indica hacia qué estados puede avanzar una unidad de entrega desde
+// cada estado. Finalizada y cancelada son terminales. Una unidad ya en operación puede
+// confirmarse como entregada aunque no se haya informado el inicio de su ruta. Repetir el
+// estado actual siempre se permite para que los eventos reprocesados sean idempotentes.
+var statusTransitions = map[string][]string{
+	// Sin historial previo la unidad puede entrar en cualquier estado
+	"": {
+		StatusAvailable, StatusPending, StatusScanned, StatusPicked,
+		StatusPlanned, StatusInTransit, StatusCancelled, StatusFinished,
+		StatusPartiallyDelivered,
+	},
+	StatusAvailable: {StatusScanned, StatusPicked, StatusPlanned, StatusInTransit, StatusCancelled},
+	StatusPending:   {StatusAvailable, StatusScanned, StatusPicked, StatusPlanned, StatusInTransit, StatusCancelled, StatusFinished, StatusPartiallyDelivered},
+	StatusScanned:   {StatusPending, StatusPicked, StatusPlanned, StatusInTransit, StatusCancelled, StatusFinished, StatusPartiallyDelivered},
+	StatusPicked:    {StatusPending, StatusScanned, StatusPlanned, StatusInTransit, StatusCancelled, StatusFinished, StatusPartiallyDelivered},
+	StatusPlanned:   {StatusAvailable, StatusPending, StatusScanned, StatusPicked, StatusInTransit, StatusCancelled, StatusFinished, StatusPartiallyDelivered},
+	StatusInTransit: {StatusPending, StatusCancelled, StatusFinished, StatusPartiallyDelivered},
+	StatusCancelled: {},
+	StatusFinished:  {},
+	// Lo rechazado vuelve como devolución; solo cabe completar la entrega
+	StatusPartiallyDelivered: {StatusFinished},
+}
+
+// CanTransitionTo indica si la tabla de transiciones permite pasar al estado next
+func (s Status) CanTransitionTo(next string) bool {
+	if s.Status == next {
+		return true
+	}
+	for _, allowed := range statusTransitions[s.Status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo cambia la unidad desde su estado actual al estado next. Las transiciones
+// fuera de la tabla solo se aceptan con un cambio manual explícito que indique el motivo.
+func (p *DeliveryUnit) TransitionTo(current, next string) error {
+	from := Status{Status: current}
+	if !from.CanTransitionTo(next) {
+		manualChange := p.ConfirmDelivery.ManualChange
+		if !manualChange.Override {
+			return apperrors.MarkAsAlertable(errors.Wrapf(
+				ErrInvalidStatusTransition,
+				"delivery unit %s: %s -> %s", p.reference(), current, next))
+		}
+		if manualChange.Reason == "" {
+			return apperrors.MarkAsAlertable(errors.Wrapf(
+				ErrManualChangeReasonRequired,
+				"delivery unit %s: %s -> %s", p.reference(), current, next))
+		}
+	}
+	p.Status = Status{Status: next}
+	return nil
+}
+
+// reference identifica la unidad en los mensajes de error
+func (p DeliveryUnit) reference() string {
+	if p.Lpn != "" {
+		return p.Lpn
+	}
+	skus := make([]string, 0, len(p.Items))
+	for _, item := range p.Items {
+		skus = append(skus, item.Sku)
+	}
+	return p.noLPNReference + "/" + strings.Join(skus, ",")
+}
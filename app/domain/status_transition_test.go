@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"transport-app/app/shared/apperrors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Status transitions", func() {
+	DescribeTable("CanTransitionTo",
+		func(from, to string, allowed bool) {
+			Expect(Status{Status: from}.CanTransitionTo(to)).To(Equal(allowed))
+		},
+		Entry("new unit can become available", "", StatusAvailable, true),
+		Entry("available can be planned", StatusAvailable, StatusPlanned, true),
+		Entry("planned can start its route", StatusPlanned, StatusInTransit, true),
+		Entry("in transit can be delivered", StatusInTransit, StatusFinished, true),
+		Entry("in transit can fail back to pending", StatusInTransit, StatusPending, true),
+		Entry("pending can be planned again", StatusPending, StatusPlanned, true),
+		Entry("repeating the current status is idempotent", StatusFinished, StatusFinished, true),
+		Entry("finished cannot be cancelled", StatusFinished, StatusCancelled, false),
+		Entry("cancelled cannot go in transit", StatusCancelled, StatusInTransit, false),
+		Entry("available cannot be finished without a route", StatusAvailable, StatusFinished, false),
+		Entry("planned can be delivered without a started route", StatusPlanned, StatusFinished, true),
+		Entry("in transit cannot be planned again", StatusInTransit, StatusPlanned, false),
+	)
+
+	Describe("DeliveryUnit.TransitionTo", func() {
+		It("should set the next status when the transition is allowed", func() {
+			du := DeliveryUnit{Lpn: "LPN-1"}
+			Expect(du.TransitionTo(StatusInTransit, StatusFinished)).To(Succeed())
+			Expect(du.Status.Status).To(Equal(StatusFinished))
+		})
+
+		It("should reject a transition out of a terminal status with an alertable error", func() {
+			du := DeliveryUnit{Lpn: "LPN-1"}
+			err := du.TransitionTo(StatusFinished, StatusCancelled)
+			Expect(err).To(MatchError(ErrInvalidStatusTransition))
+			Expect(apperrors.IsAlertable(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("LPN-1"))
+			Expect(du.Status.Status).To(BeEmpty())
+		})
+
+		It("should require a reason to override the transition table", func() {
+			du := DeliveryUnit{
+				Lpn: "LPN-1",
+				ConfirmDelivery: ConfirmDelivery{
+					ManualChange: ManualChange{PerformedBy: "admin@example.com", Override: true},
+				},
+			}
+			err := du.TransitionTo(StatusCancelled, StatusInTransit)
+			Expect(err).To(MatchError(ErrManualChangeReasonRequired))
+			Expect(apperrors.IsAlertable(err)).To(BeTrue())
+		})
+
+		It("should accept an override with a reason", func() {
+			du := DeliveryUnit{
+				Lpn: "LPN-1",
+				ConfirmDelivery: ConfirmDelivery{
+					ManualChange: ManualChange{
+						PerformedBy: "admin@example.com",
+						Reason:      "Cancelación registrada por error",
+						Override:    true,
+					},
+				},
+			}
+			Expect(du.TransitionTo(StatusCancelled, StatusInTransit)).To(Succeed())
+			Expect(du.Status.Status).To(Equal(StatusInTransit))
+		})
+	})
+
+	Describe("DeliveryUnit.DeliveryOutcome", func() {
+		It("should be finished without a non delivery reason", func() {
+			Expect(DeliveryUnit{}.DeliveryOutcome()).To(Equal(StatusFinished))
+		})
+
+		It("should be pending when a non delivery reason is informed", func() {
+			du := DeliveryUnit{ConfirmDelivery: ConfirmDelivery{
+				NonDeliveryReason: NonDeliveryReason{Reason: "CLIENTE_AUSENTE"},
+			}}
+			Expect(du.DeliveryOutcome()).To(Equal(StatusPending))
+		})
+
+		It("should be partially delivered when items were delivered and rejected", func() {
+			du := DeliveryUnit{ConfirmDelivery: ConfirmDelivery{Items: ItemConfirmations{
+				{Sku: "SKU-1", DeliveredQuantity: 2},
+				{Sku: "SKU-2", RejectedQuantity: 1, ReasonCode: "PRODUCTO_DANADO"},
+			}}}
+			Expect(du.DeliveryOutcome()).To(Equal(StatusPartiallyDelivered))
+		})
+
+		It("should be pending when every item was rejected", func() {
+			du := DeliveryUnit{ConfirmDelivery: ConfirmDelivery{Items: ItemConfirmations{
+				{Sku: "SKU-1", RejectedQuantity: 3, ReasonCode: "CLIENTE_RECHAZA"},
+			}}}
+			Expect(du.DeliveryOutcome()).To(Equal(StatusPending))
+		})
+
+		It("should be finished when every item was delivered", func() {
+			du := DeliveryUnit{ConfirmDelivery: ConfirmDelivery{Items: ItemConfirmations{
+				{Sku: "SKU-1", DeliveredQuantity: 3},
+			}}}
+			Expect(du.DeliveryOutcome()).To(Equal(StatusFinished))
+		})
+	})
+
+	Describe("partially delivered transitions", func() {
+		It("should only allow finishing a partially delivered unit", func() {
+			partial := Status{Status: StatusPartiallyDelivered}
+			Expect(partial.CanTransitionTo(StatusFinished)).To(BeTrue())
+			Expect(partial.CanTransitionTo(StatusInTransit)).To(BeFalse())
+			Expect(Status{Status: StatusInTransit}.CanTransitionTo(StatusPartiallyDelivered)).To(BeTrue())
+			Expect(Status{Status: StatusAvailable}.CanTransitionTo(StatusPartiallyDelivered)).To(BeFalse())
+		})
+	})
+})
@@ -9,4 +9,8 @@ type Tenant struct {
 	ID      uuid.UUID
 	Name    string
 	Country countries.CountryCode
+	// Radio del geocerco de entrega; cero usa el valor por defecto de la plataforma
+	DeliveryGeofenceRadiusMeters float64
+	// Política de PIN de entrega para los tipos de orden que no definen la suya
+	DeliveryPinPolicy string
 }
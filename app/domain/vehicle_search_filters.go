@@ -3,4 +3,6 @@ package domain
 type VehicleSearchFilters struct {
 	Pagination         Pagination
 	CarrierReferenceID string
+	RequestedFields    map[string]any
+	Plates             []string
 }
@@ -10,6 +10,10 @@ type retryableError struct {
 	error
 }
 
+func (e *alertableError) Unwrap() error { return e.error }
+
+func (e *retryableError) Unwrap() error { return e.error }
+
 func MarkAsAlertable(err error) error {
 	return &alertableError{error: err}
 }
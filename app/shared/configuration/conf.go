@@ -9,47 +9,67 @@ func init() {
 }
 
 type Conf struct {
-	VERSION                                   string `env:"version,required"`
-	PORT                                      string `env:"PORT" envDefault:"8080"`
-	ENVIRONMENT                               string `env:"ENVIRONMENT" envDefault:"development"`
-	CACHE_STRATEGY                            string `env:"CACHE_STRATEGY" envDefault:"redis"`
-	PROJECT_NAME                              string `env:"PROJECT_NAME" envDefault:"transport-app"`
-	GOOGLE_PROJECT_ID                         string `env:"GOOGLE_PROJECT_ID"`
-	GOOGLE_PROJECT_LOCATION                   string `env:"GOOGLE_PROJECT_LOCATION"`
-	TRANSPORT_APP_TOPIC                       string `env:"TRANSPORT_APP_TOPIC" envDefault:"transport-app-events"`
-	OPTIMIZATION_STRATEGY                     string `env:"OPTIMIZATION_STRATEGY" envDefault:"locationiq"`
-	GEOCODING_STRATEGY                        string `env:"GEOCODING_STRATEGY" envDefault:"locationiq"`
-	GOOGLE_MAPS_API_KEY                       string `env:"GOOGLE_MAPS_API_KEY"`
-	LOCATION_IQ_ACCESS_TOKEN                  string `env:"LOCATION_IQ_ACCESS_TOKEN"`
-	LOCATION_IQ_DNS                           string `env:"LOCATION_IQ_DNS"`
-	CACHE_URL                                 string `env:"CACHE_URL"`
-	FIREBASE_API_KEY                          string `env:"FIREBASE_API_KEY"`
-	FLEET_OPTIMIZED_WEBHOOK_SUBSCRIPTION      string `env:"FLEET_OPTIMIZED_WEBHOOK_SUBSCRIPTION"`
-	ORDER_SUBMITTED_SUBSCRIPTION              string `env:"ORDER_SUBMITTED_SUBSCRIPTION"`
-	NODE_SUBMITTED_SUBSCRIPTION               string `env:"NODE_SUBMITTED_SUBSCRIPTION"`
-	TENANT_SUBMITTED_SUBSCRIPTION             string `env:"TENANT_SUBMITTED_SUBSCRIPTION"`
-	REGISTRATION_SUBMITTED_SUBSCRIPTION       string `env:"REGISTRATION_SUBMITTED_SUBSCRIPTION"`
-	DELIVERIES_SUBMITTED_SUBSCRIPTION         string `env:"DELIVERIES_SUBMITTED_SUBSCRIPTION"`
-	ORDER_CANCELLATION_SUBMITTED_SUBSCRIPTION string `env:"ORDER_CANCELLATION_SUBMITTED_SUBSCRIPTION"`
-	ROUTE_STARTED_SUBMITTED_SUBSCRIPTION      string `env:"ROUTE_STARTED_SUBMITTED_SUBSCRIPTION"`
-	WEBHOOK_SUBMITTED_SUBSCRIPTION            string `env:"WEBHOOK_SUBMITTED_SUBSCRIPTION"`
-	OPTIMIZATION_REQUESTED_SUBSCRIPTION       string `env:"OPTIMIZATION_REQUESTED_SUBSCRIPTION"`
-	AGENT_OPTIMIZATION_REQUESTED_SUBSCRIPTION string `env:"AGENT_OPTIMIZATION_REQUESTED_SUBSCRIPTION"`
-	VROOM_OPTIMIZER_URL                       string `env:"VROOM_OPTIMIZER_URL"`
-	VROOM_PLANNER_URL                         string `env:"VROOM_PLANNER_URL"`
-	MASTER_NODE_URL                           string `env:"MASTER_NODE_URL"`
-	MASTER_NODE_WEBHOOKS_URL                  string `env:"MASTER_NODE_WEBHOOKS_URL"`
-	MASTER_NODE_API_KEY                       string `env:"MASTER_NODE_API_KEY"`
-	JWT_ISSUER                                string `env:"JWT_ISSUER" envDefault:"transport-app"`
-	JWT_PRIVATE_KEY                           string `env:"JWT_PRIVATE_KEY"`
-	JWT_PUBLIC_KEY                            string `env:"JWT_PUBLIC_KEY"`
-	CLIENT_CREDENTIALS_ENCRYPTION_KEY         string `env:"CLIENT_CREDENTIALS_ENCRYPTION_KEY"`
-	RESEND_API_KEY                            string `env:"RESEND_API_KEY"`
-	GOOGLE_OAUTH_CLIENT_ID                    string `env:"GOOGLE_OAUTH_CLIENT_ID"`
-	GOOGLE_OAUTH_CLIENT_SECRET                string `env:"GOOGLE_OAUTH_CLIENT_SECRET"`
-	GIT_REPOSITORY_PATH                       string `env:"GIT_REPOSITORY_PATH" envDefault:"./agent-repo"`
-	GIT_TOKEN                                 string `env:"GIT_TOKEN"`
-	GIT_SSH_KEY_PATH                          string `env:"GIT_SSH_KEY_PATH" envDefault:"/home/gitpod/.ssh/id_rsa"`
+	VERSION                                      string `env:"version,required"`
+	PORT                                         string `env:"PORT" envDefault:"8080"`
+	ENVIRONMENT                                  string `env:"ENVIRONMENT" envDefault:"development"`
+	CACHE_STRATEGY                               string `env:"CACHE_STRATEGY" envDefault:"redis"`
+	PROJECT_NAME                                 string `env:"PROJECT_NAME" envDefault:"transport-app"`
+	GOOGLE_PROJECT_ID                            string `env:"GOOGLE_PROJECT_ID"`
+	GOOGLE_PROJECT_LOCATION                      string `env:"GOOGLE_PROJECT_LOCATION"`
+	TRANSPORT_APP_TOPIC                          string `env:"TRANSPORT_APP_TOPIC" envDefault:"transport-app-events"`
+	OPTIMIZATION_STRATEGY                        string `env:"OPTIMIZATION_STRATEGY" envDefault:"locationiq"`
+	GEOCODING_STRATEGY                           string `env:"GEOCODING_STRATEGY" envDefault:"locationiq"`
+	GOOGLE_MAPS_API_KEY                          string `env:"GOOGLE_MAPS_API_KEY"`
+	LOCATION_IQ_ACCESS_TOKEN                     string `env:"LOCATION_IQ_ACCESS_TOKEN"`
+	LOCATION_IQ_DNS                              string `env:"LOCATION_IQ_DNS"`
+	CACHE_URL                                    string `env:"CACHE_URL"`
+	FIREBASE_API_KEY                             string `env:"FIREBASE_API_KEY"`
+	FLEET_OPTIMIZED_WEBHOOK_SUBSCRIPTION         string `env:"FLEET_OPTIMIZED_WEBHOOK_SUBSCRIPTION"`
+	ORDER_SUBMITTED_SUBSCRIPTION                 string `env:"ORDER_SUBMITTED_SUBSCRIPTION"`
+	NODE_SUBMITTED_SUBSCRIPTION                  string `env:"NODE_SUBMITTED_SUBSCRIPTION"`
+	TENANT_SUBMITTED_SUBSCRIPTION                string `env:"TENANT_SUBMITTED_SUBSCRIPTION"`
+	REGISTRATION_SUBMITTED_SUBSCRIPTION          string `env:"REGISTRATION_SUBMITTED_SUBSCRIPTION"`
+	DELIVERIES_SUBMITTED_SUBSCRIPTION            string `env:"DELIVERIES_SUBMITTED_SUBSCRIPTION"`
+	ORDER_CANCELLATION_SUBMITTED_SUBSCRIPTION    string `env:"ORDER_CANCELLATION_SUBMITTED_SUBSCRIPTION"`
+	ROUTE_STARTED_SUBMITTED_SUBSCRIPTION         string `env:"ROUTE_STARTED_SUBMITTED_SUBSCRIPTION"`
+	SELLER_PICKUP_CONFIRMED_SUBSCRIPTION         string `env:"SELLER_PICKUP_CONFIRMED_SUBSCRIPTION"`
+	DRIVER_LOCATIONS_SUBMITTED_SUBSCRIPTION      string `env:"DRIVER_LOCATIONS_SUBMITTED_SUBSCRIPTION"`
+	WEBHOOK_SUBMITTED_SUBSCRIPTION               string `env:"WEBHOOK_SUBMITTED_SUBSCRIPTION"`
+	OPTIMIZATION_REQUESTED_SUBSCRIPTION          string `env:"OPTIMIZATION_REQUESTED_SUBSCRIPTION"`
+	AGENT_OPTIMIZATION_REQUESTED_SUBSCRIPTION    string `env:"AGENT_OPTIMIZATION_REQUESTED_SUBSCRIPTION"`
+	DELIVERY_UNITS_EXPORT_REQUESTED_SUBSCRIPTION string `env:"DELIVERY_UNITS_EXPORT_REQUESTED_SUBSCRIPTION"`
+	VROOM_OPTIMIZER_URL                          string `env:"VROOM_OPTIMIZER_URL"`
+	VROOM_PLANNER_URL                            string `env:"VROOM_PLANNER_URL"`
+	MATRIX_PROVIDER                              string `env:"MATRIX_PROVIDER"`
+	MATRIX_SPEED_PROFILE                         string `env:"MATRIX_SPEED_PROFILE" envDefault:"car"`
+	MATRIX_SPEED_KMH                             string `env:"MATRIX_SPEED_KMH"`
+	MATRIX_FILE_PATH                             string `env:"MATRIX_FILE_PATH"`
+	OSRM_URL                                     string `env:"OSRM_URL"`
+	OSRM_PROFILE                                 string `env:"OSRM_PROFILE" envDefault:"driving"`
+	MASTER_NODE_URL                              string `env:"MASTER_NODE_URL"`
+	MASTER_NODE_WEBHOOKS_URL                     string `env:"MASTER_NODE_WEBHOOKS_URL"`
+	MASTER_NODE_API_KEY                          string `env:"MASTER_NODE_API_KEY"`
+	JWT_ISSUER                                   string `env:"JWT_ISSUER" envDefault:"transport-app"`
+	JWT_PRIVATE_KEY                              string `env:"JWT_PRIVATE_KEY"`
+	JWT_PUBLIC_KEY                               string `env:"JWT_PUBLIC_KEY"`
+	CLIENT_CREDENTIALS_ENCRYPTION_KEY            string `env:"CLIENT_CREDENTIALS_ENCRYPTION_KEY"`
+	RESEND_API_KEY                               string `env:"RESEND_API_KEY"`
+	GOOGLE_OAUTH_CLIENT_ID                       string `env:"GOOGLE_OAUTH_CLIENT_ID"`
+	GOOGLE_OAUTH_CLIENT_SECRET                   string `env:"GOOGLE_OAUTH_CLIENT_SECRET"`
+	GIT_REPOSITORY_PATH                          string `env:"GIT_REPOSITORY_PATH" envDefault:"./agent-repo"`
+	GIT_TOKEN                                    string `env:"GIT_TOKEN"`
+	GIT_SSH_KEY_PATH                             string `env:"GIT_SSH_KEY_PATH" envDefault:"/home/gitpod/.ssh/id_rsa"`
+
+	// Clave HMAC con la que se persisten los PINs de entrega
+	DELIVERY_PIN_HMAC_KEY string `env:"DELIVERY_PIN_HMAC_KEY"`
+
+	// Radio por defecto del geocerco de entrega para tenants que no lo han configurado
+	DELIVERY_GEOFENCE_RADIUS_METERS float64 `env:"DELIVERY_GEOFENCE_RADIUS_METERS" envDefault:"300"`
+
+	// Ventana del recorrido submuestreado de una ruta y antigüedad máxima de la última posición
+	// conocida para considerar la ruta activa
+	DRIVER_BREADCRUMB_INTERVAL_SECONDS int `env:"DRIVER_BREADCRUMB_INTERVAL_SECONDS" envDefault:"30"`
+	DRIVER_POSITION_ACTIVE_MINUTES     int `env:"DRIVER_POSITION_ACTIVE_MINUTES" envDefault:"30"`
 }
 
 func NewConf() (Conf, error) {
@@ -3,6 +3,7 @@ package httpserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"time"
 	"transport-app/app/adapter/in/graphql/graph"
 	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/jwt"
 	"transport-app/app/shared/infrastructure/resendcli"
 	"transport-app/app/shared/sharedcontext"
 
@@ -35,7 +37,7 @@ import (
 
 func init() {
 	ioc.Registry(New, configuration.NewConf, resendcli.NewClient)
-	ioc.RegistryAtEnd(startAtEnd, New, graph.NewResolver)
+	ioc.RegistryAtEnd(startAtEnd, New, graph.NewResolver, jwt.NewJWTServiceFromConfig)
 }
 
 type Server struct {
@@ -91,7 +93,7 @@ func New(
 	return server
 }
 
-func startAtEnd(e Server, resolver *graph.Resolver) error {
+func startAtEnd(e Server, resolver *graph.Resolver, jwtService *jwt.JWTService) error {
 	fmt.Println(`
 ████████╗██████╗  █████╗ ███╗   ██╗███████╗██████╗  ██████╗ ██████╗ ████████╗     █████╗ ██████╗ ██████╗ 
 ╚══██╔══╝██╔══██╗██╔══██╗████╗  ██║██╔════╝██╔══██╗██╔═══██╗██╔══██╗╚══██╔══╝    ██╔══██╗██╔══██╗██╔══██╗
@@ -102,6 +104,8 @@ func startAtEnd(e Server, resolver *graph.Resolver) error {
    `)
 	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
 	srv.AddTransport(transport.Options{})
+	// El transporte WebSocket va antes que GET porque el upgrade también es un GET
+	srv.AddTransport(newWebsocketTransport(jwtService))
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
@@ -110,6 +114,9 @@ func startAtEnd(e Server, resolver *graph.Resolver) error {
 		Cache: lru.New[string](100),
 	})
 	fuego.PostStd(e.Manager, "/query", srv.ServeHTTP, option.Tags("graphql"))
+	fuego.GetStd(e.Manager, "/query", srv.ServeHTTP,
+		option.Summary("GraphQL subscriptions over WebSocket"),
+		option.Tags("graphql"))
 	fuego.GetStd(e.Manager,
 		"/",
 		playground.Handler("GraphQL playground", "/query"),
@@ -207,6 +214,12 @@ func injectBaggageMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// Las suscripciones toman el tenant del access token al iniciar la conexión
+		if r.URL.Path == "/query" && isWebsocketUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		if r.Method == http.MethodPost && r.URL.Path == "/query" {
 			bodyBytes, err := io.ReadAll(r.Body)
 			if err == nil {
@@ -230,27 +243,11 @@ func injectBaggageMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Cortamos por el último guion para separar UUID del país
-		lastDash := strings.LastIndex(orgHeader, "-")
-		if lastDash == -1 || lastDash == len(orgHeader)-1 {
-			http.Error(w, "invalid tenant format, expected uuid-country (e.g., a946ac90-...-CL)", http.StatusBadRequest)
-			return
-		}
-
-		tenantID := orgHeader[:lastDash]
-		countryCode := strings.ToUpper(orgHeader[lastDash+1:])
-
-		if _, err := uuid.Parse(tenantID); err != nil {
-			http.Error(w, "invalid tenant UUID", http.StatusBadRequest)
-			return
-		}
-
-		c := countries.ByName(countryCode)
-		if c == countries.Unknown {
-			http.Error(w, "invalid country code", http.StatusBadRequest)
+		tenantID, country, err := parseTenant(orgHeader)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		country := c.Alpha2()
 
 		members := make([]baggage.Member, 0, 4)
 
@@ -278,3 +275,25 @@ func injectBaggageMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// parseTenant separa el tenant con formato uuid-country en su UUID y su código de país
+func parseTenant(tenant string) (string, string, error) {
+	// Cortamos por el último guion para separar UUID del país
+	lastDash := strings.LastIndex(tenant, "-")
+	if lastDash == -1 || lastDash == len(tenant)-1 {
+		return "", "", errors.New("invalid tenant format, expected uuid-country (e.g., a946ac90-...-CL)")
+	}
+
+	tenantID := tenant[:lastDash]
+	countryCode := strings.ToUpper(tenant[lastDash+1:])
+
+	if _, err := uuid.Parse(tenantID); err != nil {
+		return "", "", errors.New("invalid tenant UUID")
+	}
+
+	c := countries.ByName(countryCode)
+	if c == countries.Unknown {
+		return "", "", errors.New("invalid country code")
+	}
+	return tenantID, c.Alpha2(), nil
+}
@@ -0,0 +1,67 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"transport-app/app/shared/infrastructure/jwt"
+	"transport-app/app/shared/sharedcontext"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// newWebsocketTransport sirve las suscripciones GraphQL. Los navegadores no pueden enviar
+// headers al abrir un WebSocket, por lo que el tenant se toma del access token informado en
+// el mensaje connection_init y no del header tenant
+func newWebsocketTransport(jwtService *jwt.JWTService) transport.Websocket {
+	return transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+		},
+		InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+			ctx, err := subscriptionContext(ctx, jwtService, initPayload)
+			return ctx, nil, err
+		},
+	}
+}
+
+func subscriptionContext(ctx context.Context, jwtService *jwt.JWTService, initPayload transport.InitPayload) (context.Context, error) {
+	if jwtService == nil {
+		return ctx, errors.New("access token validation is not configured")
+	}
+
+	accessToken := initPayload.GetString("X-Access-Token")
+	if accessToken == "" {
+		accessToken = strings.TrimPrefix(initPayload.Authorization(), "Bearer ")
+	}
+	if accessToken == "" {
+		return ctx, errors.New("missing access token in connection_init payload")
+	}
+
+	claims, err := jwtService.ValidateToken(accessToken)
+	if err != nil {
+		return ctx, err
+	}
+
+	tenantID, country, err := parseTenant(claims.Tenant)
+	if err != nil {
+		return ctx, err
+	}
+
+	mTenant, _ := baggage.NewMember(sharedcontext.BaggageTenantID, tenantID)
+	mCountry, _ := baggage.NewMember(sharedcontext.BaggageTenantCountry, country)
+	bag, _ := baggage.New(mTenant, mCountry)
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+	return sharedcontext.WithAccessToken(ctx, accessToken), nil
+}
+
+func isWebsocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 )
 
+// AdminScope habilita operaciones administrativas, como forzar transiciones de estado
+const AdminScope = "admin"
+
 // JWTMiddleware crea un middleware para validar tokens JWT
 func JWTMiddleware(jwtService *JWTService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -59,15 +62,7 @@ func RequireScope(requiredScope string) func(http.Handler) http.Handler {
 			}
 
 			// Verificar si el scope requerido está presente
-			hasScope := false
-			for _, scope := range scopes {
-				if scope == requiredScope {
-					hasScope = true
-					break
-				}
-			}
-
-			if !hasScope {
+			if !containsScope(scopes, requiredScope) {
 				http.Error(w, "Scope requerido no encontrado: "+requiredScope, http.StatusForbidden)
 				return
 			}
@@ -94,3 +89,29 @@ func GetScopesFromContext(ctx context.Context) ([]string, bool) {
 	scopes, ok := ctx.Value("jwt_scopes").([]string)
 	return scopes, ok
 }
+
+// HasScope indica si la request trae un token válido con el scope requerido. Usa los scopes
+// dejados por JWTMiddleware y, si la ruta no lo aplica, valida el header Authorization
+func HasScope(r *http.Request, jwtService *JWTService, requiredScope string) bool {
+	if scopes, ok := GetScopesFromContext(r.Context()); ok {
+		return containsScope(scopes, requiredScope)
+	}
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || jwtService == nil {
+		return false
+	}
+	claims, err := jwtService.ValidateToken(tokenString)
+	if err != nil {
+		return false
+	}
+	return containsScope(claims.Scopes, requiredScope)
+}
+
+func containsScope(scopes []string, requiredScope string) bool {
+	for _, scope := range scopes {
+		if scope == requiredScope {
+			return true
+		}
+	}
+	return false
+}
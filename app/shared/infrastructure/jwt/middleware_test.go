@@ -0,0 +1,46 @@
+package jwt
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHasScope(t *testing.T) {
+	jwtService := NewJWTService("test-secret-key", "test-issuer")
+	token := func(scopes ...string) string {
+		tokenString, err := jwtService.GenerateToken("user123", scopes, nil, "test-tenant", "https://api.test.com", 60)
+		if err != nil {
+			t.Fatalf("Error generando token: %v", err)
+		}
+		return tokenString
+	}
+
+	cases := []struct {
+		name          string
+		authorization string
+		want          bool
+	}{
+		{"admin", "Bearer " + token("read:orders", AdminScope), true},
+		{"driver", "Bearer " + token("read:orders"), false},
+		{"sin token", "", false},
+		{"token inválido", "Bearer invalid-token", false},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("POST", "/orders/deliveries", nil)
+		if c.authorization != "" {
+			r.Header.Set("Authorization", c.authorization)
+		}
+		if got := HasScope(r, jwtService, AdminScope); got != c.want {
+			t.Errorf("%s: HasScope = %v, esperado %v", c.name, got, c.want)
+		}
+	}
+
+	// Los scopes dejados por JWTMiddleware tienen precedencia sobre el header
+	r := httptest.NewRequest("POST", "/orders/deliveries", nil)
+	r.Header.Set("Authorization", "Bearer "+token(AdminScope))
+	r = r.WithContext(context.WithValue(r.Context(), "jwt_scopes", []string{"read:orders"}))
+	if HasScope(r, jwtService, AdminScope) {
+		t.Error("Debería usar los scopes del contexto")
+	}
+}
@@ -2,6 +2,7 @@ package storj
 
 import (
 	"context"
+	"io"
 	"time"
 	"transport-app/app/shared/configuration"
 
@@ -13,6 +14,9 @@ type UplinkManager interface {
 	GeneratePreSignedURL(ctx context.Context, objectKey string, ttl time.Duration) (string, error)
 	GeneratePreSignedURLsBatch(ctx context.Context, objectKeys []string, ttl time.Duration) ([]string, error)
 	GeneratePublicDownloadURL(ctx context.Context, objectKey string, ttl time.Duration) (string, error)
+	Upload(ctx context.Context, objectKey string, contentType string, data []byte) error
+	// UploadStream sube el contenido a medida que se lee, en partes, sin cargarlo completo en memoria
+	UploadStream(ctx context.Context, objectKey string, contentType string, body io.Reader) error
 }
 
 // Struct simplificado - Sin uplink nativo
@@ -385,6 +385,19 @@ func (p Projection) DeliveryUnitItemsQuantity() Field {
 	return Field{path: "deliveryUnit.items.quantity"}
 }
 
+// Métodos para el detalle de entregas parciales por item
+func (p Projection) DeliveryUnitItemsDeliveredQuantity() Field {
+	return Field{path: "deliveryUnit.items.deliveredQuantity"}
+}
+
+func (p Projection) DeliveryUnitItemsRejectedQuantity() Field {
+	return Field{path: "deliveryUnit.items.rejectedQuantity"}
+}
+
+func (p Projection) DeliveryUnitItemsRejectionReason() Field {
+	return Field{path: "deliveryUnit.items.rejectionReason"}
+}
+
 // Métodos para campos de Dimensions en Item
 func (p Projection) DeliveryUnitItemsDimensions() Field {
 	return Field{path: "deliveryUnit.items.dimensions"}
@@ -566,6 +579,15 @@ func (p Projection) DeliveryEvidencePhotosURL() Field {
 	return Field{path: "delivery.evidencePhotos.url"}
 }
 
+// Métodos para la validación de geocerco de la confirmación
+func (p Projection) DeliveryDistanceToDestinationMeters() Field {
+	return Field{path: "delivery.distanceToDestinationMeters"}
+}
+
+func (p Projection) DeliverySuspiciousLocation() Field {
+	return Field{path: "delivery.suspiciousLocation"}
+}
+
 // Métodos para campos de Coordinates en Destination
 func (p Projection) DestinationCoordinates() Field {
 	return Field{path: "destination.addressInfo.coordinates"}
@@ -0,0 +1,95 @@
+package drivers
+
+import (
+	"reflect"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// Field representa un campo específico con métodos para verificación
+type Field struct {
+	path string
+}
+
+// Has verifica si este campo está en el mapa de campos solicitados
+func (f Field) Has(requestedFields map[string]any) bool {
+	_, exists := requestedFields[f.path]
+	return exists
+}
+
+func (f Field) HasAnyPrefix(requestedFields map[string]any) bool {
+	for field := range requestedFields {
+		if field == f.path || hasPrefix(field, f.path) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefix(field, prefix string) bool {
+	// ejemplo: prefix = "origin", field = "origin.addressInfo.contact.email"
+	return len(field) > len(prefix) && field[:len(prefix)] == prefix && field[len(prefix)] == '.'
+}
+
+func init() {
+	ioc.Registry(NewProjection)
+}
+
+func NewProjection() Projection {
+	return Projection{}
+}
+
+// Projection representa un conjunto de campos solicitados por el cliente en la query GraphQL.
+type Projection struct{}
+
+// Métodos para campos de Driver
+func (p Projection) Name() Field {
+	return Field{path: "name"}
+}
+
+func (p Projection) NationalID() Field {
+	return Field{path: "nationalID"}
+}
+
+func (p Projection) Email() Field {
+	return Field{path: "email"}
+}
+
+// GetAllProjections devuelve un mapa con todas las proyecciones disponibles
+func getAllProjections() map[string]Field {
+	var p Projection
+	projections := make(map[string]Field)
+
+	pType := reflect.TypeOf(p)
+	pValue := reflect.ValueOf(p)
+
+	// Solo se consideran métodos sin parámetros que devuelven Field
+	for i := 0; i < pType.NumMethod(); i++ {
+		method := pType.Method(i)
+		if method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+			if method.Type.Out(0).Name() == "Field" {
+				result := pValue.Method(i).Call(nil)[0].Interface().(Field)
+				projections[method.Name] = result
+			}
+		}
+	}
+
+	return projections
+}
+
+// GetAllProjectionPaths devuelve un slice con todas las rutas de campos disponibles
+func GetAllProjectionPaths() []string {
+	projections := getAllProjections()
+	paths := make([]string, 0, len(projections))
+
+	for _, field := range projections {
+		paths = append(paths, field.String())
+	}
+
+	return paths
+}
+
+// String devuelve la ruta del campo
+func (f Field) String() string {
+	return f.path
+}
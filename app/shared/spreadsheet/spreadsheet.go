@@ -0,0 +1,326 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format: expected .csv or .xlsx")
+
+var ErrEmptySheet = errors.New("the file has no header row")
+
+var ErrXLSXTooLarge = errors.New("invalid xlsx: uncompressed content exceeds the size limit")
+
+// maxXLSXUncompressedSize limita lo que se descomprime de un XLSX, sumando todas sus partes,
+// para que un archivo pequeño no pueda expandirse sin control en memoria
+const maxXLSXUncompressedSize = 64 << 20
+
+// Sheet es una tabla leída desde un archivo: la primera fila se usa como cabecera.
+// RowNumbers indica, para cada fila, su número en el archivo de origen (base uno), ya que
+// las líneas en blanco de un CSV o las filas omitidas de un XLSX no llegan a Rows
+type Sheet struct {
+	Headers    []string
+	Rows       [][]string
+	RowNumbers []int
+}
+
+// Read lee un archivo CSV o XLSX según su extensión. En XLSX sólo se considera la primera hoja.
+func Read(filename string, data []byte) (Sheet, error) {
+	var (
+		records    [][]string
+		rowNumbers []int
+		err        error
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".txt":
+		records, rowNumbers, err = readCSV(data)
+	case ".xlsx":
+		records, rowNumbers, err = readXLSX(data)
+	default:
+		return Sheet{}, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return Sheet{}, err
+	}
+	if len(records) == 0 {
+		return Sheet{}, ErrEmptySheet
+	}
+
+	headers := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		headers[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+	return Sheet{Headers: headers, Rows: records[1:], RowNumbers: rowNumbers[1:]}, nil
+}
+
+// WriteCSV serializa la cabecera y las filas en formato CSV
+func WriteCSV(sheet Sheet) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(sheet.Headers); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(sheet.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readCSV detecta el separador entre coma y punto y coma (Excel en español exporta con ';').
+// Retorna junto a cada registro la línea en que comienza
+func readCSV(data []byte) ([][]string, []int, error) {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	var (
+		records    [][]string
+		rowNumbers []int
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		rowNumbers = append(rowNumbers, line)
+	}
+	return records, rowNumbers, nil
+}
+
+type xlsxWorkbook struct {
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Style  int          `xml:"s,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX lee la primera hoja del libro sin dependencias externas. Las celdas numéricas con
+// formato de fecha u hora se entregan con los layouts de xlsxDateLayout y xlsxTimeLayout, y
+// junto a cada fila se retorna su número en la hoja
+func readXLSX(data []byte) ([][]string, []int, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid xlsx: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	budget := &xlsxReadBudget{remaining: maxXLSXUncompressedSize}
+
+	var workbook xlsxWorkbook
+	if err := budget.decode(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, nil, ErrEmptySheet
+	}
+
+	var rels xlsxRelationships
+	if err := budget.decode(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, nil, err
+	}
+	sheetPath := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RID {
+			sheetPath = rel.Target
+			break
+		}
+	}
+	if sheetPath == "" {
+		return nil, nil, fmt.Errorf("invalid xlsx: first sheet not found")
+	}
+	if strings.HasPrefix(sheetPath, "/") {
+		sheetPath = strings.TrimPrefix(sheetPath, "/")
+	} else {
+		sheetPath = path.Join("xl", sheetPath)
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := budget.decode(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var styles xlsxStyleSheet
+	if _, ok := files["xl/styles.xml"]; ok {
+		if err := budget.decode(files, "xl/styles.xml", &styles); err != nil {
+			return nil, nil, err
+		}
+	}
+	dateStyles := xlsxDateStyles(styles)
+
+	var sheet xlsxWorksheet
+	if err := budget.decode(files, sheetPath, &sheet); err != nil {
+		return nil, nil, err
+	}
+
+	records := make([][]string, 0, len(sheet.Rows))
+	rowNumbers := make([]int, 0, len(sheet.Rows))
+	previousRow := 0
+	for _, row := range sheet.Rows {
+		// El atributo r es opcional; sin él la fila sigue a la anterior
+		rowNumber := row.Number
+		if rowNumber <= previousRow {
+			rowNumber = previousRow + 1
+		}
+		previousRow = rowNumber
+
+		var record []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				col = columnIndex(cell.Ref)
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings.Items) {
+					return nil, nil, fmt.Errorf("invalid xlsx: bad shared string reference in cell %s", cell.Ref)
+				}
+				record[col] = sharedStrings.Items[idx].String()
+			case "inlineStr":
+				record[col] = cell.Inline.String()
+			case "", "n":
+				record[col] = cell.Value
+				if cell.Style >= 0 && cell.Style < len(dateStyles) && dateStyles[cell.Style] != xlsxNotDate {
+					record[col] = xlsxSerialToString(cell.Value, dateStyles[cell.Style], workbook.Properties.Date1904)
+				}
+			default:
+				record[col] = cell.Value
+			}
+		}
+		records = append(records, record)
+		rowNumbers = append(rowNumbers, rowNumber)
+	}
+	return records, rowNumbers, nil
+}
+
+// xlsxReadBudget lleva la cuenta de los bytes descomprimidos que aún pueden leerse del libro
+type xlsxReadBudget struct {
+	remaining int64
+}
+
+func (b *xlsxReadBudget) decode(files map[string]*zip.File, name string, target any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("invalid xlsx: %s not found", name)
+	}
+	// El tamaño declarado puede ser falso, por lo que además se limita la lectura real
+	if f.UncompressedSize64 > uint64(b.remaining) {
+		return ErrXLSXTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("invalid xlsx: %w", err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(&budgetReader{r: rc, budget: b}).Decode(target); err != nil {
+		if errors.Is(err, ErrXLSXTooLarge) {
+			return ErrXLSXTooLarge
+		}
+		return fmt.Errorf("invalid xlsx: %s: %w", name, err)
+	}
+	return nil
+}
+
+// budgetReader descuenta del presupuesto cada byte leído y falla al agotarlo
+type budgetReader struct {
+	r      io.Reader
+	budget *xlsxReadBudget
+}
+
+func (br *budgetReader) Read(p []byte) (int, error) {
+	if br.budget.remaining <= 0 {
+		// Agotado el presupuesto solo se acepta el fin de la parte
+		var probe [1]byte
+		n, err := br.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrXLSXTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > br.budget.remaining {
+		p = p[:br.budget.remaining]
+	}
+	n, err := br.r.Read(p)
+	br.budget.remaining -= int64(n)
+	return n, err
+}
+
+// columnIndex convierte la referencia de celda (ej. "AB12") al índice de columna base cero
+func columnIndex(ref string) int {
+	idx := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		idx = idx*26 + int(r-'A'+1)
+	}
+	return idx - 1
+}
@@ -0,0 +1,135 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead_CSVWithSemicolonSeparator(t *testing.T) {
+	data := []byte("\ufeffFolio;Dirección;Peso\nORD-1;\"Av. Providencia 1234, Dpto 5\";1500\nORD-2;Los Leones 45;\n")
+
+	sheet, err := Read("pedidos.CSV", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Folio", "Dirección", "Peso"}; !reflect.DeepEqual(sheet.Headers, want) {
+		t.Errorf("headers = %v, want %v", sheet.Headers, want)
+	}
+	if len(sheet.Rows) != 2 || sheet.Rows[0][1] != "Av. Providencia 1234, Dpto 5" {
+		t.Errorf("rows = %v", sheet.Rows)
+	}
+}
+
+func TestRead_XLSXFirstSheet(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Pedidos" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<si><t>Folio</t></si><si><t>Peso</t></si><si><r><t>ORD-</t></r><r><t>1</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2" t="inlineStr"><is><t>nota</t></is></c><c r="C2"><v>1500</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	sheet, err := Read("pedidos.xlsx", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Folio", "", "Peso"}; !reflect.DeepEqual(sheet.Headers, want) {
+		t.Errorf("headers = %v, want %v", sheet.Headers, want)
+	}
+	if want := [][]string{{"ORD-1", "nota", "1500"}}; !reflect.DeepEqual(sheet.Rows, want) {
+		t.Errorf("rows = %v, want %v", sheet.Rows, want)
+	}
+}
+
+func TestRead_CSVRowNumbersSkipBlankLines(t *testing.T) {
+	data := []byte("Folio,Peso\nORD-1,100\n\n\nORD-2,200\n")
+
+	sheet, err := Read("pedidos.csv", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 5}; !reflect.DeepEqual(sheet.RowNumbers, want) {
+		t.Errorf("row numbers = %v, want %v", sheet.RowNumbers, want)
+	}
+}
+
+func TestRead_XLSXDatesAndSkippedRows(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Pedidos" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/styles.xml": `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<numFmts count="1"><numFmt numFmtId="164" formatCode="dd/mm/yyyy;@"/></numFmts>
+			<cellXfs count="4"><xf numFmtId="0"/><xf numFmtId="164"/><xf numFmtId="20"/><xf numFmtId="22"/></cellXfs></styleSheet>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+			<row r="1"><c r="A1" t="inlineStr"><is><t>fecha</t></is></c><c r="B1" t="inlineStr"><is><t>hora</t></is></c><c r="C1" t="inlineStr"><is><t>ambos</t></is></c><c r="D1" t="inlineStr"><is><t>peso</t></is></c></row>
+			<row r="4"><c r="A4" s="1"><v>45567</v></c><c r="B4" s="2"><v>0.375</v></c><c r="C4" s="3"><v>45567.75</v></c><c r="D4" s="0"><v>45567</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	sheet, err := Read("pedidos.xlsx", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"2024-10-02", "09:00", "2024-10-02 18:00", "45567"}}; !reflect.DeepEqual(sheet.Rows, want) {
+		t.Errorf("rows = %v, want %v", sheet.Rows, want)
+	}
+	if want := []int{4}; !reflect.DeepEqual(sheet.RowNumbers, want) {
+		t.Errorf("row numbers = %v, want %v", sheet.RowNumbers, want)
+	}
+}
+
+func TestRead_XLSXRejectsOversizedContent(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook>` + strings.Repeat(" ", maxXLSXUncompressedSize) + `</workbook>`,
+	})
+
+	if _, err := Read("pedidos.xlsx", data); !errors.Is(err, ErrXLSXTooLarge) {
+		t.Errorf("err = %v, want ErrXLSXTooLarge", err)
+	}
+}
+
+func TestRead_UnsupportedFormat(t *testing.T) {
+	if _, err := Read("pedidos.pdf", []byte("x")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("err = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	data, err := WriteCSV(Sheet{Headers: []string{"row", "error"}, Rows: [][]string{{"2", "missing, referenceID"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "row,error\n2,\"missing, referenceID\"\n"; string(data) != want {
+		t.Errorf("csv = %q, want %q", data, want)
+	}
+}
+
+func buildXLSX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
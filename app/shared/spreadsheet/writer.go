@@ -0,0 +1,141 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// RowWriter escribe filas de forma incremental, sin mantener la tabla completa en memoria.
+// Close completa el archivo y debe llamarse una sola vez.
+type RowWriter interface {
+	WriteRow(row []string) error
+	Close() error
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+// NewCSVWriter escribe la cabecera y retorna un RowWriter en formato CSV
+func NewCSVWriter(w io.Writer, headers []string) (RowWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return nil, err
+	}
+	return &csvRowWriter{writer: writer}, nil
+}
+
+func (c *csvRowWriter) WriteRow(row []string) error {
+	return c.writer.Write(row)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// Partes fijas de un libro con una sola hoja llamada "data"
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="data" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+type xlsxRowWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+// NewXLSXWriter escribe un libro XLSX sin dependencias externas. La hoja se escribe primero
+// y en streaming; el resto de las partes del paquete se agregan al cerrar. Todas las celdas
+// se escriben como texto para no alterar valores como LPNs con ceros a la izquierda.
+func NewXLSXWriter(w io.Writer, headers []string) (RowWriter, error) {
+	zw := zip.NewWriter(w)
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x := &xlsxRowWriter{zip: zw, sheet: bufio.NewWriter(sheet)}
+	if _, err := x.sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+	if err := x.WriteRow(headers); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxRowWriter) WriteRow(row []string) error {
+	x.rows++
+	rowNumber := strconv.Itoa(x.rows)
+	x.sheet.WriteString(`<row r="` + rowNumber + `">`)
+	for i, value := range row {
+		if value == "" {
+			continue
+		}
+		x.sheet.WriteString(`<c r="` + columnName(i) + rowNumber + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(x.sheet, []byte(value)); err != nil {
+			return err
+		}
+		x.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxRowWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbookXML},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		f, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return x.zip.Close()
+}
+
+// columnName convierte el índice de columna base cero a su letra (ej. 27 -> "AB")
+func columnName(idx int) string {
+	name := ""
+	for idx++; idx > 0; idx = (idx - 1) / 26 {
+		name = string(rune('A'+(idx-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,68 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestXLSXWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewXLSXWriter(&buf, []string{"referenceID", "lpn", "address"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]string{
+		{"ORD-1", "00123", "Av. Providencia 1234 <Dpto 5> & Co"},
+		{"ORD-2", "", "Los Leones 45"},
+	}
+	for _, row := range rows {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	sheet, err := Read("export.xlsx", buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"referenceID", "lpn", "address"}; !reflect.DeepEqual(sheet.Headers, want) {
+		t.Errorf("headers = %v, want %v", sheet.Headers, want)
+	}
+	if !reflect.DeepEqual(sheet.Rows, rows) {
+		t.Errorf("rows = %q, want %q", sheet.Rows, rows)
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewCSVWriter(&buf, []string{"referenceID", "address"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteRow([]string{"ORD-1", "Av. Providencia 1234, Dpto 5"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "referenceID,address\nORD-1,\"Av. Providencia 1234, Dpto 5\"\n"
+	if buf.String() != want {
+		t.Errorf("csv = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for idx, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(idx); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", idx, got, want)
+		}
+		if got := columnIndex(want + "1"); got != idx {
+			t.Errorf("columnIndex(%q) = %d, want %d", want, got, idx)
+		}
+	}
+}
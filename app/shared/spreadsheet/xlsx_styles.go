@@ -0,0 +1,130 @@
+package spreadsheet
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type xlsxStyleSheet struct {
+	NumFmts []struct {
+		ID         int    `xml:"numFmtId,attr"`
+		FormatCode string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+// xlsxDateKind indica qué partes de fecha y hora muestra un formato numérico
+type xlsxDateKind int
+
+const (
+	xlsxNotDate xlsxDateKind = iota
+	xlsxDateOnly
+	xlsxTimeOnly
+	xlsxDateTime
+)
+
+// Layouts con los que se entregan las celdas con formato de fecha, los mismos que espera la
+// importación de órdenes
+const (
+	xlsxDateLayout     = "2006-01-02"
+	xlsxTimeLayout     = "15:04"
+	xlsxDateTimeLayout = "2006-01-02 15:04"
+)
+
+// xlsxBuiltinDateFormats son los numFmtId predefinidos por OOXML que representan fechas u horas
+var xlsxBuiltinDateFormats = map[int]xlsxDateKind{
+	14: xlsxDateOnly, 15: xlsxDateOnly, 16: xlsxDateOnly, 17: xlsxDateOnly,
+	18: xlsxTimeOnly, 19: xlsxTimeOnly, 20: xlsxTimeOnly, 21: xlsxTimeOnly,
+	22: xlsxDateTime,
+	45: xlsxTimeOnly, 46: xlsxTimeOnly, 47: xlsxTimeOnly,
+}
+
+// xlsxDateStyles resuelve, para cada índice de estilo de celda, si su formato es de fecha
+func xlsxDateStyles(styles xlsxStyleSheet) []xlsxDateKind {
+	customFormats := make(map[int]string, len(styles.NumFmts))
+	for _, f := range styles.NumFmts {
+		customFormats[f.ID] = f.FormatCode
+	}
+	kinds := make([]xlsxDateKind, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		if code, ok := customFormats[xf.NumFmtID]; ok {
+			kinds[i] = xlsxFormatDateKind(code)
+			continue
+		}
+		kinds[i] = xlsxBuiltinDateFormats[xf.NumFmtID]
+	}
+	return kinds
+}
+
+// xlsxFormatDateKind clasifica un formato personalizado. Se ignoran los literales entre
+// comillas, los caracteres escapados y las secciones entre corchetes (colores, locales)
+func xlsxFormatDateKind(code string) xlsxDateKind {
+	var b strings.Builder
+	inQuotes, inBrackets, escaped := false, false, false
+	for _, r := range code {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuotes:
+			inQuotes = r != '"'
+		case inBrackets:
+			inBrackets = r != ']'
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = true
+		case r == '[':
+			inBrackets = true
+		case r == ';':
+			// Solo la primera sección aplica a los valores positivos
+			return xlsxDateKindOf(b.String())
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return xlsxDateKindOf(b.String())
+}
+
+func xlsxDateKindOf(format string) xlsxDateKind {
+	format = strings.ToLower(format)
+	hasTime := strings.ContainsAny(format, "hs")
+	// La "m" es mes salvo que acompañe a horas o segundos, donde representa minutos
+	hasDate := strings.ContainsAny(format, "yd") || (strings.Contains(format, "m") && !hasTime)
+	switch {
+	case hasDate && hasTime:
+		return xlsxDateTime
+	case hasDate:
+		return xlsxDateOnly
+	case hasTime:
+		return xlsxTimeOnly
+	}
+	return xlsxNotDate
+}
+
+// xlsxSerialToString convierte el número de serie de una celda de fecha al layout de su tipo.
+// Si el valor no es numérico se retorna tal cual
+func xlsxSerialToString(value string, kind xlsxDateKind, date1904 bool) string {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial < 0 {
+		return value
+	}
+	// El sistema 1900 parte el 30-12-1899 para compensar el 29-02-1900 que Excel considera válido
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	t := epoch.Add(time.Duration(math.Round(serial*86400)) * time.Second)
+	switch kind {
+	case xlsxDateOnly:
+		return t.Format(xlsxDateLayout)
+	case xlsxTimeOnly:
+		return t.Format(xlsxTimeLayout)
+	case xlsxDateTime:
+		return t.Format(xlsxDateTimeLayout)
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/email"
+	"transport-app/app/adapter/out/fuegoapiclient"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/domain/workflows"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// AssignDeliveryPinWorkflow genera el PIN de entrega de la orden cuando su tipo lo exige,
+// lo envía al destinatario y persiste solo su hash
+type AssignDeliveryPinWorkflow func(ctx context.Context, order domain.Order) error
+
+func init() {
+	ioc.Registry(
+		NewAssignDeliveryPinWorkflow,
+		workflows.NewGenericWorkflow,
+		tidbrepository.NewFindOrderDeliveryPins,
+		tidbrepository.NewSaveOrderDeliveryPin,
+		email.NewSendDeliveryPinEmail,
+		fuegoapiclient.NewPostWebhook,
+		observability.NewObservability,
+		configuration.NewConf,
+	)
+}
+
+func NewAssignDeliveryPinWorkflow(
+	genericWorkflow workflows.GenericWorkflow,
+	findOrderDeliveryPins tidbrepository.FindOrderDeliveryPins,
+	saveOrderDeliveryPin tidbrepository.SaveOrderDeliveryPin,
+	sendDeliveryPinEmail email.SendDeliveryPinEmail,
+	postWebhook fuegoapiclient.PostWebhook,
+	obs observability.Observability,
+	conf configuration.Conf,
+) AssignDeliveryPinWorkflow {
+	pinKey := []byte(conf.DELIVERY_PIN_HMAC_KEY)
+	return func(ctx context.Context, order domain.Order) error {
+		key, ok := sharedcontext.IdempotencyKeyFromContext(ctx)
+		if !ok {
+			return fmt.Errorf("idempotency key not found in context")
+		}
+
+		// La política se lee del tipo de orden persistido, que puede haberse configurado
+		// en una orden anterior del mismo tipo, o del valor por defecto del tenant
+		pins, err := findOrderDeliveryPins(ctx, []domain.Order{order})
+		if err != nil {
+			return fmt.Errorf("failed to find order delivery pin: %w", err)
+		}
+		current, ok := pins[order.DocID(ctx)]
+		if !ok {
+			return ErrOrderNotFound
+		}
+		// Reenviar la orden no regenera un PIN que el destinatario ya recibió
+		if !current.OrderType.RequiresDeliveryPin() || current.PinHash != "" {
+			return nil
+		}
+		if len(pinKey) == 0 {
+			return domain.ErrDeliveryPinKeyMissing
+		}
+
+		workflow, err := genericWorkflow.Initialize(ctx, key, workflows.CreateWorkflow("delivery_pin", "assigned"))
+		if err != nil {
+			return fmt.Errorf("failed to initialize workflow: %w", err)
+		}
+		if err := workflow.SetCompletedTransition(ctx); err != nil {
+			obs.Logger.WarnContext(ctx,
+				err.Error(),
+				"order_doc_id", order.DocID(ctx).String())
+			return nil
+		}
+
+		pin, err := domain.GenerateDeliveryPin()
+		if err != nil {
+			return fmt.Errorf("failed to generate delivery pin: %w", err)
+		}
+
+		// El PIN se envía antes de persistir su hash: si el guardado falla, el reintento
+		// envía un PIN nuevo y el último recibido es el que queda vigente
+		if order.Destination.AddressInfo.Contact.PrimaryEmail != "" {
+			err = sendDeliveryPinEmail(ctx, order, pin)
+		} else {
+			err = postWebhook(ctx, request.MapDeliveryPinGeneratedWebhookBody(order, pin), "delivery-pin-generated")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to send delivery pin: %w", err)
+		}
+
+		if err := saveOrderDeliveryPin(ctx, order, order.DeliveryPinHash(ctx, pinKey, pin), workflow.Map(ctx)); err != nil {
+			return fmt.Errorf("failed to save delivery pin: %w", err)
+		}
+		return nil
+	}
+}
@@ -2,8 +2,11 @@ package usecase
 
 import (
 	"context"
+	"time"
+	"transport-app/app/adapter/out/natsbroadcaster"
 	"transport-app/app/adapter/out/tidbrepository"
 	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
 	"transport-app/app/shared/projection/deliveryunits"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
@@ -16,13 +19,19 @@ func init() {
 		NewCancelOrder,
 		deliveryunits.NewProjection,
 		tidbrepository.NewFindDeliveryUnitsProjectionResult,
-		tidbrepository.NewUpsertDeliveryUnitsHistory)
+		tidbrepository.NewUpsertDeliveryUnitsHistory,
+		natsbroadcaster.NewPublishDeliveryUnitStatusChanges,
+		tidbrepository.NewFindOrderRoutes,
+		observability.NewObservability)
 }
 
 func NewCancelOrder(
 	projection deliveryunits.Projection,
 	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
 	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory,
+	publishStatusChanges natsbroadcaster.PublishDeliveryUnitStatusChanges,
+	findOrderRoutes tidbrepository.FindOrderRoutes,
+	obs observability.Observability,
 ) CancelOrder {
 	return func(ctx context.Context, input domain.Route) error {
 		nonDeliveryReason := input.Orders[0].DeliveryUnits[0].ConfirmDelivery.NonDeliveryReason
@@ -65,9 +74,8 @@ func NewCancelOrder(
 				for _, deliveryUnit := range results {
 					if deliveryUnit.OrderReferenceID == input.Orders[i].ReferenceID.String() {
 						input.Orders[i].DeliveryUnits = append(input.Orders[i].DeliveryUnits, domain.DeliveryUnit{
-							Lpn:    deliveryUnit.LPN,
-							Items:  deliveryUnit.JSONItems.Map(),
-							Status: domain.Status{Status: domain.StatusCancelled},
+							Lpn:   deliveryUnit.LPN,
+							Items: deliveryUnit.JSONItems.Map(),
 							ConfirmDelivery: domain.ConfirmDelivery{
 								NonDeliveryReason: nonDeliveryReason,
 								ManualChange:      manualChange,
@@ -76,9 +84,8 @@ func NewCancelOrder(
 					}
 				}
 			} else {
-				// Si ya tiene LPN y SKUs, solo actualizamos el estado y ConfirmDelivery
+				// Si ya tiene LPN y SKUs, solo actualizamos ConfirmDelivery
 				for j := range input.Orders[i].DeliveryUnits {
-					input.Orders[i].DeliveryUnits[j].Status = domain.Status{Status: domain.StatusCancelled}
 					if input.Orders[i].DeliveryUnits[j].ConfirmDelivery.NonDeliveryReason.IsEmpty() {
 						input.Orders[i].DeliveryUnits[j].ConfirmDelivery = domain.ConfirmDelivery{
 							NonDeliveryReason: nonDeliveryReason,
@@ -92,8 +99,54 @@ func NewCancelOrder(
 			input.Orders[i].AssignIndexesIfNoLPN()
 		}
 
-		return upsertDeliveryUnitsHistory(ctx, domain.Plan{
-			Routes: []domain.Route{input},
-		})
+		// Una unidad finalizada o ya cancelada no se vuelve a cancelar
+		statuses, err := currentDeliveryUnitStatuses(ctx, projection, findDeliveryUnitsProjectionResult, input.Orders)
+		if err != nil {
+			return err
+		}
+		logSkippedTransitions(ctx, obs, transitionDeliveryUnits(ctx, &input, statuses, fixedStatus(domain.StatusCancelled)))
+
+		// La cancelación queda registrada en la ruta de cada orden para que su avance la cuente
+		referenceIDs := make([]string, 0, len(input.Orders))
+		for _, order := range input.Orders {
+			referenceIDs = append(referenceIDs, order.ReferenceID.String())
+		}
+		orderRoutes, err := findOrderRoutes(ctx, referenceIDs)
+		if err != nil {
+			return err
+		}
+		routes := groupOrdersByRoute(input, orderRoutes)
+
+		if err := upsertDeliveryUnitsHistory(ctx, domain.Plan{
+			Routes: routes,
+		}); err != nil {
+			return err
+		}
+
+		occurredAt := time.Now()
+		var changes []domain.DeliveryUnitStatusChange
+		for _, route := range routes {
+			changes = append(changes, domain.DeliveryUnitStatusChangesFromRoute(route, occurredAt)...)
+		}
+		publishStatusChanges(ctx, changes)
+		return nil
+	}
+}
+
+// groupOrdersByRoute reparte las órdenes en una ruta por cada referencia de ruta. Las órdenes
+// sin ruta quedan juntas en una ruta sin referencia
+func groupOrdersByRoute(input domain.Route, orderRoutes map[string]string) []domain.Route {
+	var routes []domain.Route
+	index := make(map[string]int)
+	for _, order := range input.Orders {
+		referenceID := orderRoutes[order.ReferenceID.String()]
+		i, ok := index[referenceID]
+		if !ok {
+			i = len(routes)
+			index[referenceID] = i
+			routes = append(routes, domain.Route{ReferenceID: referenceID})
+		}
+		routes[i].Orders = append(routes[i].Orders, order)
 	}
+	return routes
 }
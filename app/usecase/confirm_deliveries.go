@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"time"
+	"transport-app/app/adapter/out/natsbroadcaster"
 	"transport-app/app/adapter/out/tidbrepository"
 	"transport-app/app/domain"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/observability"
 	"transport-app/app/shared/projection/deliveryunits"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
@@ -16,17 +20,28 @@ func init() {
 		NewConfirmDeliveries,
 		deliveryunits.NewProjection,
 		tidbrepository.NewFindDeliveryUnitsProjectionResult,
-		tidbrepository.NewUpsertDeliveryUnitsHistory)
+		tidbrepository.NewUpsertDeliveryUnitsHistory,
+		tidbrepository.NewFindTenantDeliveryGeofence,
+		NewVerifyDeliveryPins,
+		configuration.NewConf,
+		natsbroadcaster.NewPublishDeliveryUnitStatusChanges,
+		observability.NewObservability)
 }
 
 func NewConfirmDeliveries(
 	projection deliveryunits.Projection,
 	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
-	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory) ConfirmDeliveries {
+	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory,
+	findTenantDeliveryGeofence tidbrepository.FindTenantDeliveryGeofence,
+	verifyDeliveryPins VerifyDeliveryPins,
+	conf configuration.Conf,
+	publishStatusChanges natsbroadcaster.PublishDeliveryUnitStatusChanges,
+	obs observability.Observability) ConfirmDeliveries {
 	return func(ctx context.Context, input domain.Route) error {
 		// Si la orden no tiene LPN ni SKU, buscamos los datos
-		confirmDeliveries := input.Orders[0].DeliveryUnits[0].ConfirmDelivery
 		for i := range input.Orders {
+			// Cada orden hidratada conserva la confirmación informada para ella, incluido su PIN
+			confirmDeliveries := input.Orders[i].DeliveryUnits[0].ConfirmDelivery
 
 			needsHydration := false
 			for j := range input.Orders[i].DeliveryUnits {
@@ -71,14 +86,42 @@ func NewConfirmDeliveries(
 				}
 			}
 
-			// Actualizamos los índices y estados
-			for j := range input.Orders[i].DeliveryUnits {
-				input.Orders[i].AssignIndexesIfNoLPN()
-				input.Orders[i].DeliveryUnits[j].UpdateStatusBasedOnNonDelivery()
-			}
+			// Actualizamos los índices
+			input.Orders[i].AssignIndexesIfNoLPN()
+		}
+
+		// El resultado de la verificación queda en el historial; una orden que exige PIN y no
+		// lo presentó no se confirma
+		input, err := verifyDeliveryPins(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		// Las confirmaciones lejos del destino quedan marcadas para auditoría, sin rechazarse
+		geofence, err := findTenantDeliveryGeofence(ctx)
+		if err != nil {
+			return err
 		}
-		return upsertDeliveryUnitsHistory(ctx, domain.Plan{
+		if geofence.RadiusMeters == 0 {
+			geofence.RadiusMeters = conf.DELIVERY_GEOFENCE_RADIUS_METERS
+		}
+		if err := measureDeliveryDistances(ctx, projection, findDeliveryUnitsProjectionResult, &input, geofence); err != nil {
+			return err
+		}
+
+		// Cada unidad queda finalizada o pendiente según el resultado de la entrega
+		statuses, err := currentDeliveryUnitStatuses(ctx, projection, findDeliveryUnitsProjectionResult, input.Orders)
+		if err != nil {
+			return err
+		}
+		logSkippedTransitions(ctx, obs, transitionDeliveryUnits(ctx, &input, statuses, domain.DeliveryUnit.DeliveryOutcome))
+		if err := upsertDeliveryUnitsHistory(ctx, domain.Plan{
 			Routes: []domain.Route{input},
-		})
+		}); err != nil {
+			return err
+		}
+
+		publishStatusChanges(ctx, domain.DeliveryUnitStatusChangesFromRoute(input, time.Now()))
+		return nil
 	}
 }
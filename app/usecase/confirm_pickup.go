@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type ConfirmPickup func(ctx context.Context, input domain.Route) (domain.Route, error)
+
+func init() {
+	ioc.Registry(
+		NewConfirmPickup,
+		deliveryunits.NewProjection,
+		tidbrepository.NewFindDeliveryUnitsProjectionResult,
+		tidbrepository.NewUpsertDeliveryUnitsHistory,
+		observability.NewObservability)
+}
+
+// NewConfirmPickup registra el retiro en el seller: cada unidad queda en estado picked
+// con la fecha, coordenadas y evidencias de la visita
+func NewConfirmPickup(
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
+	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory,
+	obs observability.Observability) ConfirmPickup {
+	return func(ctx context.Context, input domain.Route) (domain.Route, error) {
+		for i := range input.Orders {
+			// Si la orden no trae LPN ni SKU, buscamos sus unidades
+			needsHydration := false
+			for j := range input.Orders[i].DeliveryUnits {
+				if input.Orders[i].DeliveryUnits[j].Lpn == "" && len(input.Orders[i].DeliveryUnits[j].Items) == 0 {
+					needsHydration = true
+					break
+				}
+			}
+
+			if needsHydration {
+				confirmPickup := input.Orders[i].DeliveryUnits[0].ConfirmDelivery
+				last := 100
+				results, _, err := findDeliveryUnitsProjectionResult(ctx, domain.DeliveryUnitsFilter{
+					Order: &domain.OrderFilter{
+						ReferenceIds: []string{input.Orders[i].ReferenceID.String()},
+					},
+					RequestedFields: map[string]any{
+						projection.DeliveryUnit().String():      true,
+						projection.ReferenceID().String():       true,
+						projection.DeliveryUnitLPN().String():   true,
+						projection.DeliveryUnitItems().String(): true,
+					},
+					OnlyLatestStatus: true,
+					Pagination: domain.Pagination{
+						Last: &last,
+					},
+				})
+				if err != nil {
+					return domain.Route{}, err
+				}
+
+				input.Orders[i].DeliveryUnits = nil
+				for _, deliveryUnit := range results {
+					if deliveryUnit.OrderReferenceID == input.Orders[i].ReferenceID.String() {
+						input.Orders[i].DeliveryUnits = append(input.Orders[i].DeliveryUnits, domain.DeliveryUnit{
+							Lpn:             deliveryUnit.LPN,
+							Items:           deliveryUnit.JSONItems.Map(),
+							ConfirmDelivery: confirmPickup,
+						})
+					}
+				}
+			}
+
+			input.Orders[i].AssignIndexesIfNoLPN()
+		}
+
+		statuses, err := currentDeliveryUnitStatuses(ctx, projection, findDeliveryUnitsProjectionResult, input.Orders)
+		if err != nil {
+			return domain.Route{}, err
+		}
+		logSkippedTransitions(ctx, obs, transitionDeliveryUnits(ctx, &input, statuses, fixedStatus(domain.StatusPicked)))
+		if err := upsertDeliveryUnitsHistory(ctx, domain.Plan{
+			Routes: []domain.Route{input},
+		}); err != nil {
+			return domain.Route{}, err
+		}
+		return input, nil
+	}
+}
@@ -28,6 +28,7 @@ func init() {
 		tidbrepository.NewUpsertDeliveryUnitsLabels,
 		tidbrepository.NewUpsertSkill,
 		tidbrepository.NewUpsertDeliveryUnitsSkills,
+		NewRegisterNewDeliveryUnits,
 		geocoding.NewGeocodingStrategy,
 	)
 }
@@ -46,6 +47,7 @@ func NewCreateOrder(
 	upsertDeliveryUnitsLabels tidbrepository.UpsertDeliveryUnitsLabels,
 	upsertSkill tidbrepository.UpsertSkill,
 	upsertDeliveryUnitsSkills tidbrepository.UpsertDeliveryUnitsSkills,
+	registerNewDeliveryUnits RegisterNewDeliveryUnits,
 	geocode geocoding.GeocodingStrategy,
 ) CreateOrder {
 	return func(ctx context.Context, inOrder domain.Order) error {
@@ -146,10 +148,18 @@ func NewCreateOrder(
 		})
 
 		group.Go(func() error {
+			// Solo las unidades nuevas quedan disponibles; las ya registradas conservan su estado
+			newOrder, err := registerNewDeliveryUnits(group2Ctx, inOrder)
+			if err != nil {
+				return err
+			}
+			if len(newOrder.DeliveryUnits) == 0 {
+				return nil
+			}
 			plan := domain.Plan{
 				Routes: []domain.Route{
 					{
-						Orders: []domain.Order{inOrder},
+						Orders: []domain.Order{newOrder},
 					},
 				},
 			}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	"github.com/paulmach/orb"
+)
+
+// measureDeliveryDistances registra en cada confirmación la distancia al destino de su orden
+// y si queda fuera del geocerco
+func measureDeliveryDistances(
+	ctx context.Context,
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
+	route *domain.Route,
+	geofence domain.DeliveryGeofence,
+) error {
+	if len(route.Orders) == 0 {
+		return nil
+	}
+	referenceIDs := make([]string, 0, len(route.Orders))
+	deliveryUnitsCount := 0
+	for _, order := range route.Orders {
+		referenceIDs = append(referenceIDs, order.ReferenceID.String())
+		deliveryUnitsCount += len(order.DeliveryUnits)
+	}
+	last := max(100, deliveryUnitsCount)
+	results, _, err := findDeliveryUnitsProjectionResult(ctx, domain.DeliveryUnitsFilter{
+		Order: &domain.OrderFilter{
+			ReferenceIds: referenceIDs,
+		},
+		RequestedFields: map[string]any{
+			projection.ReferenceID().String():                     true,
+			projection.DestinationAddressInfo().String():          true,
+			projection.DestinationCoordinatesLatitude().String():  true,
+			projection.DestinationCoordinatesLongitude().String(): true,
+		},
+		OnlyLatestStatus: true,
+		Pagination: domain.Pagination{
+			Last: &last,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	destinations := make(map[string]domain.AddressInfo, len(route.Orders))
+	for _, result := range results {
+		destinations[result.OrderReferenceID] = domain.AddressInfo{
+			Coordinates: domain.Coordinates{
+				Point: orb.Point{result.DestinationCoordinatesLongitude, result.DestinationCoordinatesLatitude},
+			},
+		}
+	}
+
+	for i := range route.Orders {
+		destination := destinations[route.Orders[i].ReferenceID.String()]
+		for j := range route.Orders[i].DeliveryUnits {
+			route.Orders[i].DeliveryUnits[j].ConfirmDelivery.MeasureDistanceTo(destination, geofence)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/projection/deliveryunits"
+)
+
+// currentDeliveryUnitStatuses retorna el último estado registrado de cada unidad de las
+// órdenes, indexado por el documento de la unidad
+func currentDeliveryUnitStatuses(
+	ctx context.Context,
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
+	orders []domain.Order,
+) (map[domain.DocumentID]string, error) {
+	statuses := make(map[domain.DocumentID]string)
+	if len(orders) == 0 {
+		return statuses, nil
+	}
+	referenceIDs := make([]string, 0, len(orders))
+	deliveryUnitsCount := 0
+	for _, order := range orders {
+		referenceIDs = append(referenceIDs, order.ReferenceID.String())
+		deliveryUnitsCount += len(order.DeliveryUnits)
+	}
+	last := max(100, deliveryUnitsCount)
+	results, _, err := findDeliveryUnitsProjectionResult(ctx, domain.DeliveryUnitsFilter{
+		Order: &domain.OrderFilter{
+			ReferenceIds: referenceIDs,
+		},
+		RequestedFields: map[string]any{
+			projection.DeliveryUnit().String():      true,
+			projection.ReferenceID().String():       true,
+			projection.DeliveryUnitLPN().String():   true,
+			projection.DeliveryUnitItems().String(): true,
+			projection.Status().String():            true,
+		},
+		OnlyLatestStatus: true,
+		Pagination: domain.Pagination{
+			Last: &last,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		// Se arma la unidad igual que en la orden para obtener el mismo documento
+		order := domain.Order{
+			ReferenceID: domain.ReferenceID(result.OrderReferenceID),
+			DeliveryUnits: domain.DeliveryUnits{{
+				Lpn:   result.LPN,
+				Items: result.JSONItems.Map(),
+			}},
+		}
+		order.AssignIndexesIfNoLPN()
+		statuses[order.DeliveryUnits[0].DocID(ctx)] = result.Status
+	}
+	return statuses, nil
+}
+
+// transitionDeliveryUnits lleva cada unidad de la ruta al estado que indica next, validando
+// la transición desde su último estado registrado. Las unidades con una transición inválida
+// se quitan de la ruta para que no impidan registrar las demás y se retorna el motivo de cada una.
+func transitionDeliveryUnits(
+	ctx context.Context,
+	route *domain.Route,
+	statuses map[domain.DocumentID]string,
+	next func(domain.DeliveryUnit) string,
+) []error {
+	var skipped []error
+	for i := range route.Orders {
+		route.Orders[i].AssignIndexesIfNoLPN()
+		deliveryUnits := make(domain.DeliveryUnits, 0, len(route.Orders[i].DeliveryUnits))
+		for _, du := range route.Orders[i].DeliveryUnits {
+			if err := du.TransitionTo(statuses[du.DocID(ctx)], next(du)); err != nil {
+				skipped = append(skipped, err)
+				continue
+			}
+			deliveryUnits = append(deliveryUnits, du)
+		}
+		route.Orders[i].DeliveryUnits = deliveryUnits
+	}
+	return skipped
+}
+
+// logSkippedTransitions informa las unidades que quedaron fuera por una transición inválida
+func logSkippedTransitions(ctx context.Context, obs observability.Observability, skipped []error) {
+	for _, err := range skipped {
+		obs.Logger.WarnContext(ctx, "DELIVERY_UNIT_TRANSITION_SKIPPED", "error", err.Error())
+	}
+}
+
+// fixedStatus retorna el mismo estado para todas las unidades
+func fixedStatus(status string) func(domain.DeliveryUnit) string {
+	return func(domain.DeliveryUnit) string {
+		return status
+	}
+}
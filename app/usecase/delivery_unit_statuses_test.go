@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transport-app/app/domain"
+)
+
+func TestTransitionDeliveryUnitsSkipsInvalidUnits(t *testing.T) {
+	ctx := context.Background()
+	route := domain.Route{
+		Orders: []domain.Order{{
+			ReferenceID: "ORDER-1",
+			DeliveryUnits: domain.DeliveryUnits{
+				{Lpn: "LPN-CANCELLED"},
+				{Lpn: "LPN-PLANNED"},
+			},
+		}},
+	}
+	statuses := map[domain.DocumentID]string{
+		route.Orders[0].DeliveryUnits[0].DocID(ctx): domain.StatusCancelled,
+		route.Orders[0].DeliveryUnits[1].DocID(ctx): domain.StatusPlanned,
+	}
+
+	skipped := transitionDeliveryUnits(ctx, &route, statuses, fixedStatus(domain.StatusInTransit))
+
+	if len(skipped) != 1 || !errors.Is(skipped[0], domain.ErrInvalidStatusTransition) {
+		t.Fatalf("skipped = %v, want one invalid transition", skipped)
+	}
+	units := route.Orders[0].DeliveryUnits
+	if len(units) != 1 || units[0].Lpn != "LPN-PLANNED" || units[0].Status.Status != domain.StatusInTransit {
+		t.Errorf("only the valid unit should remain in transit, got %+v", units)
+	}
+}
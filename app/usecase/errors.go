@@ -3,5 +3,11 @@ package usecase
 import "github.com/cockroachdb/errors"
 
 var (
-	ErrOrganizationAlreadyExists = errors.New("organization already exists")
+	ErrOrganizationAlreadyExists      = errors.New("organization already exists")
+	ErrRouteNotFound                  = errors.New("route not found")
+	ErrNoPendingVisits                = errors.New("route has no pending visits")
+	ErrOptimizationJobNotFound        = errors.New("optimization job not found")
+	ErrDeliveryUnitsExportJobNotFound = errors.New("delivery units export job not found")
+	ErrOrderNotFound                  = errors.New("order not found")
+	ErrTenantRequired                 = errors.New("tenant not found in context")
 )
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"transport-app/app/adapter/out/storjbucket"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/adapter/out/tidbrepository/projectionresult"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/storj"
+	"transport-app/app/shared/projection/deliveryunits"
+	"transport-app/app/shared/spreadsheet"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+const (
+	// deliveryUnitsExportPageSize es la cantidad de unidades leídas por consulta al exportar
+	deliveryUnitsExportPageSize = 500
+	deliveryUnitsExportURLTTL   = 7 * 24 * time.Hour
+)
+
+// ExportDeliveryUnits recorre página a página las unidades que cumplen el filtro, las escribe en
+// el formato del job, sube el archivo al bucket y retorna el job con su URL de descarga
+type ExportDeliveryUnits func(ctx context.Context, job domain.DeliveryUnitsExportJob, filter domain.DeliveryUnitsFilter) (domain.DeliveryUnitsExportJob, error)
+
+func init() {
+	ioc.Registry(
+		NewExportDeliveryUnits,
+		tidbrepository.NewFindDeliveryUnitsProjectionResult,
+		storjbucket.NewTransportAppBucket,
+	)
+}
+
+func NewExportDeliveryUnits(
+	findDeliveryUnits tidbrepository.FindDeliveryUnitsProjectionResult,
+	storjManager storj.UplinkManager) ExportDeliveryUnits {
+	return func(ctx context.Context, job domain.DeliveryUnitsExportJob, filter domain.DeliveryUnitsFilter) (domain.DeliveryUnitsExportJob, error) {
+		if err := job.Format.Validate(); err != nil {
+			return job, err
+		}
+
+		// Las páginas se escriben en un pipe que el bucket consume mientras se generan, por lo
+		// que en memoria solo vive la página actual y la parte pendiente de subir
+		pr, pw := io.Pipe()
+		written := make(chan deliveryUnitsExportResult, 1)
+		go func() {
+			rows, err := writeDeliveryUnitsExport(ctx, pw, job.Format, filter, findDeliveryUnits)
+			pw.CloseWithError(err)
+			written <- deliveryUnitsExportResult{rows: rows, err: err}
+		}()
+
+		objectKey := fmt.Sprintf("delivery-units/exports/%s.%s", job.ID, job.Format.Extension())
+		uploadErr := storjManager.UploadStream(ctx, objectKey, job.Format.ContentType(), pr)
+		// Si la subida se corta, cerrar el lector libera a la goroutine bloqueada en el pipe
+		pr.CloseWithError(uploadErr)
+		result := <-written
+		if result.err != nil {
+			return job, result.err
+		}
+		if uploadErr != nil {
+			return job, fmt.Errorf("error uploading export: %w", uploadErr)
+		}
+		downloadURL, err := storjManager.GeneratePublicDownloadURL(ctx, objectKey, deliveryUnitsExportURLTTL)
+		if err != nil {
+			return job, fmt.Errorf("error generating download url: %w", err)
+		}
+
+		now := time.Now()
+		return job.Succeed(now, result.rows, objectKey, downloadURL, now.Add(deliveryUnitsExportURLTTL)), nil
+	}
+}
+
+type deliveryUnitsExportResult struct {
+	rows int
+	err  error
+}
+
+// writeDeliveryUnitsExport escribe en w las unidades que cumplen el filtro, página a página, y
+// retorna la cantidad de filas escritas
+func writeDeliveryUnitsExport(
+	ctx context.Context,
+	w io.Writer,
+	format domain.DeliveryUnitsExportFormat,
+	filter domain.DeliveryUnitsFilter,
+	findDeliveryUnits tidbrepository.FindDeliveryUnitsProjectionResult) (int, error) {
+	writer, err := newDeliveryUnitsExportWriter(w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	filter.RequestedFields = deliveryUnitsExportRequestedFields()
+	first := deliveryUnitsExportPageSize
+	filter.Pagination = domain.Pagination{First: &first}
+
+	rows := 0
+	for {
+		results, hasMore, err := findDeliveryUnits(ctx, filter)
+		if err != nil {
+			return rows, fmt.Errorf("error finding delivery units: %w", err)
+		}
+		for _, result := range results {
+			if err := writer.Write(result); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if !hasMore || len(results) == 0 {
+			break
+		}
+		after := strconv.FormatInt(results[len(results)-1].ID, 10)
+		filter.Pagination.After = &after
+	}
+	return rows, writer.Close()
+}
+
+type exportedDeliveryUnit = projectionresult.DeliveryUnitsProjectionResult
+
+// deliveryUnitsExportColumn es una columna del archivo exportado; su cabecera es el path
+// de la proyección de unidades de entrega que la alimenta
+type deliveryUnitsExportColumn struct {
+	field deliveryunits.Field
+	value func(du exportedDeliveryUnit) string
+}
+
+var deliveryUnitsProjection = deliveryunits.NewProjection()
+
+var deliveryUnitsExportColumns = []deliveryUnitsExportColumn{
+	{deliveryUnitsProjection.ReferenceID(), func(du exportedDeliveryUnit) string { return du.OrderReferenceID }},
+	{deliveryUnitsProjection.Status(), func(du exportedDeliveryUnit) string { return du.Status }},
+	{deliveryUnitsProjection.Commerce(), func(du exportedDeliveryUnit) string { return du.Commerce }},
+	{deliveryUnitsProjection.Channel(), func(du exportedDeliveryUnit) string { return du.Channel }},
+	{deliveryUnitsProjection.OrderTypeType(), func(du exportedDeliveryUnit) string { return du.OrderType }},
+	{deliveryUnitsProjection.DeliveryUnitLPN(), func(du exportedDeliveryUnit) string { return du.LPN }},
+	{deliveryUnitsProjection.DeliveryUnitSizeCategory(), func(du exportedDeliveryUnit) string { return du.SizeCategory }},
+	{deliveryUnitsProjection.DeliveryUnitWeight(), func(du exportedDeliveryUnit) string { return strconv.FormatInt(du.Weight, 10) }},
+	{deliveryUnitsProjection.DeliveryUnitVolume(), func(du exportedDeliveryUnit) string { return strconv.FormatInt(du.Volume, 10) }},
+	{deliveryUnitsProjection.DeliveryUnitPrice(), func(du exportedDeliveryUnit) string { return strconv.FormatInt(du.Price, 10) }},
+	{deliveryUnitsProjection.DestinationAddressLine1(), func(du exportedDeliveryUnit) string { return du.DestinationAddressLine1 }},
+	{deliveryUnitsProjection.DestinationAddressLine2(), func(du exportedDeliveryUnit) string { return du.DestinationAddressLine2 }},
+	{deliveryUnitsProjection.DestinationAdminAreaLevel1(), func(du exportedDeliveryUnit) string { return du.DestinationAdminAreaLevel1 }},
+	{deliveryUnitsProjection.DestinationAdminAreaLevel2(), func(du exportedDeliveryUnit) string { return du.DestinationAdminAreaLevel2 }},
+	{deliveryUnitsProjection.DestinationAdminAreaLevel3(), func(du exportedDeliveryUnit) string { return du.DestinationAdminAreaLevel3 }},
+	{deliveryUnitsProjection.DestinationZipCode(), func(du exportedDeliveryUnit) string { return du.DestinationZipCode }},
+	{deliveryUnitsProjection.DestinationCoordinatesLatitude(), func(du exportedDeliveryUnit) string {
+		return strconv.FormatFloat(du.DestinationCoordinatesLatitude, 'f', -1, 64)
+	}},
+	{deliveryUnitsProjection.DestinationCoordinatesLongitude(), func(du exportedDeliveryUnit) string {
+		return strconv.FormatFloat(du.DestinationCoordinatesLongitude, 'f', -1, 64)
+	}},
+	{deliveryUnitsProjection.DestinationContactFullName(), func(du exportedDeliveryUnit) string { return du.DestinationContactFullName }},
+	{deliveryUnitsProjection.DestinationContactPhone(), func(du exportedDeliveryUnit) string { return du.DestinationContactPhone }},
+	{deliveryUnitsProjection.DestinationContactEmail(), func(du exportedDeliveryUnit) string { return du.DestinationContactEmail }},
+	{deliveryUnitsProjection.PromisedDateDateRangeStartDate(), func(du exportedDeliveryUnit) string { return du.OrderPromisedDateStartDate }},
+	{deliveryUnitsProjection.PromisedDateDateRangeEndDate(), func(du exportedDeliveryUnit) string { return du.OrderPromisedDateEndDate }},
+	{deliveryUnitsProjection.PromisedDateTimeRangeStartTime(), func(du exportedDeliveryUnit) string { return du.OrderPromisedDateStartTime }},
+	{deliveryUnitsProjection.PromisedDateTimeRangeEndTime(), func(du exportedDeliveryUnit) string { return du.OrderPromisedDateEndTime }},
+	{deliveryUnitsProjection.DeliveryFailureReason(), func(du exportedDeliveryUnit) string { return du.NonDeliveryReason }},
+	{deliveryUnitsProjection.DeliverySuspiciousLocation(), func(du exportedDeliveryUnit) string { return strconv.FormatBool(du.SuspiciousLocation) }},
+	{deliveryUnitsProjection.DeliveryDistanceToDestinationMeters(), func(du exportedDeliveryUnit) string {
+		if du.DistanceToDestinationMeters == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*du.DistanceToDestinationMeters, 'f', -1, 64)
+	}},
+}
+
+func deliveryUnitsExportRequestedFields() map[string]any {
+	fields := make(map[string]any, len(deliveryUnitsExportColumns))
+	for _, column := range deliveryUnitsExportColumns {
+		fields[column.field.String()] = ""
+	}
+	return fields
+}
+
+func deliveryUnitsExportHeaders() []string {
+	headers := make([]string, len(deliveryUnitsExportColumns))
+	for i, column := range deliveryUnitsExportColumns {
+		headers[i] = column.field.String()
+	}
+	return headers
+}
+
+func deliveryUnitsExportRow(du projectionresult.DeliveryUnitsProjectionResult) []string {
+	row := make([]string, len(deliveryUnitsExportColumns))
+	for i, column := range deliveryUnitsExportColumns {
+		row[i] = column.value(du)
+	}
+	return row
+}
+
+type deliveryUnitsExportWriter interface {
+	Write(du exportedDeliveryUnit) error
+	Close() error
+}
+
+func newDeliveryUnitsExportWriter(w io.Writer, format domain.DeliveryUnitsExportFormat) (deliveryUnitsExportWriter, error) {
+	switch format {
+	case domain.DeliveryUnitsExportCSV:
+		rows, err := spreadsheet.NewCSVWriter(w, deliveryUnitsExportHeaders())
+		if err != nil {
+			return nil, err
+		}
+		return sheetExportWriter{rows: rows}, nil
+	case domain.DeliveryUnitsExportXLSX:
+		rows, err := spreadsheet.NewXLSXWriter(w, deliveryUnitsExportHeaders())
+		if err != nil {
+			return nil, err
+		}
+		return sheetExportWriter{rows: rows}, nil
+	case domain.DeliveryUnitsExportGeoJSON:
+		return newGeoJSONExportWriter(w)
+	}
+	return nil, domain.ErrInvalidDeliveryUnitsExportFormat
+}
+
+type sheetExportWriter struct {
+	rows spreadsheet.RowWriter
+}
+
+func (s sheetExportWriter) Write(du exportedDeliveryUnit) error {
+	return s.rows.WriteRow(deliveryUnitsExportRow(du))
+}
+
+func (s sheetExportWriter) Close() error {
+	return s.rows.Close()
+}
+
+// geoJSONExportWriter escribe un FeatureCollection con el punto de destino de cada unidad y
+// las columnas de la exportación como propiedades. Las unidades sin coordenadas se incluyen
+// con geometría nula para que el total coincida con los demás formatos.
+type geoJSONExportWriter struct {
+	w        io.Writer
+	headers  []string
+	features int
+}
+
+func newGeoJSONExportWriter(w io.Writer) (*geoJSONExportWriter, error) {
+	if _, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`); err != nil {
+		return nil, err
+	}
+	return &geoJSONExportWriter{w: w, headers: deliveryUnitsExportHeaders()}, nil
+}
+
+func (g *geoJSONExportWriter) Write(du exportedDeliveryUnit) error {
+	feature := geojson.NewFeature(nil)
+	if du.DestinationCoordinatesLatitude != 0 || du.DestinationCoordinatesLongitude != 0 {
+		feature.Geometry = orb.Point{du.DestinationCoordinatesLongitude, du.DestinationCoordinatesLatitude}
+	}
+	feature.ID = strconv.FormatInt(du.ID, 10)
+	for i, value := range deliveryUnitsExportRow(du) {
+		if value != "" {
+			feature.Properties[g.headers[i]] = value
+		}
+	}
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+	if g.features > 0 {
+		if _, err := io.WriteString(g.w, ","); err != nil {
+			return err
+		}
+	}
+	g.features++
+	_, err = g.w.Write(data)
+	return err
+}
+
+func (g *geoJSONExportWriter) Close() error {
+	_, err := io.WriteString(g.w, "]}")
+	return err
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/google/uuid"
+)
+
+type FindDeliveryUnitsExportJob func(ctx context.Context, jobID string) (domain.DeliveryUnitsExportJob, error)
+
+func init() {
+	ioc.Registry(
+		NewFindDeliveryUnitsExportJob,
+		NewGetDataFromRedisWorkflow,
+	)
+}
+
+func NewFindDeliveryUnitsExportJob(getDataFromRedisWorkflow GetDataFromRedisWorkflow) FindDeliveryUnitsExportJob {
+	return func(ctx context.Context, jobID string) (domain.DeliveryUnitsExportJob, error) {
+		tenantID := sharedcontext.TenantIDFromContext(ctx)
+		if tenantID == uuid.Nil {
+			return domain.DeliveryUnitsExportJob{}, ErrDeliveryUnitsExportJobNotFound
+		}
+		data, err := getDataFromRedisWorkflow(ctx, deliveryUnitsExportJobKey(tenantID, jobID))
+		if err != nil {
+			return domain.DeliveryUnitsExportJob{}, err
+		}
+		if data == nil {
+			return domain.DeliveryUnitsExportJob{}, ErrDeliveryUnitsExportJobNotFound
+		}
+		var job domain.DeliveryUnitsExportJob
+		if err := json.Unmarshal(data, &job); err != nil {
+			return domain.DeliveryUnitsExportJob{}, fmt.Errorf("error deserializando job de exportación: %w", err)
+		}
+		// Un job de otro tenant se trata como inexistente para no revelar que existe
+		if job.TenantID != tenantID {
+			return domain.DeliveryUnitsExportJob{}, ErrDeliveryUnitsExportJobNotFound
+		}
+		return job, nil
+	}
+}
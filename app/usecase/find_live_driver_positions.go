@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"transport-app/app/adapter/out/cacherepository"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/configuration"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// FindLiveDriverPositions retorna la última posición conocida de las rutas activas del tenant,
+// entendiendo como activas las que reportaron dentro de la ventana configurada y que todavía
+// tienen unidades en tránsito
+type FindLiveDriverPositions func(ctx context.Context) ([]domain.DriverLocation, error)
+
+func init() {
+	ioc.Registry(
+		NewFindLiveDriverPositions,
+		cacherepository.NewDriverPositionCacheStrategy,
+		tidbrepository.NewFindRoutesInTransit,
+		configuration.NewConf)
+}
+
+func NewFindLiveDriverPositions(
+	positions cacherepository.DriverPositionCacheStrategy,
+	findRoutesInTransit tidbrepository.FindRoutesInTransit,
+	conf configuration.Conf) FindLiveDriverPositions {
+	return func(ctx context.Context) ([]domain.DriverLocation, error) {
+		since := time.Now().Add(-time.Duration(conf.DRIVER_POSITION_ACTIVE_MINUTES) * time.Minute)
+		recent, err := positions.List(ctx, since)
+		if err != nil {
+			return nil, err
+		}
+		if len(recent) == 0 {
+			return recent, nil
+		}
+
+		routeReferenceIDs := make([]string, len(recent))
+		for i, position := range recent {
+			routeReferenceIDs[i] = position.RouteReferenceID
+		}
+		inTransit, err := findRoutesInTransit(ctx, routeReferenceIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		// Una ruta finalizada puede seguir reportando hasta que el conductor cierra la app
+		live := make([]domain.DriverLocation, 0, len(recent))
+		for _, position := range recent {
+			if inTransit[position.RouteReferenceID] {
+				live = append(live, position)
+			}
+		}
+		return live, nil
+	}
+}
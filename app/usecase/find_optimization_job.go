@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"transport-app/app/domain"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type FindOptimizationJob func(ctx context.Context, jobID string) (domain.OptimizationJob, error)
+
+func init() {
+	ioc.Registry(
+		NewFindOptimizationJob,
+		NewGetDataFromRedisWorkflow,
+	)
+}
+
+func NewFindOptimizationJob(getDataFromRedisWorkflow GetDataFromRedisWorkflow) FindOptimizationJob {
+	return func(ctx context.Context, jobID string) (domain.OptimizationJob, error) {
+		job, err := loadOptimizationJob(ctx, getDataFromRedisWorkflow, jobID)
+		if err != nil || job.ForwardedTo == "" {
+			return job, err
+		}
+		// El job reenviado aún puede no existir mientras se transforma la solicitud
+		forwarded, err := loadOptimizationJob(ctx, getDataFromRedisWorkflow, job.ForwardedTo)
+		if errors.Is(err, ErrOptimizationJobNotFound) {
+			return job, nil
+		}
+		if err != nil {
+			return domain.OptimizationJob{}, err
+		}
+		forwarded.ID = job.ID
+		forwarded.QueuedAt = job.QueuedAt
+		forwarded.ForwardedTo = job.ForwardedTo
+		return forwarded, nil
+	}
+}
+
+func loadOptimizationJob(ctx context.Context, getDataFromRedisWorkflow GetDataFromRedisWorkflow, jobID string) (domain.OptimizationJob, error) {
+	data, err := getDataFromRedisWorkflow(ctx, optimizationJobKey(ctx, jobID))
+	if err != nil {
+		return domain.OptimizationJob{}, err
+	}
+	if data == nil {
+		return domain.OptimizationJob{}, ErrOptimizationJobNotFound
+	}
+	var job domain.OptimizationJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return domain.OptimizationJob{}, fmt.Errorf("error deserializando job de optimización: %w", err)
+	}
+	return job, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// FindRouteProgress resume el último estado de las unidades de entrega registradas en la ruta
+type FindRouteProgress func(ctx context.Context, routeReferenceID string) (domain.RouteProgress, error)
+
+func init() {
+	ioc.Registry(
+		NewFindRouteProgress,
+		deliveryunits.NewProjection,
+		tidbrepository.NewFindDeliveryUnitsProjectionResult)
+}
+
+func NewFindRouteProgress(
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult) FindRouteProgress {
+	return func(ctx context.Context, routeReferenceID string) (domain.RouteProgress, error) {
+		var statuses []string
+		first := 100
+		var after *string
+		for {
+			results, hasMore, err := findDeliveryUnitsProjectionResult(ctx, domain.DeliveryUnitsFilter{
+				Route: &domain.RouteFilter{
+					ReferenceIds: []string{routeReferenceID},
+				},
+				RequestedFields: map[string]any{
+					projection.Status().String(): true,
+				},
+				OnlyLatestStatus: true,
+				Pagination: domain.Pagination{
+					First: &first,
+					After: after,
+				},
+			})
+			if err != nil {
+				return domain.RouteProgress{}, err
+			}
+			for _, result := range results {
+				statuses = append(statuses, result.Status)
+			}
+			if !hasMore || len(results) == 0 {
+				break
+			}
+			cursor := strconv.FormatInt(results[len(results)-1].ID, 10)
+			after = &cursor
+		}
+		return domain.NewRouteProgress(routeReferenceID, statuses), nil
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"transport-app/app/adapter/out/geocoding"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type FixOrderDestination func(ctx context.Context, orders []domain.Order, change domain.ManualChange) ([]domain.Order, error)
+
+func init() {
+	ioc.Registry(
+		NewFixOrderDestination,
+		tidbrepository.NewUpsertAddressInfo,
+		tidbrepository.NewUpdateOrderDestination,
+		geocoding.NewGeocodingStrategy,
+	)
+}
+
+// NewFixOrderDestination guarda la dirección corregida como una nueva versión y deja las
+// órdenes apuntando a ella. Solo se geocodifica cuando la corrección no trae coordenadas.
+func NewFixOrderDestination(
+	upsertAddressInfo tidbrepository.UpsertAddressInfo,
+	updateOrderDestination tidbrepository.UpdateOrderDestination,
+	geocode geocoding.GeocodingStrategy,
+) FixOrderDestination {
+	return func(ctx context.Context, orders []domain.Order, change domain.ManualChange) ([]domain.Order, error) {
+		if len(orders) == 0 {
+			return nil, nil
+		}
+		destination := orders[0].Destination.AddressInfo
+		if destination.HasCoordinates() {
+			destination.ToLowerAndRemovePunctuation()
+		} else if err := destination.NormalizeAndGeocode(ctx, geocode); err != nil {
+			return nil, fmt.Errorf("failed to geocode destination: %w", err)
+		}
+
+		if err := upsertAddressInfo(ctx, destination); err != nil {
+			return nil, fmt.Errorf("failed to upsert destination: %w", err)
+		}
+
+		for i := range orders {
+			orders[i].Destination.AddressInfo = destination
+		}
+		if err := updateOrderDestination(ctx, orders, change); err != nil {
+			return nil, fmt.Errorf("failed to fix destination: %w", err)
+		}
+		return orders, nil
+	}
+}
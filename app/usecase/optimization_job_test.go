@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transport-app/app/domain"
+)
+
+func TestOptimizationJobIsScopedToTenant(t *testing.T) {
+	store := map[string][]byte{}
+	save := NewSaveOptimizationJob(func(ctx context.Context, key string, value []byte) error {
+		store[key] = value
+		return nil
+	})
+	find := NewFindOptimizationJob(func(ctx context.Context, key string) ([]byte, error) {
+		return store[key], nil
+	})
+
+	owner := buildCtx("6f1f1f5e-1111-4c4c-9a9a-000000000001", "CL")
+	other := buildCtx("6f1f1f5e-2222-4c4c-9a9a-000000000002", "CL")
+	if err := save(owner, domain.NewOptimizationJob("JOB-1", time.Now())); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if _, err := find(owner, "JOB-1"); err != nil {
+		t.Fatalf("find with the owner tenant: %v", err)
+	}
+	if _, err := find(other, "JOB-1"); !errors.Is(err, ErrOptimizationJobNotFound) {
+		t.Fatalf("find with another tenant = %v, want ErrOptimizationJobNotFound", err)
+	}
+}
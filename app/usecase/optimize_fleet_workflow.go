@@ -3,8 +3,11 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/tidbrepository"
 	"transport-app/app/adapter/out/vroom"
+	"transport-app/app/domain"
 	"transport-app/app/domain/optimization"
 	"transport-app/app/shared/infrastructure/observability"
 
@@ -17,15 +20,31 @@ func init() {
 	ioc.Registry(
 		NewOptimizeFleetWorkflow,
 		vroom.NewOptimize,
+		tidbrepository.NewFindPriorityRules,
 		observability.NewObservability,
 	)
 }
 
 func NewOptimizeFleetWorkflow(
 	optimize vroom.Optimize,
+	findPriorityRules tidbrepository.FindPriorityRules,
 	obs observability.Observability,
 ) OptimizeFleetWorkflow {
 	return func(ctx context.Context, input optimization.FleetOptimization) ([]request.UpsertRouteRequest, error) {
+		rules, err := findPriorityRules(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find priority rules: %w", err)
+		}
+		if len(rules) == 0 {
+			rules = domain.DefaultPriorityRules
+		}
+		// La cercanía de la fecha prometida se mide desde el día en que se ejecuta el plan
+		planningDate := input.PlannedDate
+		if planningDate.IsZero() {
+			planningDate = time.Now()
+		}
+		input = applyPriorityRules(input, rules, planningDate)
+
 		routeRequests, err := optimize(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to optimize fleet: %w", err)
@@ -34,3 +53,24 @@ func NewOptimizeFleetWorkflow(
 		return routeRequests, nil
 	}
 }
+
+// applyPriorityRules asigna a cada visita la mayor prioridad entre la informada y la
+// derivada de la categoría de servicio y fecha prometida de sus órdenes, contando los días
+// desde planningDate
+func applyPriorityRules(input optimization.FleetOptimization, rules domain.PriorityRules, planningDate time.Time) optimization.FleetOptimization {
+	visits := make([]optimization.Visit, len(input.Visits))
+	for i, visit := range input.Visits {
+		for _, order := range visit.Orders {
+			priority, ok := rules.Resolve(
+				order.PromisedDate.ServiceCategory,
+				order.PromisedDate.Deadline(),
+				planningDate)
+			if ok && priority > visit.Priority {
+				visit.Priority = priority
+			}
+		}
+		visits[i] = visit
+	}
+	input.Visits = visits
+	return input
+}
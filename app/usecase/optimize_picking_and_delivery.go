@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/adapter/out/vroom"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type OptimizePickingAndDelivery func(ctx context.Context, input request.OptimizePickingAndDeliveryRequest) (request.UpsertRouteRequest, error)
+
+func init() {
+	ioc.Registry(
+		NewOptimizePickingAndDelivery,
+		vroom.NewOptimize,
+		tidbrepository.NewUpsertPlan,
+		tidbrepository.NewUpsertRoute,
+		tidbrepository.NewUpsertDeliveryUnitsHistory,
+		tidbrepository.NewUpsertDriver,
+		NewUpsertCarrierWorkflow,
+		NewPlanDeliveryUnits,
+		NewStoreDataInRedisWorkflow,
+		observability.NewObservability,
+	)
+}
+
+func NewOptimizePickingAndDelivery(
+	optimize vroom.Optimize,
+	upsertPlan tidbrepository.UpsertPlan,
+	upsertRoute tidbrepository.UpsertRoute,
+	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory,
+	upsertDriver tidbrepository.UpsertDriver,
+	upsertCarrierWorkflow UpsertCarrierWorkflow,
+	planDeliveryUnits PlanDeliveryUnits,
+	storeDataInRedisWorkflow StoreDataInRedisWorkflow,
+	obs observability.Observability,
+) OptimizePickingAndDelivery {
+	return func(ctx context.Context, input request.OptimizePickingAndDeliveryRequest) (request.UpsertRouteRequest, error) {
+		routeRequests, err := optimize(ctx, input.Map())
+		if err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to optimize container route: %w", err)
+		}
+
+		// Un solo vehículo genera a lo más su ruta y la de órdenes sin asignar
+		var routeRequest request.UpsertRouteRequest
+		var unassignedVisits []request.UpsertRouteVisit
+		for _, rr := range routeRequests {
+			if rr.Vehicle.Plate == "UNASSIGNED" {
+				unassignedVisits = append(unassignedVisits, rr.Visits...)
+				continue
+			}
+			routeRequest = rr
+		}
+		now := time.Now()
+		// La ruta queda identificada por el contenedor que recorre el conductor
+		routeRequest.ReferenceID = input.Container.Lpn
+		routeRequest.PlanReferenceID = input.Container.Lpn
+		routeRequest.Vehicle.Plate = input.Container.Lpn
+		routeRequest.CreatedAt = now.UTC().Format(time.RFC3339)
+		routeRequest.Visits = append(routeRequest.Visits, unassignedVisits...)
+
+		route, err := routeRequest.Map()
+		if err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to map route %s: %w", routeRequest.ReferenceID, err)
+		}
+		route.Vehicle.Carrier = input.MapCarrier()
+
+		plan := domain.Plan{
+			ReferenceID: routeRequest.PlanReferenceID,
+			PlannedDate: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		}
+		var assigned []domain.Order
+		for _, order := range route.Orders {
+			if order.UnassignedReason != "" {
+				plan.UnassignedOrders = append(plan.UnassignedOrders, order)
+				continue
+			}
+			assigned = append(assigned, order)
+		}
+		planned := route
+		planned.Orders = assigned
+		if err := planDeliveryUnits(ctx, &planned); err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to plan delivery units: %w", err)
+		}
+		plan.Routes = []domain.Route{planned}
+
+		if route.Vehicle.Carrier.NationalID != "" {
+			if err := upsertCarrierWorkflow(ctx, route.Vehicle.Carrier); err != nil {
+				return request.UpsertRouteRequest{}, err
+			}
+		}
+		if route.Vehicle.Carrier.Driver.NationalID != "" {
+			if err := upsertDriver(ctx, route.Vehicle.Carrier.Driver); err != nil {
+				return request.UpsertRouteRequest{}, fmt.Errorf("failed to upsert driver: %w", err)
+			}
+		}
+		if err := upsertPlan(ctx, plan); err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to upsert plan: %w", err)
+		}
+		if err := upsertRoute(ctx, route, routeRequest, plan.DocID(ctx).String()); err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to upsert route %s: %w", route.ReferenceID, err)
+		}
+		if len(assigned) > 0 {
+			if err := upsertDeliveryUnitsHistory(ctx, plan); err != nil {
+				return request.UpsertRouteRequest{}, fmt.Errorf("failed to plan delivery units: %w", err)
+			}
+		}
+
+		routeBytes, err := json.Marshal(routeRequest)
+		if err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+		if err := storeDataInRedisWorkflow(ctx, routeRequest.ReferenceID, routeBytes); err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+
+		obs.Logger.InfoContext(ctx, "CONTAINER_ROUTE_OPTIMIZED",
+			"route", routeRequest.ReferenceID,
+			"visits", len(routeRequest.Visits),
+			"unassignedOrders", len(plan.UnassignedOrders))
+		return routeRequest, nil
+	}
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// PlanDeliveryUnits deja planificadas las unidades de la ruta, validando la transición desde
+// su último estado registrado. Las unidades que no pueden planificarse se quitan de la ruta
+type PlanDeliveryUnits func(ctx context.Context, route *domain.Route) error
+
+func init() {
+	ioc.Registry(
+		NewPlanDeliveryUnits,
+		deliveryunits.NewProjection,
+		tidbrepository.NewFindDeliveryUnitsProjectionResult,
+		observability.NewObservability)
+}
+
+func NewPlanDeliveryUnits(
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
+	obs observability.Observability) PlanDeliveryUnits {
+	return func(ctx context.Context, route *domain.Route) error {
+		statuses, err := currentDeliveryUnitStatuses(ctx, projection, findDeliveryUnitsProjectionResult, route.Orders)
+		if err != nil {
+			return err
+		}
+		logSkippedTransitions(ctx, obs, transitionDeliveryUnits(ctx, route, statuses, fixedStatus(domain.StatusPlanned)))
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+// RegisterNewDeliveryUnits retorna la orden solo con las unidades que aún no tienen estado,
+// dejándolas disponibles. Reenviar una orden no altera el estado de las unidades ya registradas
+type RegisterNewDeliveryUnits func(ctx context.Context, order domain.Order) (domain.Order, error)
+
+func init() {
+	ioc.Registry(
+		NewRegisterNewDeliveryUnits,
+		deliveryunits.NewProjection,
+		tidbrepository.NewFindDeliveryUnitsProjectionResult)
+}
+
+func NewRegisterNewDeliveryUnits(
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult) RegisterNewDeliveryUnits {
+	return func(ctx context.Context, order domain.Order) (domain.Order, error) {
+		statuses, err := currentDeliveryUnitStatuses(ctx, projection, findDeliveryUnitsProjectionResult, []domain.Order{order})
+		if err != nil {
+			return order, err
+		}
+		order.AssignIndexesIfNoLPN()
+		newDeliveryUnits := make(domain.DeliveryUnits, 0, len(order.DeliveryUnits))
+		for _, du := range order.DeliveryUnits {
+			current := statuses[du.DocID(ctx)]
+			if current != "" {
+				continue
+			}
+			if err := du.TransitionTo(current, domain.StatusAvailable); err != nil {
+				return order, err
+			}
+			newDeliveryUnits = append(newDeliveryUnits, du)
+		}
+		order.DeliveryUnits = newDeliveryUnits
+		return order, nil
+	}
+}
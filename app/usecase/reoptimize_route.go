@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/fuegoapiclient"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/adapter/out/vroom"
+	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/shared/projection/deliveryunits"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type ReoptimizeRoute func(ctx context.Context, routeID string, input request.ReoptimizeRouteRequest) (request.UpsertRouteRequest, error)
+
+func init() {
+	ioc.Registry(
+		NewReoptimizeRoute,
+		deliveryunits.NewProjection,
+		tidbrepository.NewFindDeliveryUnitsProjectionResult,
+		NewGetDataFromRedisWorkflow,
+		NewStoreDataInRedisWorkflow,
+		tidbrepository.NewUpsertRoute,
+		vroom.NewOptimize,
+		fuegoapiclient.NewPostWebhook,
+		observability.NewObservability,
+	)
+}
+
+// closedRouteStatuses son los estados con los que una unidad deja de estar pendiente en
+// la ruta: entregada, entregada parcialmente, cancelada o devuelta a pendiente tras un
+// intento fallido
+var closedRouteStatuses = map[string]bool{
+	domain.StatusFinished:           true,
+	domain.StatusPartiallyDelivered: true,
+	domain.StatusCancelled:          true,
+	domain.StatusPending:            true,
+}
+
+// closedRouteVisits indica si la unidad de la orden ya no está pendiente en la ruta, según el
+// último estado de cada unidad indexado por referencia de orden y LPN
+func closedRouteVisits(statuses map[string]map[string]string) func(orderReferenceID, lpn string) bool {
+	return func(orderReferenceID, lpn string) bool {
+		byLPN, ok := statuses[orderReferenceID]
+		if !ok {
+			return false
+		}
+		if status, ok := byLPN[lpn]; ok && lpn != "" {
+			return closedRouteStatuses[status]
+		}
+		// Sin LPN la orden se considera cerrada solo si todas sus unidades lo están
+		for _, status := range byLPN {
+			if !closedRouteStatuses[status] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func NewReoptimizeRoute(
+	projection deliveryunits.Projection,
+	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
+	getDataFromRedisWorkflow GetDataFromRedisWorkflow,
+	storeDataInRedisWorkflow StoreDataInRedisWorkflow,
+	upsertRoute tidbrepository.UpsertRoute,
+	optimize vroom.Optimize,
+	postWebhook fuegoapiclient.PostWebhook,
+	obs observability.Observability,
+) ReoptimizeRoute {
+	return func(ctx context.Context, routeID string, input request.ReoptimizeRouteRequest) (request.UpsertRouteRequest, error) {
+		data, err := getDataFromRedisWorkflow(ctx, routeID)
+		if err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+		if data == nil {
+			return request.UpsertRouteRequest{}, ErrRouteNotFound
+		}
+		var current request.UpsertRouteRequest
+		if err := json.Unmarshal(data, &current); err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("error deserializando ruta: %w", err)
+		}
+
+		// Último estado de cada unidad según el historial escrito por RouteStarted/ConfirmDeliveries
+		var orderReferenceIDs []string
+		deliveryUnitsCount := 0
+		for _, visit := range current.Visits {
+			for _, order := range visit.Orders {
+				orderReferenceIDs = append(orderReferenceIDs, order.ReferenceID)
+				deliveryUnitsCount += len(order.DeliveryUnits)
+			}
+		}
+		statuses := make(map[string]map[string]string)
+		if len(orderReferenceIDs) > 0 {
+			last := max(100, deliveryUnitsCount)
+			results, _, err := findDeliveryUnitsProjectionResult(ctx, domain.DeliveryUnitsFilter{
+				Order: &domain.OrderFilter{
+					ReferenceIds: orderReferenceIDs,
+				},
+				RequestedFields: map[string]any{
+					projection.DeliveryUnit().String():    true,
+					projection.ReferenceID().String():     true,
+					projection.DeliveryUnitLPN().String(): true,
+					projection.Status().String():          true,
+				},
+				OnlyLatestStatus: true,
+				Pagination: domain.Pagination{
+					Last: &last,
+				},
+			})
+			if err != nil {
+				return request.UpsertRouteRequest{}, err
+			}
+			for _, result := range results {
+				if statuses[result.OrderReferenceID] == nil {
+					statuses[result.OrderReferenceID] = make(map[string]string)
+				}
+				statuses[result.OrderReferenceID][result.LPN] = result.Status
+			}
+		}
+		isClosed := closedRouteVisits(statuses)
+
+		// Las ventanas de la ruta se interpretan en su zona horaria y no en la del servidor
+		now := time.Now().In(input.Location(current))
+		fleetOptimization := input.Map(current, isClosed, now)
+		if len(fleetOptimization.Visits) == 0 {
+			return request.UpsertRouteRequest{}, ErrNoPendingVisits
+		}
+
+		routeRequests, err := optimize(ctx, fleetOptimization)
+		if err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to reoptimize route: %w", err)
+		}
+
+		// La optimización de un solo vehículo genera a lo más su ruta y la de órdenes sin asignar
+		var revision request.UpsertRouteRequest
+		var unassignedVisits []request.UpsertRouteVisit
+		for _, routeRequest := range routeRequests {
+			if routeRequest.Vehicle.Plate == "UNASSIGNED" {
+				unassignedVisits = append(unassignedVisits, routeRequest.Visits...)
+				continue
+			}
+			revision = routeRequest
+		}
+		revision.ReferenceID = current.ReferenceID
+		revision.PlanReferenceID = current.PlanReferenceID
+		revision.Revision = max(current.Revision, 1) + 1
+		revision.CreatedAt = now.UTC().Format(time.RFC3339)
+		if revision.Vehicle.Plate == "" {
+			revision.Vehicle = current.Vehicle
+		}
+		// Las visitas que ya no caben se mantienen en la ruta con su motivo para no perderlas
+		revision.Visits = append(revision.Visits, unassignedVisits...)
+
+		// Se conserva la revisión anterior antes de reemplazar la vigente
+		if err := storeDataInRedisWorkflow(ctx, routeRevisionKey(current.ReferenceID, max(current.Revision, 1)), data); err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+		revisionBytes, err := json.Marshal(revision)
+		if err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+		if err := storeDataInRedisWorkflow(ctx, routeRevisionKey(revision.ReferenceID, revision.Revision), revisionBytes); err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+		if err := storeDataInRedisWorkflow(ctx, revision.ReferenceID, revisionBytes); err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+
+		// La revisión vigente reemplaza el contrato guardado con la ruta, que es el que leen las
+		// consultas, y limpia la marca de reoptimización pendiente
+		route, err := revision.Map()
+		if err != nil {
+			return request.UpsertRouteRequest{}, err
+		}
+		planDoc := domain.Plan{ReferenceID: revision.PlanReferenceID}.DocID(ctx).String()
+		if err := upsertRoute(ctx, route, revision, planDoc); err != nil {
+			return request.UpsertRouteRequest{}, fmt.Errorf("failed to upsert route %s: %w", route.ReferenceID, err)
+		}
+
+		obs.Logger.InfoContext(ctx, "ROUTE_REOPTIMIZED",
+			"route", revision.ReferenceID,
+			"revision", revision.Revision,
+			"pendingVisits", len(fleetOptimization.Visits),
+			"unassignedVisits", len(unassignedVisits))
+
+		if err := postWebhook(ctx, request.FleetOptimizedWebhookBody{
+			Plan:      revision.PlanReferenceID,
+			Routes:    []string{revision.ReferenceID},
+			TotalCost: revision.Cost,
+		}, "fleet-optimized"); err != nil {
+			// La revisión ya quedó almacenada; reintentar crearía otra revisión
+			obs.Logger.ErrorContext(ctx, "Error publicando webhook", "error", err)
+		}
+		return revision, nil
+	}
+}
+
+// routeRevisionKey es la llave con la que se almacena cada revisión de una ruta
+func routeRevisionKey(routeReferenceID string, revision int) string {
+	return fmt.Sprintf("%s:revision:%d", routeReferenceID, revision)
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"testing"
+	"transport-app/app/domain"
+)
+
+func TestClosedRouteVisits(t *testing.T) {
+	isClosed := closedRouteVisits(map[string]map[string]string{
+		"ORDER-DELIVERED": {"LPN-1": domain.StatusFinished},
+		"ORDER-PARTIAL":   {"LPN-2": domain.StatusPartiallyDelivered, "LPN-3": domain.StatusInTransit},
+		"ORDER-CLOSED":    {"LPN-4": domain.StatusPartiallyDelivered, "LPN-5": domain.StatusCancelled},
+	})
+
+	cases := []struct {
+		order, lpn string
+		want       bool
+	}{
+		{"ORDER-DELIVERED", "LPN-1", true},
+		{"ORDER-PARTIAL", "LPN-2", true},
+		{"ORDER-PARTIAL", "LPN-3", false},
+		{"ORDER-PARTIAL", "", false},
+		{"ORDER-CLOSED", "", true},
+		{"ORDER-UNKNOWN", "LPN-6", false},
+	}
+	for _, c := range cases {
+		if got := isClosed(c.order, c.lpn); got != c.want {
+			t.Errorf("isClosed(%q, %q) = %v, want %v", c.order, c.lpn, got, c.want)
+		}
+	}
+}
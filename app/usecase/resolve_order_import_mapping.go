@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/agents"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/spreadsheet"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+const (
+	OrderImportMappingSourceRequest = "request"
+	OrderImportMappingSourceSaved   = "saved"
+	OrderImportMappingSourceAI      = "ai"
+)
+
+// ResolveOrderImportMapping propone el mapeo clave canónica -> cabecera para un archivo de
+// órdenes. Usa el mapeo guardado por el tenant si todas sus cabeceras están en el archivo y,
+// si no, lo pide al LLM a partir de las cabeceras y una fila de ejemplo
+type ResolveOrderImportMapping func(ctx context.Context, sheet spreadsheet.Sheet) (domain.OrderImportMapping, string, error)
+
+func init() {
+	ioc.Registry(
+		NewResolveOrderImportMapping,
+		tidbrepository.NewFindTenantOrderImportMapping,
+		agents.NewOrderFieldNamesNormalizer)
+}
+
+func NewResolveOrderImportMapping(
+	findTenantOrderImportMapping tidbrepository.FindTenantOrderImportMapping,
+	orderFieldNamesNormalizer agents.OrderFieldNamesNormalizer) ResolveOrderImportMapping {
+	return func(ctx context.Context, sheet spreadsheet.Sheet) (domain.OrderImportMapping, string, error) {
+		saved, err := findTenantOrderImportMapping(ctx)
+		if err != nil {
+			return domain.OrderImportMapping{}, "", err
+		}
+		if saved.AppliesTo(sheet.Headers) {
+			return saved, OrderImportMappingSourceSaved, nil
+		}
+
+		proposed, err := orderFieldNamesNormalizer(ctx, orderImportSample(sheet))
+		if err != nil {
+			return domain.OrderImportMapping{}, "", err
+		}
+		return SanitizeOrderImportMapping(proposed, sheet.Headers), OrderImportMappingSourceAI, nil
+	}
+}
+
+// SanitizeOrderImportMapping descarta claves que no son canónicas y cabeceras que no
+// existen en el archivo, para no confiar a ciegas en el mapeo recibido
+func SanitizeOrderImportMapping(columns map[string]string, headers []string) domain.OrderImportMapping {
+	sanitized := make(map[string]string, len(columns))
+	for key, header := range columns {
+		if !slices.Contains(request.OrderImportKeys, key) {
+			continue
+		}
+		for _, h := range headers {
+			if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(header)) {
+				sanitized[key] = h
+				break
+			}
+		}
+	}
+	return domain.OrderImportMapping{Columns: sanitized}
+}
+
+// orderImportSample arma el input del LLM: cada cabecera con el primer valor no vacío de su columna
+func orderImportSample(sheet spreadsheet.Sheet) map[string]string {
+	sample := make(map[string]string, len(sheet.Headers))
+	for i, header := range sheet.Headers {
+		if header == "" {
+			continue
+		}
+		sample[header] = ""
+		for _, row := range sheet.Rows {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				sample[header] = strings.TrimSpace(row[i])
+				break
+			}
+		}
+	}
+	return sample
+}
@@ -2,8 +2,11 @@ package usecase
 
 import (
 	"context"
+	"time"
+	"transport-app/app/adapter/out/natsbroadcaster"
 	"transport-app/app/adapter/out/tidbrepository"
 	"transport-app/app/domain"
+	"transport-app/app/shared/infrastructure/observability"
 	"transport-app/app/shared/projection/deliveryunits"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
@@ -15,13 +18,17 @@ func init() {
 	ioc.Registry(NewRouteStarted,
 		deliveryunits.NewProjection,
 		tidbrepository.NewFindDeliveryUnitsProjectionResult,
-		tidbrepository.NewUpsertDeliveryUnitsHistory)
+		tidbrepository.NewUpsertDeliveryUnitsHistory,
+		natsbroadcaster.NewPublishDeliveryUnitStatusChanges,
+		observability.NewObservability)
 }
 
 func NewRouteStarted(
 	projection deliveryunits.Projection,
 	findDeliveryUnitsProjectionResult tidbrepository.FindDeliveryUnitsProjectionResult,
-	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory) RouteStarted {
+	upsertDeliveryUnitsHistory tidbrepository.UpsertDeliveryUnitsHistory,
+	publishStatusChanges natsbroadcaster.PublishDeliveryUnitStatusChanges,
+	obs observability.Observability) RouteStarted {
 	return func(ctx context.Context, input domain.Route) error {
 		for i := range input.Orders {
 			needsHydration := false
@@ -58,24 +65,30 @@ func NewRouteStarted(
 				for _, deliveryUnit := range results {
 					if deliveryUnit.OrderReferenceID == input.Orders[i].ReferenceID.String() {
 						input.Orders[i].DeliveryUnits = append(input.Orders[i].DeliveryUnits, domain.DeliveryUnit{
-							Status: domain.Status{Status: domain.StatusInTransit},
-							Lpn:    deliveryUnit.LPN,
-							Items:  deliveryUnit.JSONItems.Map(),
+							Lpn:   deliveryUnit.LPN,
+							Items: deliveryUnit.JSONItems.Map(),
 						})
 					}
 				}
-			} else {
-				// Si ya tiene LPN y SKUs, solo actualizamos el estado y ConfirmDelivery
-				for j := range input.Orders[i].DeliveryUnits {
-					input.Orders[i].DeliveryUnits[j].Status = domain.Status{Status: domain.StatusInTransit}
-				}
 			}
 
 			input.Orders[i].AssignIndexesIfNoLPN()
 
 		}
-		return upsertDeliveryUnitsHistory(ctx, domain.Plan{
+
+		// Las unidades canceladas o finalizadas no pueden salir a ruta
+		statuses, err := currentDeliveryUnitStatuses(ctx, projection, findDeliveryUnitsProjectionResult, input.Orders)
+		if err != nil {
+			return err
+		}
+		logSkippedTransitions(ctx, obs, transitionDeliveryUnits(ctx, &input, statuses, fixedStatus(domain.StatusInTransit)))
+		if err := upsertDeliveryUnitsHistory(ctx, domain.Plan{
 			Routes: []domain.Route{input},
-		})
+		}); err != nil {
+			return err
+		}
+
+		publishStatusChanges(ctx, domain.DeliveryUnitStatusChangesFromRoute(input, time.Now()))
+		return nil
 	}
 }
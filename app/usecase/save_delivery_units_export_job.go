@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/google/uuid"
+)
+
+type SaveDeliveryUnitsExportJob func(ctx context.Context, job domain.DeliveryUnitsExportJob) error
+
+func init() {
+	ioc.Registry(
+		NewSaveDeliveryUnitsExportJob,
+		NewStoreDataInRedisWorkflow,
+	)
+}
+
+func NewSaveDeliveryUnitsExportJob(storeDataInRedisWorkflow StoreDataInRedisWorkflow) SaveDeliveryUnitsExportJob {
+	return func(ctx context.Context, job domain.DeliveryUnitsExportJob) error {
+		tenantID := sharedcontext.TenantIDFromContext(ctx)
+		if tenantID == uuid.Nil {
+			return ErrTenantRequired
+		}
+		job.TenantID = tenantID
+		bytes, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return storeDataInRedisWorkflow(ctx, deliveryUnitsExportJobKey(tenantID, job.ID), bytes)
+	}
+}
+
+// deliveryUnitsExportJobKey es la llave de cache con la que se almacena cada exportación. Incluye
+// el tenant para que un job solo pueda consultarse desde el tenant que lo solicitó
+func deliveryUnitsExportJobKey(tenantID uuid.UUID, jobID string) string {
+	return "delivery_units_export_job:" + tenantID.String() + ":" + jobID
+}
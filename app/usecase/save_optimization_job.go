@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"transport-app/app/domain"
+	"transport-app/app/shared/sharedcontext"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type SaveOptimizationJob func(ctx context.Context, job domain.OptimizationJob) error
+
+func init() {
+	ioc.Registry(
+		NewSaveOptimizationJob,
+		NewStoreDataInRedisWorkflow,
+	)
+}
+
+func NewSaveOptimizationJob(storeDataInRedisWorkflow StoreDataInRedisWorkflow) SaveOptimizationJob {
+	return func(ctx context.Context, job domain.OptimizationJob) error {
+		bytes, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return storeDataInRedisWorkflow(ctx, optimizationJobKey(ctx, job.ID), bytes)
+	}
+}
+
+// optimizationJobKey es la llave de cache con la que se almacena cada job; incluye el tenant para
+// que un jobId conocido no permita leer la optimización de otro tenant
+func optimizationJobKey(ctx context.Context, jobID string) string {
+	return "optimization_job:" + sharedcontext.TenantIDFromContext(ctx).String() + ":" + jobID
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"transport-app/app/adapter/out/cacherepository"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/configuration"
+	"transport-app/app/shared/infrastructure/observability"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+)
+
+type TrackDriverLocations func(ctx context.Context, locations []domain.DriverLocation) error
+
+func init() {
+	ioc.Registry(
+		NewTrackDriverLocations,
+		cacherepository.NewDriverPositionCacheStrategy,
+		tidbrepository.NewSaveRouteBreadcrumbs,
+		configuration.NewConf,
+		observability.NewObservability)
+}
+
+func NewTrackDriverLocations(
+	positions cacherepository.DriverPositionCacheStrategy,
+	saveRouteBreadcrumbs tidbrepository.SaveRouteBreadcrumbs,
+	conf configuration.Conf,
+	obs observability.Observability) TrackDriverLocations {
+	return func(ctx context.Context, locations []domain.DriverLocation) error {
+		valid := make([]domain.DriverLocation, 0, len(locations))
+		for _, location := range locations {
+			if err := location.Validate(); err != nil {
+				obs.Logger.WarnContext(ctx, "Descartando ubicación inválida del conductor",
+					"routeReferenceID", location.RouteReferenceID,
+					"error", err)
+				continue
+			}
+			valid = append(valid, location)
+		}
+		if len(valid) == 0 {
+			return nil
+		}
+
+		if err := positions.Save(ctx, valid); err != nil {
+			return err
+		}
+
+		window := time.Duration(conf.DRIVER_BREADCRUMB_INTERVAL_SECONDS) * time.Second
+		return saveRouteBreadcrumbs(ctx, domain.DownsampleDriverLocations(valid, window))
+	}
+}
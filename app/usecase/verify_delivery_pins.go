@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
+	"transport-app/app/shared/apperrors"
+	"transport-app/app/shared/configuration"
+
+	ioc "github.com/Ignaciojeria/einar-ioc/v2"
+	"github.com/cockroachdb/errors"
+)
+
+// VerifyDeliveryPins valida el PIN informado en cada unidad entregada y deja el resultado en
+// su ConfirmDelivery. Retorna ErrDeliveryPinRejected si alguna orden con política de rechazo
+// no presentó el PIN correcto y ErrDeliveryPinLocked si alguna superó el límite de intentos
+type VerifyDeliveryPins func(ctx context.Context, route domain.Route) (domain.Route, error)
+
+func init() {
+	ioc.Registry(
+		NewVerifyDeliveryPins,
+		tidbrepository.NewFindOrderDeliveryPins,
+		tidbrepository.NewRecordDeliveryPinAttempt,
+		configuration.NewConf)
+}
+
+func NewVerifyDeliveryPins(
+	findOrderDeliveryPins tidbrepository.FindOrderDeliveryPins,
+	recordDeliveryPinAttempt tidbrepository.RecordDeliveryPinAttempt,
+	conf configuration.Conf) VerifyDeliveryPins {
+	pinKey := []byte(conf.DELIVERY_PIN_HMAC_KEY)
+	return func(ctx context.Context, route domain.Route) (domain.Route, error) {
+		pins, err := findOrderDeliveryPins(ctx, route.Orders)
+		if err != nil {
+			return route, err
+		}
+
+		now := time.Now()
+		rejected := make([]string, 0)
+		locked := make([]string, 0)
+		for i := range route.Orders {
+			order := route.Orders[i]
+			current, ok := pins[order.DocID(ctx)]
+			if !ok {
+				continue
+			}
+			order.OrderType = current.OrderType
+			if current.PinHash != "" && len(pinKey) == 0 {
+				return route, domain.ErrDeliveryPinKeyMissing
+			}
+			orderLocked := current.Attempts.IsLocked(now)
+			orderRejected := false
+			countsAsAttempt := false
+			for j := range route.Orders[i].DeliveryUnits {
+				confirm := &route.Orders[i].DeliveryUnits[j].ConfirmDelivery
+				// Un intento fallido no entrega nada, por lo que no exige PIN
+				if !confirm.NonDeliveryReason.IsEmpty() {
+					confirm.PinVerification = domain.DeliveryPinVerification{Policy: current.OrderType.DeliveryPinPolicy}
+				} else {
+					confirm.PinVerification = order.VerifyDeliveryPin(ctx, pinKey, current.PinHash, confirm.DeliveryPin)
+				}
+				if orderLocked {
+					confirm.PinVerification = confirm.PinVerification.LockedOut()
+				}
+				confirm.DeliveryPin = ""
+				orderRejected = orderRejected || confirm.PinVerification.Rejected()
+				countsAsAttempt = countsAsAttempt || confirm.PinVerification.CountsAsAttempt()
+			}
+			// Cada envío cuenta como un único intento por orden, sin importar cuántas unidades traiga
+			if countsAsAttempt {
+				if err := recordDeliveryPinAttempt(ctx, order, !orderRejected, now); err != nil {
+					return route, err
+				}
+			}
+			switch {
+			case orderLocked && orderRejected:
+				locked = append(locked, order.ReferenceID.String())
+			case orderRejected:
+				rejected = append(rejected, order.ReferenceID.String())
+			}
+		}
+
+		if len(locked) > 0 {
+			return route, apperrors.MarkAsAlertable(errors.Wrapf(
+				domain.ErrDeliveryPinLocked,
+				"orders: %s",
+				strings.Join(locked, ", ")))
+		}
+
+		if len(rejected) > 0 {
+			return route, apperrors.MarkAsAlertable(errors.Wrapf(
+				domain.ErrDeliveryPinRejected,
+				"orders: %s",
+				strings.Join(rejected, ", ")))
+		}
+		return route, nil
+	}
+}
@@ -3,47 +3,88 @@ package workers
 import (
 	"context"
 	"fmt"
-	"transport-app/app/adapter/out/fuegoapiclient"
-	"transport-app/app/adapter/out/vroom"
+	"time"
+	"transport-app/app/adapter/in/fuegoapi/request"
+	"transport-app/app/adapter/out/tidbrepository"
+	"transport-app/app/domain"
 	"transport-app/app/domain/optimization"
+	"transport-app/app/shared/infrastructure/observability"
+	"transport-app/app/usecase"
 
 	ioc "github.com/Ignaciojeria/einar-ioc/v2"
 )
 
 type FleetOptimizer func(
 	ctx context.Context,
-	input optimization.FleetOptimization) error
+	input optimization.FleetOptimization) ([]request.UpsertRouteRequest, error)
 
 func init() {
 	ioc.Registry(
 		NewFleetOptimizer,
-		vroom.NewOptimize,
-		fuegoapiclient.NewPostUpsertRoute,
+		usecase.NewOptimizeFleetWorkflow,
+		tidbrepository.NewUpsertOptimizedPlan,
+		usecase.NewPlanDeliveryUnits,
+		observability.NewObservability,
 	)
 }
 
-func NewFleetOptimizer(
-	optimize vroom.Optimize,
-	postUpsertRoute fuegoapiclient.PostUpsertRoute) FleetOptimizer {
-	return func(ctx context.Context, input optimization.FleetOptimization) error {
+// unassignedRoutePlate identifica la ruta con las órdenes que la optimización no asignó
+const unassignedRoutePlate = "UNASSIGNED"
 
-		routeRequests, err := optimize(ctx, input)
+func NewFleetOptimizer(
+	optimizeFleetWorkflow usecase.OptimizeFleetWorkflow,
+	upsertOptimizedPlan tidbrepository.UpsertOptimizedPlan,
+	planDeliveryUnits usecase.PlanDeliveryUnits,
+	obs observability.Observability) FleetOptimizer {
+	return func(ctx context.Context, input optimization.FleetOptimization) ([]request.UpsertRouteRequest, error) {
+		routeRequests, err := optimizeFleetWorkflow(ctx, input)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if len(routeRequests) == 0 {
+			return routeRequests, nil
 		}
 
-		// Procesar cada ruta individualmente
+		plan := domain.Plan{
+			ReferenceID: routeRequests[0].PlanReferenceID,
+			PlannedDate: input.PlannedDate,
+		}
+		if plan.PlannedDate.IsZero() {
+			now := time.Now()
+			plan.PlannedDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		}
+
+		routes := make([]domain.Route, len(routeRequests))
 		for i, routeRequest := range routeRequests {
-			// Convertir UpsertRouteRequest a domain.Route para usar con postUpsertRoute
-			// Por ahora, como postUpsertRoute espera domain.Route, necesitamos convertir
-			// Esto es temporal hasta que se actualice postUpsertRoute para usar UpsertRouteRequest directamente
-			fmt.Printf("Procesando ruta %d de %d: %s\n", i+1, len(routeRequests), routeRequest.ReferenceID)
+			route, err := routeRequest.Map()
+			if err != nil {
+				return nil, fmt.Errorf("failed to map route %s: %w", routeRequest.ReferenceID, err)
+			}
+			if routeRequest.Vehicle.Plate == unassignedRoutePlate {
+				plan.UnassignedOrders = append(plan.UnassignedOrders, route.Orders...)
+			} else {
+				// Cada unidad asignada queda planificada en su ruta
+				if err := planDeliveryUnits(ctx, &route); err != nil {
+					return nil, fmt.Errorf("failed to plan delivery units of route %s: %w", route.ReferenceID, err)
+				}
+				plan.Routes = append(plan.Routes, route)
+			}
+			routes[i] = route
+		}
 
-			// TODO: Implementar conversión de UpsertRouteRequest a domain.Route
-			// o actualizar postUpsertRoute para usar UpsertRouteRequest directamente
+		// La ruta de no asignadas también se guarda para conservar los motivos en su contrato
+		contracts := make([]interface{}, len(routeRequests))
+		for i, routeRequest := range routeRequests {
+			contracts[i] = routeRequest
+		}
+		if err := upsertOptimizedPlan(ctx, plan, routes, contracts); err != nil {
+			return nil, err
 		}
 
-		fmt.Printf("Optimización completada. Se procesaron %d rutas.\n", len(routeRequests))
-		return nil
+		obs.Logger.InfoContext(ctx, "FLEET_OPTIMIZATION_PERSISTED",
+			"plan", plan.ReferenceID,
+			"routes", len(plan.Routes),
+			"unassignedOrders", len(plan.UnassignedOrders))
+		return routeRequests, nil
 	}
 }